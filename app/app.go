@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -17,7 +20,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/100bench/avito_tech_assignment_autumn_2025/deployment/config"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/auth"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/metrics"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/notify"
 	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/storage/postgres"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/tracing"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/webhook"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
 	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
 	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
 )
@@ -25,25 +34,148 @@ import (
 func RunApp() error {
 	cfg := config.Load()
 
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return errors.Wrap(err, "newLogger")
+	}
+	// вывод пакета log и сторонних библиотек тоже уходит в JSON
+	slog.SetDefault(logger)
+
 	if err := runMigrations(cfg.PostgresDSN()); err != nil {
 		return errors.Wrap(err, "failed to run migrations")
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	ctx = entities.ContextWithLogger(ctx, logger)
+
+	tracerProvider, err := tracing.NewProvider(ctx, tracing.Config{
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingEndpoint,
+		File:        cfg.TracingFile,
+		SampleRatio: cfg.TracingSampleRatio,
+		ServiceName: "pr-review-service",
+	})
+	if err != nil {
+		return errors.Wrap(err, "tracing.NewProvider")
+	}
+	var clientOpts []postgres.ClientOption
+	if tracerProvider != nil {
+		defer func() {
+			// отправляем спаны, накопленные к остановке
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer shutdownCancel()
+			if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+				logger.Error("tracer provider shutdown failed", "error", err)
+			}
+		}()
+		clientOpts = append(clientOpts, postgres.WithQueryTracing(tracerProvider))
+	}
 
-	storage, err := postgres.NewPgxClient(ctx, cfg.PostgresDSN())
+	storage, err := postgres.NewPgxClient(ctx, cfg.PostgresDSN(), clientOpts...)
 	if err != nil {
 		return errors.Wrap(err, "postgres.NewPgxClient")
 	}
 
-	service, err := usecases.NewServiceStorage(storage)
+	selectors, err := usecases.NewReviewerSelectors(cfg.ReviewerStrategy, cfg.TeamReviewerStrategies, cfg.ReviewerWeights)
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "usecases.NewReviewerSelectors")
+	}
+
+	serviceOpts := []usecases.Option{
+		usecases.WithReviewerSelectors(selectors),
+		usecases.WithMergePolicy(usecases.MergePolicy{
+			RequiredApprovals:       cfg.RequiredApprovals,
+			BlockOnChangesRequested: cfg.BlockOnChangesRequested,
+		}),
+	}
+	serverOpts := []public.ServerOption{
+		public.WithLogger(logger),
+		public.WithGitWebhookSecrets(cfg.GitHubWebhookSecret, cfg.GitLabWebhookSecret),
+	}
+	// интерфейс остается nil при выключенных метриках, фоновые задачи проверяют его на nil
+	var businessMetrics usecases.Metrics
+	if cfg.MetricsEnabled {
+		m, err := newMetrics(storage)
+		if err != nil {
+			storage.Close()
+			return errors.Wrap(err, "newMetrics")
+		}
+		businessMetrics = m
+		serviceOpts = append(serviceOpts, usecases.WithMetrics(m))
+		serverOpts = append(serverOpts, public.WithMetrics(m))
+	}
+
+	service, err := usecases.NewServiceStorage(storage, serviceOpts...)
 	if err != nil {
 		storage.Close()
 		return errors.Wrap(err, "usecases.NewServiceStorage")
 	}
 
-	server, err := public.NewServer(service)
+	dispatcher, err := usecases.NewWebhookDispatcher(storage, webhook.NewHTTPSender(cfg.WebhookRequestTimeout),
+		usecases.WebhookDispatcherConfig{
+			PollInterval: cfg.WebhookPollInterval,
+			MaxAttempts:  cfg.WebhookMaxAttempts,
+			BaseBackoff:  cfg.WebhookBaseBackoff,
+			MaxBackoff:   cfg.WebhookMaxBackoff,
+			SendTimeout:  cfg.WebhookRequestTimeout,
+		})
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "usecases.NewWebhookDispatcher")
+	}
+
+	availability, err := usecases.NewAvailabilityScheduler(storage, selectors,
+		usecases.AvailabilitySchedulerConfig{PollInterval: cfg.AvailabilityPollInterval, Metrics: businessMetrics})
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "usecases.NewAvailabilityScheduler")
+	}
+
+	reviewSLA, err := usecases.NewReviewSLAScheduler(storage, selectors,
+		usecases.ReviewSLASchedulerConfig{PollInterval: cfg.ReviewSLAPollInterval, Metrics: businessMetrics})
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "usecases.NewReviewSLAScheduler")
+	}
+
+	notifier, notifierCloser, err := newDigestNotifier(cfg)
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "newDigestNotifier")
+	}
+	if notifierCloser != nil {
+		defer func() { _ = notifierCloser.Close() }()
+	}
+	var digests *usecases.DigestScheduler
+	if notifier != nil {
+		digests, err = usecases.NewDigestScheduler(storage, notifier,
+			usecases.DigestSchedulerConfig{PollInterval: cfg.DigestPollInterval, SendTime: cfg.DigestSendTime})
+		if err != nil {
+			storage.Close()
+			return errors.Wrap(err, "usecases.NewDigestScheduler")
+		}
+	}
+
+	jwtVerifier, err := newJWTVerifier(cfg)
+	if err != nil {
+		storage.Close()
+		return errors.Wrap(err, "newJWTVerifier")
+	}
+
+	var api public.PRReviewService = service
+	if tracerProvider != nil {
+		api, err = usecases.NewTracedService(service, tracerProvider)
+		if err != nil {
+			storage.Close()
+			return errors.Wrap(err, "usecases.NewTracedService")
+		}
+		serverOpts = append(serverOpts, public.WithTracing(tracerProvider))
+	}
+
+	serverOpts = append(serverOpts, public.WithAuth(public.AuthConfig{AdminToken: cfg.AuthAdminToken, JWT: jwtVerifier}))
+	server, err := public.NewServer(api, serverOpts...)
 	if err != nil {
 		storage.Close()
 		return errors.Wrap(err, "public.NewServer")
@@ -54,12 +186,38 @@ func RunApp() error {
 		Handler: server.GetRouter(),
 	}
 
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		dispatcher.Run(ctx)
+	}()
+
+	availabilityDone := make(chan struct{})
+	go func() {
+		defer close(availabilityDone)
+		availability.Run(ctx)
+	}()
+
+	reviewSLADone := make(chan struct{})
+	go func() {
+		defer close(reviewSLADone)
+		reviewSLA.Run(ctx)
+	}()
+
+	digestsDone := make(chan struct{})
+	go func() {
+		defer close(digestsDone)
+		if digests != nil {
+			digests.Run(ctx)
+		}
+	}()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
 	errChan := make(chan error, 1)
 	go func() {
-		log.Printf("Starting HTTP server on %s", cfg.HTTPAddr)
+		logger.Info("starting HTTP server", "addr", cfg.HTTPAddr)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			errChan <- err
 		}
@@ -68,10 +226,14 @@ func RunApp() error {
 	select {
 	case err := <-errChan:
 		cancel()
+		<-dispatcherDone
+		<-availabilityDone
+		<-reviewSLADone
+		<-digestsDone
 		storage.Close()
 		return errors.Wrap(err, "http server error")
 	case sig := <-stop:
-		log.Printf("Received signal: %v. Starting graceful shutdown...", sig)
+		logger.Info("received signal, starting graceful shutdown", "signal", sig.String())
 
 		// Отменяем основной контекст
 		cancel()
@@ -81,27 +243,103 @@ func RunApp() error {
 		defer shutdownCancel()
 
 		// Останавливаем HTTP сервер
-		log.Println("Shutting down HTTP server...")
+		logger.Info("shutting down HTTP server")
 		if err := httpServer.Shutdown(shutdownCtx); err != nil {
-			log.Printf("HTTP server shutdown error: %v", err)
+			logger.Error("HTTP server shutdown failed", "error", err)
 			storage.Close()
 			return errors.Wrap(err, "server shutdown failed")
 		}
-		log.Println("HTTP server stopped")
+		logger.Info("HTTP server stopped")
+
+		// Дожидаемся текущей пачки доставок webhook, переназначений, эскалаций и дайджестов
+		<-dispatcherDone
+		<-availabilityDone
+		<-reviewSLADone
+		<-digestsDone
 
 		// Даём время на завершение активных операций с БД
 		time.Sleep(100 * time.Millisecond)
 
 		// Закрываем соединение с БД
-		log.Println("Closing database connection...")
+		logger.Info("closing database connection")
 		storage.Close()
-		log.Println("Database connection closed")
+		logger.Info("database connection closed")
 
-		log.Println("Graceful shutdown completed")
+		logger.Info("graceful shutdown completed")
 		return nil
 	}
 }
 
+// newLogger создает JSON-логгер с уровнем из конфигурации: debug, info, warn или error
+func newLogger(cfg *config.Config) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		return nil, fmt.Errorf("unknown log level '%s'", cfg.LogLevel)
+	}
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})), nil
+}
+
+// newJWTVerifier возвращает nil интерфейс, если ключ JWT не настроен
+func newJWTVerifier(cfg *config.Config) (public.TokenVerifier, error) {
+	switch {
+	case cfg.AuthJWTPublicKeyFile != "":
+		pemData, err := os.ReadFile(cfg.AuthJWTPublicKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read jwt public key")
+		}
+		return auth.NewRS256Verifier(pemData)
+	case cfg.AuthJWTSecret != "":
+		return auth.NewHS256Verifier([]byte(cfg.AuthJWTSecret))
+	default:
+		return nil, nil
+	}
+}
+
+// newMetrics создает реестр метрик со статистикой пула соединений и бизнес-метриками из базы
+func newMetrics(storage *postgres.PgxStorage) (*metrics.Metrics, error) {
+	m := metrics.New()
+	if err := m.Register(metrics.NewPoolCollector(storage.PoolStat)); err != nil {
+		return nil, errors.Wrap(err, "register pool collector")
+	}
+	if err := m.Register(metrics.NewBusinessCollector(storage, 5*time.Second)); err != nil {
+		return nil, errors.Wrap(err, "register business collector")
+	}
+	return m, nil
+}
+
+// newDigestNotifier возвращает nil интерфейс, если дайджесты отключены, и файл, который нужно закрыть при остановке
+func newDigestNotifier(cfg *config.Config) (usecases.Notifier, io.Closer, error) {
+	switch cfg.DigestNotifier {
+	case "log":
+		return notify.NewWriterNotifier(log.Writer()), nil, nil
+	case "file":
+		file, err := os.OpenFile(cfg.DigestFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "open digest file")
+		}
+		return notify.NewWriterNotifier(file), file, nil
+	case "webhook":
+		if cfg.DigestWebhookURL == "" {
+			return nil, nil, errors.New("digest_webhook_url is required")
+		}
+		return notify.NewWebhookNotifier(cfg.DigestWebhookURL, cfg.DigestWebhookSecret, cfg.WebhookRequestTimeout), nil, nil
+	case "smtp":
+		if cfg.DigestSMTPAddr == "" || cfg.DigestSMTPFrom == "" {
+			return nil, nil, errors.New("digest_smtp_addr and digest_smtp_from are required")
+		}
+		return notify.NewSMTPNotifier(notify.SMTPConfig{
+			Addr:     cfg.DigestSMTPAddr,
+			From:     cfg.DigestSMTPFrom,
+			Username: cfg.DigestSMTPUsername,
+			Password: cfg.DigestSMTPPassword,
+		}), nil, nil
+	case "none", "":
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown digest notifier '%s'", cfg.DigestNotifier)
+	}
+}
+
 func runMigrations(dsn string) error {
 	m, err := migrate.New(
 		"file://deployment/migrations/postgres",
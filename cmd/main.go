@@ -1,13 +1,17 @@
 package main
 
 import (
-	"log"
+	"log/slog"
+	"os"
+	// часовые пояса пользователей из настроек уведомлений, в образе alpine нет tzdata
+	_ "time/tzdata"
 
 	"github.com/100bench/avito_tech_assignment_autumn_2025/app"
 )
 
 func main() {
 	if err := app.RunApp(); err != nil {
-		log.Fatalf("Application failed: %v", err)
+		slog.Error("application failed", "error", err)
+		os.Exit(1)
 	}
 }
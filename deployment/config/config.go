@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -16,6 +17,62 @@ type Config struct {
 	PostgresDB       string `yaml:"postgres_db"`
 	HTTPAddr         string `yaml:"http_addr"`
 	ShutdownTimeout  time.Duration
+
+	// Уровень логов: debug, info, warn или error
+	LogLevel string `yaml:"log_level"`
+
+	// Стратегия выбора ревьюверов: random, least_loaded, round_robin, weighted
+	ReviewerStrategy       string            `yaml:"reviewer_strategy"`
+	TeamReviewerStrategies map[string]string `yaml:"team_reviewer_strategies"`
+	ReviewerWeights        map[string]int    `yaml:"reviewer_weights"`
+
+	// Условия мержа: 0 и false отключают проверку вердиктов
+	RequiredApprovals       int  `yaml:"required_approvals"`
+	BlockOnChangesRequested bool `yaml:"block_on_changes_requested"`
+
+	// Доставка исходящих webhook
+	WebhookPollInterval   time.Duration `yaml:"webhook_poll_interval"`
+	WebhookMaxAttempts    int           `yaml:"webhook_max_attempts"`
+	WebhookBaseBackoff    time.Duration `yaml:"webhook_base_backoff"`
+	WebhookMaxBackoff     time.Duration `yaml:"webhook_max_backoff"`
+	WebhookRequestTimeout time.Duration `yaml:"webhook_request_timeout"`
+
+	// Как часто проверять начавшиеся периоды недоступности ревьюверов
+	AvailabilityPollInterval time.Duration `yaml:"availability_poll_interval"`
+
+	// Как часто искать назначения с истекшим SLA команды
+	ReviewSLAPollInterval time.Duration `yaml:"review_sla_poll_interval"`
+
+	// Ежедневные дайджесты открытых ревью: log, file, webhook, smtp или none
+	DigestNotifier     string        `yaml:"digest_notifier"`
+	DigestPollInterval time.Duration `yaml:"digest_poll_interval"`
+	// DigestSendTime "HH:MM" местного времени отправки для пользователей без своего digest_time
+	DigestSendTime      string `yaml:"digest_send_time"`
+	DigestFile          string `yaml:"digest_file"`
+	DigestWebhookURL    string `yaml:"digest_webhook_url"`
+	DigestWebhookSecret string `yaml:"digest_webhook_secret"`
+	DigestSMTPAddr      string `yaml:"digest_smtp_addr"`
+	DigestSMTPFrom      string `yaml:"digest_smtp_from"`
+	DigestSMTPUsername  string `yaml:"digest_smtp_username"`
+	DigestSMTPPassword  string `yaml:"digest_smtp_password"`
+
+	// Метрики Prometheus на /metrics
+	MetricsEnabled bool `yaml:"metrics_enabled"`
+
+	// Трассировка OpenTelemetry: none, stdout, file или otlp
+	TracingExporter    string  `yaml:"tracing_exporter"`
+	TracingEndpoint    string  `yaml:"tracing_endpoint"`
+	TracingFile        string  `yaml:"tracing_file"`
+	TracingSampleRatio float64 `yaml:"tracing_sample_ratio"`
+
+	// Секреты входящих webhook git-хостингов, пустое значение отключает эндпоинт
+	GitHubWebhookSecret string `yaml:"github_webhook_secret"`
+	GitLabWebhookSecret string `yaml:"gitlab_webhook_secret"`
+
+	// Аутентификация выключена, пока не задан ни токен администратора, ни ключ JWT
+	AuthAdminToken       string `yaml:"auth_admin_token"`
+	AuthJWTSecret        string `yaml:"auth_jwt_secret"`
+	AuthJWTPublicKeyFile string `yaml:"auth_jwt_public_key_file"`
 }
 
 func Load() *Config {
@@ -27,6 +84,27 @@ func Load() *Config {
 		PostgresDB:       "pr_review_db",
 		HTTPAddr:         ":8080",
 		ShutdownTimeout:  5 * time.Second,
+		LogLevel:         "info",
+		ReviewerStrategy: "least_loaded",
+
+		WebhookPollInterval:   time.Second,
+		WebhookMaxAttempts:    8,
+		WebhookBaseBackoff:    time.Second,
+		WebhookMaxBackoff:     10 * time.Minute,
+		WebhookRequestTimeout: 5 * time.Second,
+
+		AvailabilityPollInterval: time.Minute,
+		ReviewSLAPollInterval:    time.Minute,
+
+		DigestNotifier:     "log",
+		DigestPollInterval: 15 * time.Minute,
+		DigestSendTime:     "09:00",
+		DigestSMTPFrom:     "pr-reviewer@localhost",
+
+		MetricsEnabled: true,
+
+		TracingExporter:    "none",
+		TracingSampleRatio: 1,
 	}
 
 	if data, err := os.ReadFile("deployment/config/config.yaml"); err == nil {
@@ -51,6 +129,80 @@ func Load() *Config {
 	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
 		cfg.HTTPAddr = addr
 	}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		cfg.LogLevel = level
+	}
+	if strategy := os.Getenv("REVIEWER_STRATEGY"); strategy != "" {
+		cfg.ReviewerStrategy = strategy
+	}
+
+	if approvals := os.Getenv("REQUIRED_APPROVALS"); approvals != "" {
+		if parsed, err := strconv.Atoi(approvals); err == nil {
+			cfg.RequiredApprovals = parsed
+		}
+	}
+	if block := os.Getenv("BLOCK_ON_CHANGES_REQUESTED"); block != "" {
+		if parsed, err := strconv.ParseBool(block); err == nil {
+			cfg.BlockOnChangesRequested = parsed
+		}
+	}
+
+	if attempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); attempts != "" {
+		if parsed, err := strconv.Atoi(attempts); err == nil {
+			cfg.WebhookMaxAttempts = parsed
+		}
+	}
+
+	if enabled := os.Getenv("METRICS_ENABLED"); enabled != "" {
+		if parsed, err := strconv.ParseBool(enabled); err == nil {
+			cfg.MetricsEnabled = parsed
+		}
+	}
+
+	if exporter := os.Getenv("TRACING_EXPORTER"); exporter != "" {
+		cfg.TracingExporter = exporter
+	}
+	if endpoint := os.Getenv("TRACING_ENDPOINT"); endpoint != "" {
+		cfg.TracingEndpoint = endpoint
+	}
+	if ratio := os.Getenv("TRACING_SAMPLE_RATIO"); ratio != "" {
+		if parsed, err := strconv.ParseFloat(ratio, 64); err == nil {
+			cfg.TracingSampleRatio = parsed
+		}
+	}
+
+	if secret := os.Getenv("GITHUB_WEBHOOK_SECRET"); secret != "" {
+		cfg.GitHubWebhookSecret = secret
+	}
+	if secret := os.Getenv("GITLAB_WEBHOOK_SECRET"); secret != "" {
+		cfg.GitLabWebhookSecret = secret
+	}
+
+	if notifier := os.Getenv("DIGEST_NOTIFIER"); notifier != "" {
+		cfg.DigestNotifier = notifier
+	}
+	if sendTime := os.Getenv("DIGEST_SEND_TIME"); sendTime != "" {
+		cfg.DigestSendTime = sendTime
+	}
+	if secret := os.Getenv("DIGEST_WEBHOOK_SECRET"); secret != "" {
+		cfg.DigestWebhookSecret = secret
+	}
+	if addr := os.Getenv("DIGEST_SMTP_ADDR"); addr != "" {
+		cfg.DigestSMTPAddr = addr
+	}
+	if password := os.Getenv("DIGEST_SMTP_PASSWORD"); password != "" {
+		cfg.DigestSMTPPassword = password
+	}
+
+	if token := os.Getenv("AUTH_ADMIN_TOKEN"); token != "" {
+		cfg.AuthAdminToken = token
+	}
+	if secret := os.Getenv("AUTH_JWT_SECRET"); secret != "" {
+		cfg.AuthJWTSecret = secret
+	}
+	if keyFile := os.Getenv("AUTH_JWT_PUBLIC_KEY_FILE"); keyFile != "" {
+		cfg.AuthJWTPublicKeyFile = keyFile
+	}
 
 	shutdownTimeout := 30 * time.Second
 	if timeoutStr := os.Getenv("SHUTDOWN_TIMEOUT"); timeoutStr != "" {
@@ -60,13 +212,54 @@ func Load() *Config {
 	}
 
 	return &Config{
-		PostgresHost:     cfg.PostgresHost,
-		PostgresPort:     cfg.PostgresPort,
-		PostgresUser:     cfg.PostgresUser,
-		PostgresPassword: cfg.PostgresPassword,
-		PostgresDB:       cfg.PostgresDB,
-		HTTPAddr:         cfg.HTTPAddr,
-		ShutdownTimeout:  shutdownTimeout,
+		PostgresHost:           cfg.PostgresHost,
+		PostgresPort:           cfg.PostgresPort,
+		PostgresUser:           cfg.PostgresUser,
+		PostgresPassword:       cfg.PostgresPassword,
+		PostgresDB:             cfg.PostgresDB,
+		HTTPAddr:               cfg.HTTPAddr,
+		ShutdownTimeout:        shutdownTimeout,
+		LogLevel:               cfg.LogLevel,
+		ReviewerStrategy:       cfg.ReviewerStrategy,
+		TeamReviewerStrategies: cfg.TeamReviewerStrategies,
+		ReviewerWeights:        cfg.ReviewerWeights,
+
+		RequiredApprovals:       cfg.RequiredApprovals,
+		BlockOnChangesRequested: cfg.BlockOnChangesRequested,
+
+		WebhookPollInterval:   cfg.WebhookPollInterval,
+		WebhookMaxAttempts:    cfg.WebhookMaxAttempts,
+		WebhookBaseBackoff:    cfg.WebhookBaseBackoff,
+		WebhookMaxBackoff:     cfg.WebhookMaxBackoff,
+		WebhookRequestTimeout: cfg.WebhookRequestTimeout,
+
+		AvailabilityPollInterval: cfg.AvailabilityPollInterval,
+		ReviewSLAPollInterval:    cfg.ReviewSLAPollInterval,
+
+		DigestNotifier:      cfg.DigestNotifier,
+		DigestPollInterval:  cfg.DigestPollInterval,
+		DigestSendTime:      cfg.DigestSendTime,
+		DigestFile:          cfg.DigestFile,
+		DigestWebhookURL:    cfg.DigestWebhookURL,
+		DigestWebhookSecret: cfg.DigestWebhookSecret,
+		DigestSMTPAddr:      cfg.DigestSMTPAddr,
+		DigestSMTPFrom:      cfg.DigestSMTPFrom,
+		DigestSMTPUsername:  cfg.DigestSMTPUsername,
+		DigestSMTPPassword:  cfg.DigestSMTPPassword,
+
+		MetricsEnabled: cfg.MetricsEnabled,
+
+		TracingExporter:    cfg.TracingExporter,
+		TracingEndpoint:    cfg.TracingEndpoint,
+		TracingFile:        cfg.TracingFile,
+		TracingSampleRatio: cfg.TracingSampleRatio,
+
+		GitHubWebhookSecret: cfg.GitHubWebhookSecret,
+		GitLabWebhookSecret: cfg.GitLabWebhookSecret,
+
+		AuthAdminToken:       cfg.AuthAdminToken,
+		AuthJWTSecret:        cfg.AuthJWTSecret,
+		AuthJWTPublicKeyFile: cfg.AuthJWTPublicKeyFile,
 	}
 }
 
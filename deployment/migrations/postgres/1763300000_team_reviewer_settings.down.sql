@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_teams_reviewers_range,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
+
+COMMIT;
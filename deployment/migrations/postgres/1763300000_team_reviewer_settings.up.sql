@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Настройки количества ревьюверов для PR авторов команды. Значения по умолчанию сохраняют поведение "до 2 ревьюверов"
+ALTER TABLE teams
+    ADD COLUMN min_reviewers INT NOT NULL DEFAULT 0,
+    ADD COLUMN max_reviewers INT NOT NULL DEFAULT 2,
+    ADD CONSTRAINT chk_teams_reviewers_range CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
+
+COMMIT;
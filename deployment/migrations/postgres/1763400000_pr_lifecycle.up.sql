@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Полный жизненный цикл PR: DRAFT -> OPEN -> MERGED | CLOSED, CLOSED -> OPEN
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
+    ADD COLUMN closed_at TIMESTAMP NULL;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_pull_requests_merged;
+DROP INDEX IF EXISTS idx_pull_requests_name;
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+DROP INDEX IF EXISTS idx_pull_requests_status_created;
+DROP INDEX IF EXISTS idx_pull_requests_created;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Индексы под /pullRequest/list: keyset-пагинация по (поле сортировки, pull_request_id) и фильтры
+CREATE INDEX idx_pull_requests_created ON pull_requests(created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_status_created ON pull_requests(status, created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_author_created ON pull_requests(author_id, created_at);
+CREATE INDEX idx_pull_requests_name ON pull_requests(pull_request_name, pull_request_id);
+CREATE INDEX idx_pull_requests_merged ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- Вердикты ревьюверов. Таблица только дополняется: актуален последний вердикт каждого ревьювера
+CREATE TABLE pr_reviews (
+    review_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    verdict VARCHAR(20) NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pr_reviews_pr ON pr_reviews(pull_request_id, created_at);
+
+COMMIT;
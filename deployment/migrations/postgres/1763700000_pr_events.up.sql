@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Журнал изменений PR: назначения, переназначения и смены статуса. Строки только добавляются
+CREATE TABLE pr_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    old_reviewer_id VARCHAR(255) NULL,
+    new_reviewer_id VARCHAR(255) NULL,
+    old_status VARCHAR(20) NULL,
+    new_status VARCHAR(20) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pr_events_pr ON pr_events(pull_request_id, event_id);
+
+COMMIT;
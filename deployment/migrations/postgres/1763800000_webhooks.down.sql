@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE webhook_subscriptions (
+    subscription_id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Outbox: доставки создаются в транзакции, которая пишет событие в pr_events,
+-- и отправляются воркером. Падение процесса после коммита не теряет событие
+CREATE TABLE webhook_deliveries (
+    delivery_id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES pr_events(event_id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, delivery_id);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Соответствие логинов GitHub/GitLab пользователям сервиса для входящих webhook
+CREATE TABLE external_identities (
+    provider VARCHAR(16) NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_external_identities_user ON external_identities(user_id);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- Персональные токены API. Хранится только sha256 хэш токена
+CREATE TABLE api_tokens (
+    token_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('admin', 'user')),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id);
+
+COMMIT;
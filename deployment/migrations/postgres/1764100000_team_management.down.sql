@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Откат невозможен, пока есть пользователи без команды
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE users
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
+
+COMMIT;
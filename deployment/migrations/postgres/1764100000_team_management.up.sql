@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Исключенный из команды пользователь остается в базе без команды: на него ссылаются PR и журнал.
+-- Переименование команды каскадно обновляет участников, удалить команду с участниками нельзя
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE users
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+COMMIT;
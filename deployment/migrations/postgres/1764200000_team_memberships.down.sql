@@ -0,0 +1,19 @@
+BEGIN;
+
+-- При откате у пользователя остается только основная команда
+ALTER TABLE users
+    ADD COLUMN team_name VARCHAR(255) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+UPDATE users u
+SET team_name = m.team_name
+FROM team_members m
+WHERE m.user_id = u.user_id AND m.is_primary;
+
+CREATE INDEX idx_users_team_active ON users(team_name, is_active);
+
+DROP INDEX IF EXISTS idx_pull_requests_team_created;
+ALTER TABLE pull_requests DROP COLUMN team_name;
+
+DROP TABLE team_members;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- Пользователь может состоять в нескольких командах, одна из них основная.
+-- Основная команда используется по умолчанию при создании PR
+CREATE TABLE team_members (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE UNIQUE INDEX idx_team_members_primary ON team_members(user_id) WHERE is_primary;
+CREATE INDEX idx_team_members_user ON team_members(user_id);
+
+INSERT INTO team_members (team_name, user_id, is_primary, created_at)
+SELECT team_name, user_id, true, created_at
+FROM users
+WHERE team_name IS NOT NULL;
+
+-- Команда, из которой PR получает ревьюверов. Для существующих PR - команда автора
+ALTER TABLE pull_requests
+    ADD COLUMN team_name VARCHAR(255) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_name = u.team_name
+FROM users u
+WHERE u.user_id = pr.author_id;
+
+CREATE INDEX idx_pull_requests_team_created ON pull_requests(team_name, created_at);
+
+ALTER TABLE users DROP COLUMN team_name;
+
+COMMIT;
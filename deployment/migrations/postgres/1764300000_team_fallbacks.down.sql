@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE pr_reviewers DROP COLUMN source_team;
+
+DROP TABLE team_fallbacks;
+
+COMMIT;
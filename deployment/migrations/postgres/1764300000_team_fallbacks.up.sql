@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Резервные команды, из которых назначаются ревьюверы, когда в команде PR не хватает кандидатов.
+-- Команды перебираются по возрастанию position
+CREATE TABLE team_fallbacks (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    fallback_team VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    CONSTRAINT chk_team_fallbacks_self CHECK (team_name <> fallback_team)
+);
+
+-- Команда, из которой назначен ревьювер. Для существующих назначений - команда PR
+ALTER TABLE pr_reviewers
+    ADD COLUMN source_team VARCHAR(255) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL;
+
+UPDATE pr_reviewers r
+SET source_team = pr.team_name
+FROM pull_requests pr
+WHERE pr.pull_request_id = r.pull_request_id;
+
+COMMIT;
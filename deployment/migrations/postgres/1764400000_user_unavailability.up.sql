@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Периоды недоступности пользователя (отпуск, отсутствие). Пока период идет, пользователь не назначается ревьювером.
+-- processed_at - когда фоновая задача переназначила его открытые ревью после начала периода
+CREATE TABLE user_unavailability (
+    period_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP,
+    CONSTRAINT chk_user_unavailability_range CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, ends_at);
+CREATE INDEX idx_user_unavailability_pending ON user_unavailability(starts_at) WHERE processed_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- NULL - без ограничения
+ALTER TABLE users ADD COLUMN max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
+
+COMMIT;
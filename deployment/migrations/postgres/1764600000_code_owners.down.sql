@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE pr_reviewers DROP COLUMN owner_rule;
+ALTER TABLE pull_requests DROP COLUMN changed_files;
+DROP TABLE team_owner_rules;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Правила владения кодом в стиле CODEOWNERS. Для файла срабатывает последнее подходящее правило
+-- по возрастанию position. Владелец - user_id или имя команды с префиксом "@"
+CREATE TABLE team_owner_rules (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    position INT NOT NULL,
+    pattern TEXT NOT NULL,
+    owners TEXT[] NOT NULL,
+    PRIMARY KEY (team_name, position)
+);
+
+-- Измененные файлы PR, по которым выбираются владельцы
+ALTER TABLE pull_requests ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
+
+-- Шаблон правила, по которому назначен ревьювер. NULL - выбран из команды без учета владения
+ALTER TABLE pr_reviewers ADD COLUMN owner_rule TEXT NULL;
+
+COMMIT;
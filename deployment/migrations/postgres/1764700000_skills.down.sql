@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE pull_requests DROP COLUMN labels;
+ALTER TABLE users DROP COLUMN skills;
+
+COMMIT;
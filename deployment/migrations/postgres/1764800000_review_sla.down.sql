@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_pr_reviewers_sla_pending;
+ALTER TABLE pr_reviewers DROP COLUMN escalated_at;
+ALTER TABLE teams DROP COLUMN sla_escalation, DROP COLUMN review_sla_minutes;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- SLA на вердикт ревьювера: минуты с момента назначения, 0 - без SLA. При просрочке ревьювер
+-- заменяется (reassign) или к PR добавляется еще один ревьювер (add_reviewer)
+ALTER TABLE teams
+    ADD COLUMN review_sla_minutes INT NOT NULL DEFAULT 0 CHECK (review_sla_minutes >= 0),
+    ADD COLUMN sla_escalation VARCHAR(20) NOT NULL DEFAULT 'reassign'
+        CHECK (sla_escalation IN ('reassign', 'add_reviewer'));
+
+-- Когда просроченное назначение было эскалировано; эскалированное назначение повторно не обрабатывается
+ALTER TABLE pr_reviewers ADD COLUMN escalated_at TIMESTAMP NULL;
+
+CREATE INDEX idx_pr_reviewers_sla_pending ON pr_reviewers(assigned_at) WHERE escalated_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Настройки уведомлений пользователя. Строки нет - дайджест включен, без тихих часов, время UTC.
+-- Тихие часы задаются "HH:MM" местного времени и могут переходить через полночь
+CREATE TABLE user_notification_settings (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    digest_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_hours_start VARCHAR(5) NULL,
+    quiet_hours_end VARCHAR(5) NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    -- когда был отправлен последний дайджест; не чаще одного за местные сутки
+    last_digest_at TIMESTAMP NULL,
+    CONSTRAINT chk_user_notification_quiet_hours CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_pull_requests_team_merged;
+DROP INDEX IF EXISTS idx_pr_reviews_created;
+DROP INDEX IF EXISTS idx_pr_events_assignments_created;
+
+COMMIT;
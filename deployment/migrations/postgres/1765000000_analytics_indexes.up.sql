@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Индексы под аналитику за период: назначения и замены из журнала, вердикты и мержи по командам
+CREATE INDEX idx_pr_events_assignments_created ON pr_events(created_at)
+    WHERE event_type IN ('REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED');
+CREATE INDEX idx_pr_reviews_created ON pr_reviews(created_at);
+CREATE INDEX idx_pull_requests_team_merged ON pull_requests(team_name, merged_at) WHERE merged_at IS NOT NULL;
+
+COMMIT;
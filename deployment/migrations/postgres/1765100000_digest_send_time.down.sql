@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE user_notification_settings DROP COLUMN digest_time;
+
+COMMIT;
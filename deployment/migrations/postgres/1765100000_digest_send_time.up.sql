@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Время отправки дайджеста "HH:MM" местного времени пользователя; NULL - время по умолчанию сервиса
+ALTER TABLE user_notification_settings ADD COLUMN digest_time VARCHAR(5) NULL;
+
+COMMIT;
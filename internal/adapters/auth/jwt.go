@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// Поддерживаемые алгоритмы подписи JWT
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// допустимое расхождение часов с выпускающей стороной
+const clockSkew = 30 * time.Second
+
+// Claims поля JWT, которые понимает сервис. sub - user_id, role - admin или user (по умолчанию user)
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf,omitempty"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// JWTVerifier проверяет JWT локальным ключом. Алгоритм фиксируется при создании,
+// поле alg токена только сверяется с ним, чтобы нельзя было подменить RS256 на HS256
+type JWTVerifier struct {
+	alg       string
+	secret    []byte
+	publicKey *rsa.PublicKey
+	now       func() time.Time
+}
+
+func NewHS256Verifier(secret []byte) (*JWTVerifier, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("jwt secret cannot be empty")
+	}
+	return &JWTVerifier{alg: AlgHS256, secret: secret, now: time.Now}, nil
+}
+
+// NewRS256Verifier принимает публичный ключ в PEM (PKIX или PKCS#1)
+func NewRS256Verifier(pemData []byte) (*JWTVerifier, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("jwt public key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return &JWTVerifier{alg: AlgRS256, publicKey: key, now: time.Now}, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse jwt public key")
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("jwt public key is not RSA")
+	}
+	return &JWTVerifier{alg: AlgRS256, publicKey: key, now: time.Now}, nil
+}
+
+func (v *JWTVerifier) Verify(token string) (*en.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed jwt")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, errors.Wrap(err, "jwt header")
+	}
+	if h.Alg != v.alg {
+		return nil, errors.Errorf("unexpected jwt alg '%s'", h.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "jwt signature")
+	}
+	if err := v.checkSignature(parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errors.Wrap(err, "jwt claims")
+	}
+	now := v.now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, errors.New("jwt expired")
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, errors.New("jwt not yet valid")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("jwt sub is empty")
+	}
+
+	role := en.Role(claims.Role)
+	switch role {
+	case "":
+		role = en.RoleUser
+	case en.RoleAdmin, en.RoleUser:
+	default:
+		return nil, errors.Errorf("unknown jwt role '%s'", claims.Role)
+	}
+	return &en.Principal{UserID: claims.Subject, Role: role}, nil
+}
+
+func (v *JWTVerifier) checkSignature(signingInput string, signature []byte) error {
+	switch v.alg {
+	case AlgHS256:
+		if !hmac.Equal(signature, hs256(v.secret, signingInput)) {
+			return errors.New("jwt signature mismatch")
+		}
+	case AlgRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("jwt signature mismatch")
+		}
+	}
+	return nil
+}
+
+// SignHS256 выпускает JWT с подписью HS256. Используется для выдачи токенов доверенными сервисами и в тестах
+func SignHS256(secret []byte, claims Claims) (string, error) {
+	headerJSON, err := json.Marshal(header{Alg: AlgHS256, Typ: "JWT"})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal jwt header")
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal jwt claims")
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(hs256(secret, signingInput)), nil
+}
+
+func hs256(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, dst interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// poolCollector отдает статистику пула соединений pgx на момент опроса
+type poolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	constructingConn *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	emptyAcquire     *prometheus.Desc
+	canceledAcquire  *prometheus.Desc
+}
+
+// NewPoolCollector создает коллектор статистики пула, stat вызывается при каждом опросе
+func NewPoolCollector(stat func() *pgxpool.Stat) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		stat:             stat,
+		acquiredConns:    desc("acquired_connections", "Connections currently in use."),
+		idleConns:        desc("idle_connections", "Idle connections in the pool."),
+		constructingConn: desc("constructing_connections", "Connections being established."),
+		totalConns:       desc("total_connections", "All connections in the pool."),
+		maxConns:         desc("max_connections", "Maximum pool size."),
+		acquireCount:     desc("acquires_total", "Successful connection acquires."),
+		acquireDuration:  desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquire:     desc("empty_acquires_total", "Acquires that had to wait for a connection."),
+		canceledAcquire:  desc("canceled_acquires_total", "Acquires canceled by context."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConn, prometheus.GaugeValue, float64(s.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquire, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
+
+// SnapshotSource возвращает бизнес-метрики из базы
+type SnapshotSource interface {
+	GetMetricsSnapshot(ctx context.Context) (*en.MetricsSnapshot, error)
+}
+
+// businessCollector читает бизнес-метрики из базы при каждом опросе. Значения общие для всех экземпляров
+// сервиса, поэтому при агрегации по экземплярам их нужно брать через max, а не sum
+type businessCollector struct {
+	source  SnapshotSource
+	timeout time.Duration
+
+	pullRequests *prometheus.Desc
+	users        *prometheus.Desc
+}
+
+// NewBusinessCollector создает коллектор бизнес-метрик. timeout ограничивает запросы к базе при опросе
+func NewBusinessCollector(source SnapshotSource, timeout time.Duration) prometheus.Collector {
+	return &businessCollector{
+		source:  source,
+		timeout: timeout,
+		pullRequests: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "pull_requests"),
+			"Pull requests by status.", []string{"status"}, nil),
+		users: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "users"),
+			"Users by activity.", []string{"active"}, nil),
+	}
+}
+
+func (c *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pullRequests
+	ch <- c.users
+}
+
+func (c *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	snapshot, err := c.source.GetMetricsSnapshot(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.pullRequests, err)
+		return
+	}
+
+	for status, count := range snapshot.PRsByStatus {
+		ch <- prometheus.MustNewConstMetric(c.pullRequests, prometheus.GaugeValue, float64(count), string(status))
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(snapshot.ActiveUsers), "true")
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(snapshot.InactiveUsers), "false")
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_review"
+
+// Metrics реестр метрик сервиса: HTTP-запросы, счетчики бизнес-событий текущего экземпляра
+// и коллекторы, которые считывают значения при каждом опросе
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	noCandidate      prometheus.Counter
+	usersDeactivated prometheus.Counter
+	assignments      *prometheus.CounterVec
+	reassignments    *prometheus.CounterVec
+	removals         *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Reviewer reassignments rejected with NO_CANDIDATE by this instance.",
+		}),
+		usersDeactivated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "users_deactivated_total",
+			Help:      "Users deactivated through this instance.",
+		}),
+		assignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_assignments_total",
+			Help:      "Reviewer assignments made by this instance, including replacements, by reason.",
+		}, []string{"reason"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Reviewer replacements made by this instance by reason.",
+		}, []string{"reason"}),
+		removals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_removals_total",
+			Help:      "Reviewers removed from PRs without replacement by this instance by reason.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.noCandidate,
+		m.usersDeactivated,
+		m.assignments,
+		m.reassignments,
+		m.removals,
+	)
+	return m
+}
+
+// Register добавляет коллектор, например статистику пула соединений или бизнес-метрики из базы
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.registry.Register(c)
+}
+
+// Handler отдает метрики в текстовом формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest учитывает обработанный запрос. route - шаблон маршрута, а не путь, чтобы не плодить серии
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) NoCandidate() {
+	m.noCandidate.Inc()
+}
+
+func (m *Metrics) UsersDeactivated(count int) {
+	m.usersDeactivated.Add(float64(count))
+}
+
+func (m *Metrics) ReviewersAssigned(reason string, count int) {
+	m.assignments.WithLabelValues(reason).Add(float64(count))
+}
+
+// ReviewersReassigned учитывает замены; замена назначает нового ревьювера, поэтому учитывается и как назначение
+func (m *Metrics) ReviewersReassigned(reason string, count int) {
+	m.reassignments.WithLabelValues(reason).Add(float64(count))
+	m.assignments.WithLabelValues(reason).Add(float64(count))
+}
+
+func (m *Metrics) ReviewersRemoved(reason string, count int) {
+	m.removals.WithLabelValues(reason).Add(float64(count))
+}
@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// SMTPConfig параметры почтового сервера. Без Username письма отправляются без аутентификации
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// SMTPNotifier отправляет дайджест письмом на адрес из настроек уведомлений пользователя.
+// Пользователи без адреса пропускаются
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) Notify(_ context.Context, digest *en.ReviewDigest) error {
+	if digest.Email == "" {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(n.cfg.Addr)
+		if err != nil {
+			return errors.Wrap(err, "parse smtp addr")
+		}
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", digest.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", digestSubject(digest))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(FormatDigest(digest))
+
+	if err := smtp.SendMail(n.cfg.Addr, auth, n.cfg.From, []string{digest.Email}, msg.Bytes()); err != nil {
+		return errors.Wrap(err, "send mail")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/webhook"
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// DigestEvent значение заголовка события для дайджестов
+const DigestEvent = "review.digest"
+
+// WebhookNotifier отправляет дайджест POST-запросом с JSON телом на общий адрес,
+// например в бот мессенджера. При непустом secret запрос подписывается как исходящие webhook
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookNotifier(url, secret string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, secret: secret, client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, digest *en.ReviewDigest) error {
+	body, err := json.Marshal(digest)
+	if err != nil {
+		return errors.Wrap(err, "marshal digest")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "build request")
+	}
+	timestamp := n.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderEvent, DigestEvent)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if n.secret != "" {
+		req.Header.Set(webhook.HeaderSignature, webhook.Sign(n.secret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// WriterNotifier пишет дайджесты текстом в w, например в лог сервиса или файл при локальной разработке
+type WriterNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+func (n *WriterNotifier) Notify(_ context.Context, digest *en.ReviewDigest) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, err := io.WriteString(n.w, FormatDigest(digest)+"\n"); err != nil {
+		return errors.Wrap(err, "write digest")
+	}
+	return nil
+}
+
+func digestSubject(digest *en.ReviewDigest) string {
+	return fmt.Sprintf("Review digest: %d open reviews", len(digest.Reviews))
+}
+
+// FormatDigest возвращает текстовое представление дайджеста, общее для всех получателей
+func FormatDigest(digest *en.ReviewDigest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s for %s (%s)\n", digestSubject(digest), digest.Username, digest.UserID)
+	for _, r := range digest.Reviews {
+		age := (time.Duration(r.AgeSeconds) * time.Second).Truncate(time.Minute)
+		fmt.Fprintf(&b, "- %s %q by %s, waiting %s\n", r.PullRequestID, r.PullRequestName, r.AuthorID, age)
+	}
+	return b.String()
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// GetPRThroughput возвращает число созданных и смерженных PR по командам и интервалам filter.Bucket
+func (p *PgxStorage) GetPRThroughput(ctx context.Context, filter en.AnalyticsFilter) ([]en.ThroughputPoint, error) {
+	const q = `
+		WITH created AS (
+			SELECT COALESCE(team_name, '') AS team_name, date_trunc($3, created_at) AS bucket, COUNT(*) AS n
+			FROM pull_requests
+			WHERE created_at >= $1 AND created_at < $2 AND ($4 = '' OR team_name = $4)
+			GROUP BY 1, 2
+		), merged AS (
+			SELECT COALESCE(team_name, '') AS team_name, date_trunc($3, merged_at) AS bucket, COUNT(*) AS n
+			FROM pull_requests
+			WHERE merged_at >= $1 AND merged_at < $2 AND ($4 = '' OR team_name = $4)
+			GROUP BY 1, 2
+		)
+		SELECT COALESCE(c.team_name, m.team_name), COALESCE(c.bucket, m.bucket), COALESCE(c.n, 0), COALESCE(m.n, 0)
+		FROM created c
+		FULL JOIN merged m ON m.team_name = c.team_name AND m.bucket = c.bucket
+		ORDER BY 2, 1
+	`
+	rows, err := p.pool.Query(ctx, q, filter.From, filter.To, string(filter.Bucket), filter.TeamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetPRThroughput")
+	}
+	defer rows.Close()
+
+	points := []en.ThroughputPoint{}
+	for rows.Next() {
+		var point en.ThroughputPoint
+		if err := rows.Scan(&point.TeamName, &point.BucketStart, &point.Created, &point.Merged); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetPRThroughput.Scan")
+		}
+		points = append(points, point)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetPRThroughput.RowsError")
+	}
+	return points, nil
+}
+
+// GetReviewTimings возвращает медиану и p90 времени до мержа и до первого вердикта по командам и в целом
+func (p *PgxStorage) GetReviewTimings(ctx context.Context, filter en.AnalyticsFilter) (*en.ReviewTimings, error) {
+	const qMerge = `
+		WITH durations AS (
+			SELECT COALESCE(team_name, '') AS team_name, EXTRACT(EPOCH FROM merged_at - created_at)::float8 AS seconds
+			FROM pull_requests
+			WHERE merged_at >= $1 AND merged_at < $2 AND ($3 = '' OR team_name = $3)
+		)` + durationPercentiles
+
+	// первый вердикт отсчитывается от первого назначения ревьювера, чтобы не учитывать время в DRAFT
+	const qFirstReview = `
+		WITH first_reviews AS (
+			SELECT pull_request_id, MIN(created_at) AS reviewed_at
+			FROM pr_reviews
+			GROUP BY pull_request_id
+		), durations AS (
+			SELECT COALESCE(pr.team_name, '') AS team_name,
+			       EXTRACT(EPOCH FROM f.reviewed_at - COALESCE(a.assigned_at, pr.created_at))::float8 AS seconds
+			FROM first_reviews f
+			JOIN pull_requests pr ON pr.pull_request_id = f.pull_request_id
+			LEFT JOIN LATERAL (
+				SELECT MIN(e.created_at) AS assigned_at
+				FROM pr_events e
+				WHERE e.pull_request_id = pr.pull_request_id AND e.event_type = 'REVIEWER_ASSIGNED'
+			) a ON TRUE
+			WHERE f.reviewed_at >= $1 AND f.reviewed_at < $2 AND ($3 = '' OR pr.team_name = $3)
+		)` + durationPercentiles
+
+	timings := &en.ReviewTimings{Teams: []en.TeamTimings{}}
+	teams := make(map[string]*en.TeamTimings)
+	slots := []struct {
+		query string
+		stats func(*en.TeamTimings) *en.DurationStats
+	}{
+		{qMerge, func(t *en.TeamTimings) *en.DurationStats { return &t.TimeToMerge }},
+		{qFirstReview, func(t *en.TeamTimings) *en.DurationStats { return &t.TimeToFirstReview }},
+	}
+	for _, slot := range slots {
+		rows, err := p.pool.Query(ctx, slot.query, filter.From, filter.To, filter.TeamName)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetReviewTimings")
+		}
+		for rows.Next() {
+			var team string
+			var overall bool
+			var stats en.DurationStats
+			if err := rows.Scan(&team, &overall, &stats.Count, &stats.MedianSeconds, &stats.P90Seconds); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, "PgxStorage.GetReviewTimings.Scan")
+			}
+			target := &timings.Overall
+			if !overall {
+				target = teams[team]
+				if target == nil {
+					target = &en.TeamTimings{TeamName: team}
+					teams[team] = target
+				}
+			}
+			*slot.stats(target) = stats
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return nil, errors.Wrap(rows.Err(), "PgxStorage.GetReviewTimings.RowsError")
+		}
+	}
+
+	for _, t := range teams {
+		timings.Teams = append(timings.Teams, *t)
+	}
+	sort.Slice(timings.Teams, func(i, j int) bool { return timings.Teams[i].TeamName < timings.Teams[j].TeamName })
+	return timings, nil
+}
+
+// durationPercentiles считает статистику CTE durations(team_name, seconds) по командам и по всем строкам сразу;
+// итоговая строка отличается признаком overall
+const durationPercentiles = `
+	SELECT COALESCE(team_name, ''), GROUPING(team_name) = 1 AS overall, COUNT(*),
+	       percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds),
+	       percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds)
+	FROM durations
+	GROUP BY GROUPING SETS ((team_name), ())`
+
+// GetReassignmentStats возвращает назначения и замены ревьюверов в PR каждой команды за период с причинами замен
+func (p *PgxStorage) GetReassignmentStats(ctx context.Context, filter en.AnalyticsFilter) ([]en.TeamReassignments, error) {
+	const q = `
+		SELECT COALESCE(pr.team_name, ''), e.event_type, e.reason, COUNT(*)
+		FROM pr_events e
+		JOIN pull_requests pr ON pr.pull_request_id = e.pull_request_id
+		WHERE e.event_type IN ('REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED')
+		  AND e.created_at >= $1 AND e.created_at < $2 AND ($3 = '' OR pr.team_name = $3)
+		GROUP BY 1, 2, 3
+		ORDER BY 1
+	`
+	rows, err := p.pool.Query(ctx, q, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetReassignmentStats")
+	}
+	defer rows.Close()
+
+	stats := []en.TeamReassignments{}
+	for rows.Next() {
+		var team, reason string
+		var eventType en.PREventType
+		var count int
+		if err := rows.Scan(&team, &eventType, &reason, &count); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetReassignmentStats.Scan")
+		}
+		if len(stats) == 0 || stats[len(stats)-1].TeamName != team {
+			stats = append(stats, en.TeamReassignments{TeamName: team, Reasons: map[string]int{}})
+		}
+		current := &stats[len(stats)-1]
+		// каждая замена тоже назначает ревьювера
+		current.Assignments += count
+		if eventType == en.EventReviewerReassigned {
+			current.Reassignments += count
+			current.Reasons[reason] += count
+		}
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetReassignmentStats.RowsError")
+	}
+
+	for i := range stats {
+		if stats[i].Assignments > 0 {
+			stats[i].Rate = float64(stats[i].Reassignments) / float64(stats[i].Assignments)
+		}
+	}
+	return stats, nil
+}
+
+// GetWorkloadTrend возвращает по интервалам, сколько ревью назначено каждому пользователю и сколько вердиктов он оставил.
+// Учитываются PR команды filter.TeamName, при непустом filter.UserID - только этот пользователь
+func (p *PgxStorage) GetWorkloadTrend(ctx context.Context, filter en.AnalyticsFilter) ([]en.WorkloadPoint, error) {
+	const q = `
+		WITH assigned AS (
+			SELECT e.new_reviewer_id AS user_id, date_trunc($3, e.created_at) AS bucket, COUNT(*) AS n
+			FROM pr_events e
+			JOIN pull_requests pr ON pr.pull_request_id = e.pull_request_id
+			WHERE e.event_type IN ('REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED') AND e.new_reviewer_id IS NOT NULL
+			  AND e.created_at >= $1 AND e.created_at < $2
+			  AND ($4 = '' OR pr.team_name = $4) AND ($5 = '' OR e.new_reviewer_id = $5)
+			GROUP BY 1, 2
+		), reviewed AS (
+			SELECT v.reviewer_id AS user_id, date_trunc($3, v.created_at) AS bucket, COUNT(*) AS n
+			FROM pr_reviews v
+			JOIN pull_requests pr ON pr.pull_request_id = v.pull_request_id
+			WHERE v.created_at >= $1 AND v.created_at < $2
+			  AND ($4 = '' OR pr.team_name = $4) AND ($5 = '' OR v.reviewer_id = $5)
+			GROUP BY 1, 2
+		)
+		SELECT COALESCE(a.user_id, r.user_id), COALESCE(a.bucket, r.bucket), COALESCE(a.n, 0), COALESCE(r.n, 0)
+		FROM assigned a
+		FULL JOIN reviewed r ON r.user_id = a.user_id AND r.bucket = a.bucket
+		ORDER BY 1, 2
+	`
+	rows, err := p.pool.Query(ctx, q, filter.From, filter.To, string(filter.Bucket), filter.TeamName, filter.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetWorkloadTrend")
+	}
+	defer rows.Close()
+
+	points := []en.WorkloadPoint{}
+	for rows.Next() {
+		var point en.WorkloadPoint
+		if err := rows.Scan(&point.UserID, &point.BucketStart, &point.Assigned, &point.Reviewed); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetWorkloadTrend.Scan")
+		}
+		points = append(points, point)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetWorkloadTrend.RowsError")
+	}
+	return points, nil
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+const apiTokenColumns = `token_id, user_id, role, created_at, revoked_at`
+
+func scanAPIToken(row pgx.Row) (*en.APIToken, error) {
+	var token en.APIToken
+	var role string
+	if err := row.Scan(&token.TokenID, &token.UserID, &role, &token.CreatedAt, &token.RevokedAt); err != nil {
+		return nil, err
+	}
+	token.Role = en.Role(role)
+	return &token, nil
+}
+
+func (p *PgxStorage) CreateAPIToken(ctx context.Context, token *en.APIToken, tokenHash string) (*en.APIToken, error) {
+	q := `
+		INSERT INTO api_tokens (user_id, role, token_hash)
+		VALUES ($1, $2, $3)
+		RETURNING ` + apiTokenColumns
+	created, err := scanAPIToken(p.pool.QueryRow(ctx, q, token.UserID, string(token.Role), tokenHash))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.CreateAPIToken")
+	}
+	return created, nil
+}
+
+func (p *PgxStorage) GetAPITokenByHash(ctx context.Context, tokenHash string) (*en.APIToken, error) {
+	q := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+	token, err := scanAPIToken(p.pool.QueryRow(ctx, q, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.GetAPITokenByHash")
+	}
+	return token, nil
+}
+
+func (p *PgxStorage) ListAPITokens(ctx context.Context, userID string) ([]*en.APIToken, error) {
+	q := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE $1 = '' OR user_id = $1
+		ORDER BY token_id
+	`
+	rows, err := p.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListAPITokens")
+	}
+	defer rows.Close()
+
+	var tokens []*en.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListAPITokens.Scan")
+		}
+		tokens = append(tokens, token)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListAPITokens.RowsError")
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken отзывает токен. Повторный отзыв не меняет время отзыва
+func (p *PgxStorage) RevokeAPIToken(ctx context.Context, tokenID int64, at time.Time) (bool, error) {
+	const q = `UPDATE api_tokens SET revoked_at = COALESCE(revoked_at, $2) WHERE token_id = $1`
+	tag, err := p.pool.Exec(ctx, q, tokenID, at)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.RevokeAPIToken")
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// insertPREvents пишет события журнала и ставит их в очередь доставки подписчикам webhook (outbox).
+// Вызывается внутри транзакции изменения, инициатор берется из контекста запроса
+func insertPREvents(ctx context.Context, tx pgx.Tx, events ...en.PREvent) error {
+	const q = `
+		INSERT INTO pr_events (pull_request_id, event_type, actor, reason, old_reviewer_id, new_reviewer_id, old_status, new_status)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''))
+		RETURNING event_id, created_at
+	`
+	const qOutbox = `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT subscription_id, $1, $2, $3
+		FROM webhook_subscriptions
+		WHERE is_active AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))
+	`
+	actor := en.ActorFromContext(ctx)
+	for _, ev := range events {
+		ev.Actor = actor
+		err := tx.QueryRow(ctx, q, ev.PullRequestID, string(ev.Type), actor, ev.Reason,
+			ev.OldReviewerID, ev.NewReviewerID, string(ev.OldStatus), string(ev.NewStatus)).Scan(&ev.EventID, &ev.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "insert pr event")
+		}
+
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return errors.Wrap(err, "marshal pr event")
+		}
+		if _, err := tx.Exec(ctx, qOutbox, ev.EventID, string(ev.Type), payload); err != nil {
+			return errors.Wrap(err, "enqueue webhook deliveries")
+		}
+	}
+	return nil
+}
+
+func (p *PgxStorage) GetPREvents(ctx context.Context, prID string) ([]*en.PREvent, error) {
+	const q = `
+		SELECT event_id, pull_request_id, event_type, actor, reason,
+		       COALESCE(old_reviewer_id, ''), COALESCE(new_reviewer_id, ''),
+		       COALESCE(old_status, ''), COALESCE(new_status, ''), created_at
+		FROM pr_events
+		WHERE pull_request_id = $1
+		ORDER BY event_id
+	`
+	rows, err := p.pool.Query(ctx, q, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetPREvents")
+	}
+	defer rows.Close()
+
+	var events []*en.PREvent
+	for rows.Next() {
+		var ev en.PREvent
+		var eventType, oldStatus, newStatus string
+		if err := rows.Scan(&ev.EventID, &ev.PullRequestID, &eventType, &ev.Actor, &ev.Reason,
+			&ev.OldReviewerID, &ev.NewReviewerID, &oldStatus, &newStatus, &ev.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetPREvents.Scan")
+		}
+		ev.Type = en.PREventType(eventType)
+		ev.OldStatus = en.PRStatus(oldStatus)
+		ev.NewStatus = en.PRStatus(newStatus)
+		events = append(events, &ev)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetPREvents.RowsError")
+	}
+
+	return events, nil
+}
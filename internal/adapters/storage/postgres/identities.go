@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+func (p *PgxStorage) SetExternalIdentity(ctx context.Context, identity *en.ExternalIdentity) error {
+	const q = `
+		INSERT INTO external_identities (provider, login, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, login) DO UPDATE SET user_id = EXCLUDED.user_id
+	`
+	if _, err := p.pool.Exec(ctx, q, string(identity.Provider), identity.Login, identity.UserID); err != nil {
+		return errors.Wrap(err, "PgxStorage.SetExternalIdentity")
+	}
+	return nil
+}
+
+func (p *PgxStorage) GetUserIDByExternalLogin(ctx context.Context, provider en.GitProvider, login string) (string, error) {
+	const q = `SELECT user_id FROM external_identities WHERE provider = $1 AND login = $2`
+	var userID string
+	err := p.pool.QueryRow(ctx, q, string(provider), login).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "PgxStorage.GetUserIDByExternalLogin")
+	}
+	return userID, nil
+}
+
+func (p *PgxStorage) ListExternalIdentities(ctx context.Context, provider en.GitProvider) ([]*en.ExternalIdentity, error) {
+	const q = `
+		SELECT provider, login, user_id
+		FROM external_identities
+		WHERE $1 = '' OR provider = $1
+		ORDER BY provider, login
+	`
+	rows, err := p.pool.Query(ctx, q, string(provider))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListExternalIdentities")
+	}
+	defer rows.Close()
+
+	var identities []*en.ExternalIdentity
+	for rows.Next() {
+		var identity en.ExternalIdentity
+		var prov string
+		if err := rows.Scan(&prov, &identity.Login, &identity.UserID); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListExternalIdentities.Scan")
+		}
+		identity.Provider = en.GitProvider(prov)
+		identities = append(identities, &identity)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListExternalIdentities.RowsError")
+	}
+	return identities, nil
+}
+
+func (p *PgxStorage) DeleteExternalIdentity(ctx context.Context, provider en.GitProvider, login string) (bool, error) {
+	const q = `DELETE FROM external_identities WHERE provider = $1 AND login = $2`
+	tag, err := p.pool.Exec(ctx, q, string(provider), login)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteExternalIdentity")
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+const notificationColumns = `user_id, email, digest_opt_out, COALESCE(quiet_hours_start, ''), COALESCE(quiet_hours_end, ''),
+	COALESCE(digest_time, ''), timezone, last_digest_at`
+
+func scanNotificationSettings(row pgx.Row) (*en.NotificationSettings, error) {
+	var settings en.NotificationSettings
+	err := row.Scan(&settings.UserID, &settings.Email, &settings.DigestOptOut, &settings.QuietHoursStart,
+		&settings.QuietHoursEnd, &settings.DigestTime, &settings.Timezone, &settings.LastDigestAt)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (p *PgxStorage) GetNotificationSettings(ctx context.Context, userID string) (*en.NotificationSettings, error) {
+	q := `SELECT ` + notificationColumns + ` FROM user_notification_settings WHERE user_id = $1`
+	settings, err := scanNotificationSettings(p.pool.QueryRow(ctx, q, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.GetNotificationSettings")
+	}
+	return settings, nil
+}
+
+// UpsertNotificationSettings сохраняет настройки пользователя, время последнего дайджеста не меняется
+func (p *PgxStorage) UpsertNotificationSettings(ctx context.Context, settings *en.NotificationSettings) (*en.NotificationSettings, error) {
+	q := `
+		INSERT INTO user_notification_settings (user_id, email, digest_opt_out, quiet_hours_start, quiet_hours_end,
+		                                        digest_time, timezone)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email = EXCLUDED.email,
+		    digest_opt_out = EXCLUDED.digest_opt_out,
+		    quiet_hours_start = EXCLUDED.quiet_hours_start,
+		    quiet_hours_end = EXCLUDED.quiet_hours_end,
+		    digest_time = EXCLUDED.digest_time,
+		    timezone = EXCLUDED.timezone
+		RETURNING ` + notificationColumns
+	saved, err := scanNotificationSettings(p.pool.QueryRow(ctx, q, settings.UserID, settings.Email, settings.DigestOptOut,
+		settings.QuietHoursStart, settings.QuietHoursEnd, settings.DigestTime, settings.Timezone))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpsertNotificationSettings")
+	}
+	return saved, nil
+}
+
+// digestColumns получатель, его настройки и одно назначенное ему ревью OPEN PR. Порядок совпадает с queryReviewDigests
+const digestColumns = `u.user_id, u.username,
+	COALESCE(s.email, ''), COALESCE(s.digest_opt_out, FALSE), COALESCE(s.quiet_hours_start, ''),
+	COALESCE(s.quiet_hours_end, ''), COALESCE(s.digest_time, ''), COALESCE(s.timezone, '` + en.DefaultTimezone + `'),
+	s.last_digest_at, pr.pull_request_id, pr.pull_request_name, pr.author_id, r.assigned_at`
+
+// digestSources активные пользователи с назначенными ревью OPEN PR и их настройки уведомлений
+const digestSources = `users u
+	JOIN pr_reviewers r ON r.user_id = u.user_id
+	JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id AND pr.status = 'OPEN'
+	LEFT JOIN user_notification_settings s ON s.user_id = u.user_id`
+
+// ListPendingReviewDigests возвращает дайджесты активных пользователей (или только userID, если он не пустой),
+// у которых есть назначенные ревью OPEN PR. Ревью упорядочены от самых старых назначений
+func (p *PgxStorage) ListPendingReviewDigests(ctx context.Context, userID string) ([]*en.ReviewDigest, error) {
+	q := `
+		SELECT ` + digestColumns + `
+		FROM ` + digestSources + `
+		WHERE u.is_active AND ($1 = '' OR u.user_id = $1)
+		ORDER BY u.user_id, r.assigned_at, pr.pull_request_id
+	`
+	digests, err := queryReviewDigests(ctx, p.pool, q, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListPendingReviewDigests")
+	}
+	return digests, nil
+}
+
+// ListDueReviewDigests возвращает дайджесты, которые пора отправить в момент now (UTC): получатель не отказался
+// от рассылки, в его часовом поясе наступило время отправки (свое или defaultTime), сейчас не тихие часы
+// и в текущие местные сутки дайджест еще не отправлялся
+func (p *PgxStorage) ListDueReviewDigests(ctx context.Context, now time.Time, defaultTime string) ([]*en.ReviewDigest, error) {
+	q := `
+		SELECT ` + digestColumns + `
+		FROM ` + digestSources + `
+		CROSS JOIN LATERAL (
+			SELECT ($1::timestamp AT TIME ZONE 'UTC') AT TIME ZONE COALESCE(s.timezone, '` + en.DefaultTimezone + `') AS at
+		) l
+		WHERE u.is_active AND NOT COALESCE(s.digest_opt_out, FALSE)
+		  AND l.at::time >= COALESCE(s.digest_time, $2)::time
+		  AND (s.last_digest_at IS NULL
+		       OR ((s.last_digest_at AT TIME ZONE 'UTC') AT TIME ZONE s.timezone)::date < l.at::date)
+		  AND NOT (s.quiet_hours_start IS NOT NULL AND s.quiet_hours_start::time <> s.quiet_hours_end::time AND
+		           CASE WHEN s.quiet_hours_start::time < s.quiet_hours_end::time
+		                THEN l.at::time >= s.quiet_hours_start::time AND l.at::time < s.quiet_hours_end::time
+		                -- промежуток через полночь, например 22:00-08:00
+		                ELSE l.at::time >= s.quiet_hours_start::time OR l.at::time < s.quiet_hours_end::time
+		           END)
+		ORDER BY u.user_id, r.assigned_at, pr.pull_request_id
+	`
+	digests, err := queryReviewDigests(ctx, p.pool, q, now, defaultTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListDueReviewDigests")
+	}
+	return digests, nil
+}
+
+// queryReviewDigests собирает дайджесты из строк digestColumns, упорядоченных по пользователю
+func queryReviewDigests(ctx context.Context, q querier, sql string, args ...interface{}) ([]*en.ReviewDigest, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	digests := []*en.ReviewDigest{}
+	var current *en.ReviewDigest
+	for rows.Next() {
+		var digest en.ReviewDigest
+		var settings en.NotificationSettings
+		var review en.DigestReview
+		err := rows.Scan(&digest.UserID, &digest.Username,
+			&settings.Email, &settings.DigestOptOut, &settings.QuietHoursStart,
+			&settings.QuietHoursEnd, &settings.DigestTime, &settings.Timezone, &settings.LastDigestAt,
+			&review.PullRequestID, &review.PullRequestName, &review.AuthorID, &review.AssignedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+
+		if current == nil || current.UserID != digest.UserID {
+			// пользователь без строки настроек получает значения по умолчанию
+			settings.UserID = digest.UserID
+			digest.Settings = &settings
+			digest.Email = settings.Email
+			current = &digest
+			digests = append(digests, current)
+		}
+		current.Reviews = append(current.Reviews, review)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "rows")
+	}
+	return digests, nil
+}
+
+// SetDigestSentAt меняет время последнего дайджеста пользователя с prev на sentAt, только если оно не изменилось.
+// Так дайджест забирается одним экземпляром сервиса, а при ошибке доставки возвращается обратно
+func (p *PgxStorage) SetDigestSentAt(ctx context.Context, userID string, prev, sentAt *time.Time) (bool, error) {
+	const q = `
+		INSERT INTO user_notification_settings (user_id, last_digest_at) VALUES ($1, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET last_digest_at = EXCLUDED.last_digest_at
+		WHERE user_notification_settings.last_digest_at IS NOT DISTINCT FROM $2
+	`
+	tag, err := p.pool.Exec(ctx, q, userID, prev, sentAt)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.SetDigestSentAt")
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -8,11 +8,14 @@ import (
 	"github.com/pkg/errors"
 )
 
+// PgxStorage хранилище в PostgreSQL. Колонки времени объявлены без часового пояса и хранят UTC:
+// pgx записывает в них время по часам значения без учета зоны, поэтому сервис передает время в UTC,
+// а сессии работают в UTC, чтобы NOW() и значения колонок по умолчанию были в той же зоне
 type PgxStorage struct {
 	pool *pgxpool.Pool
 }
 
-func NewPgxClient(ctx context.Context, dsn string) (*PgxStorage, error) {
+func NewPgxClient(ctx context.Context, dsn string, opts ...ClientOption) (*PgxStorage, error) {
 	cfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, errors.Wrap(err, "pgxpool.ParseConfig")
@@ -21,6 +24,10 @@ func NewPgxClient(ctx context.Context, dsn string) (*PgxStorage, error) {
 	cfg.MinConns = 2
 	cfg.MaxConnLifetime = time.Hour
 	cfg.MaxConnIdleTime = time.Minute
+	cfg.ConnConfig.RuntimeParams["timezone"] = "UTC"
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
 	pool, err := pgxpool.ConnectConfig(ctx, cfg)
 	if err != nil {
@@ -32,3 +39,8 @@ func NewPgxClient(ctx context.Context, dsn string) (*PgxStorage, error) {
 func (p *PgxStorage) Close() {
 	p.pool.Close()
 }
+
+// PoolStat возвращает статистику пула соединений для метрик
+func (p *PgxStorage) PoolStat() *pgxpool.Stat {
+	return p.pool.Stat()
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v4"
@@ -18,20 +20,39 @@ func (p *PgxStorage) CreatePRWithReviewers(ctx context.Context, pr *en.PullReque
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	const qPR = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name, changed_files, labels)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)
 	`
-	_, err = tx.Exec(ctx, qPR, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status), pr.CreatedAt, pr.MergedAt)
+	changedFiles := pr.ChangedFiles
+	if changedFiles == nil {
+		changedFiles = []string{}
+	}
+	labels := pr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	_, err = tx.Exec(ctx, qPR, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status), pr.CreatedAt, pr.MergedAt, pr.TeamName,
+		changedFiles, labels)
 	if err != nil {
 		return errors.Wrap(err, "PgxStorage.CreatePRWithReviewers.CreatePR")
 	}
 
-	const qReviewers = `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+	events := []en.PREvent{{PullRequestID: pr.PullRequestID, Type: en.EventPRCreated, Reason: en.ReasonCreated, NewStatus: pr.Status}}
+	const qReviewers = `
+		INSERT INTO pr_reviewers (pull_request_id, user_id, source_team, owner_rule) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+	`
 	for _, reviewerID := range reviewerIDs {
-		_, err = tx.Exec(ctx, qReviewers, pr.PullRequestID, reviewerID)
+		_, err = tx.Exec(ctx, qReviewers, pr.PullRequestID, reviewerID, pr.ReviewerTeams[reviewerID], pr.ReviewerRules[reviewerID])
 		if err != nil {
 			return errors.Wrap(err, "PgxStorage.CreatePRWithReviewers.AssignReviewer")
 		}
+		events = append(events, en.PREvent{
+			PullRequestID: pr.PullRequestID, Type: en.EventReviewerAssigned, Reason: en.ReasonAutoAssign, NewReviewerID: reviewerID,
+		})
+	}
+
+	if err = insertPREvents(ctx, tx, events...); err != nil {
+		return errors.Wrap(err, "PgxStorage.CreatePRWithReviewers.InsertEvents")
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -43,14 +64,14 @@ func (p *PgxStorage) CreatePRWithReviewers(ctx context.Context, pr *en.PullReque
 
 func (p *PgxStorage) GetPR(ctx context.Context, prID string) (*en.PullRequest, error) {
 	const qPR = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, COALESCE(team_name, ''), changed_files, labels
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`
 	var pr en.PullRequest
 	var status string
 	err := p.pool.QueryRow(ctx, qPR, prID).Scan(
-		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.TeamName, &pr.ChangedFiles, &pr.Labels,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -60,65 +81,134 @@ func (p *PgxStorage) GetPR(ctx context.Context, prID string) (*en.PullRequest, e
 	}
 	pr.Status = en.PRStatus(status)
 
-	const qReviewers = `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`
-	rows, err := p.pool.Query(ctx, qReviewers, prID)
+	pr.AssignedReviewers, pr.ReviewerTeams, pr.ReviewerRules, err = queryPRReviewers(ctx, p.pool, prID)
 	if err != nil {
 		return nil, errors.Wrap(err, "PgxStorage.GetPR.GetReviewers")
 	}
-	defer rows.Close()
+	return &pr, nil
+}
 
-	var reviewers []string
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
-			return nil, errors.Wrap(err, "PgxStorage.GetPR.ScanReviewer")
+func (p *PgxStorage) MergePR(ctx context.Context, prID string, mergedAt time.Time) (*en.PullRequest, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MergePR.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const qLock = `SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`
+	var oldStatus string
+	err = tx.QueryRow(ctx, qLock, prID).Scan(&oldStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
 		}
-		reviewers = append(reviewers, userID)
+		return nil, errors.Wrap(err, "PgxStorage.MergePR.LockPR")
 	}
-	if rows.Err() != nil {
-		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetPR.RowsError")
+	// статус мог измениться после проверки в сервисе; повторный merge оставляет прежнее время слияния
+	switch en.PRStatus(oldStatus) {
+	case en.StatusOpen, en.StatusMerged:
+	default:
+		return nil, en.NewInvalidTransitionError(prID, en.PRStatus(oldStatus), en.StatusMerged)
+	}
+
+	const q = `
+		UPDATE pull_requests
+		SET status = $2, merged_at = COALESCE(merged_at, $3)
+		WHERE pull_request_id = $1
+		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, COALESCE(team_name, ''), changed_files, labels
+	`
+	var pr en.PullRequest
+	var status string
+	err = tx.QueryRow(ctx, q, prID, string(en.StatusMerged), mergedAt).Scan(
+		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.TeamName, &pr.ChangedFiles, &pr.Labels,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MergePR")
+	}
+	pr.Status = en.PRStatus(status)
+
+	if oldStatus != status {
+		err = insertPREvents(ctx, tx, en.PREvent{
+			PullRequestID: prID, Type: en.EventStatusChanged, Reason: en.ReasonStatusChange,
+			OldStatus: en.PRStatus(oldStatus), NewStatus: pr.Status,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.MergePR.InsertEvents")
+		}
+	}
+
+	pr.AssignedReviewers, pr.ReviewerTeams, pr.ReviewerRules, err = queryPRReviewers(ctx, tx, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MergePR.GetReviewers")
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MergePR.Commit")
 	}
 
-	pr.AssignedReviewers = reviewers
 	return &pr, nil
 }
 
-func (p *PgxStorage) MergePR(ctx context.Context, prID string, mergedAt time.Time) (*en.PullRequest, error) {
-	const q = `
+// UpdatePRStatus переводит PR из статуса from в to и назначает reviewerIDs в одной транзакции.
+// reviewerTeams - команды, из которых выбраны ревьюверы, reviewerRules - правила владения, по которым они выбраны.
+// Возвращает nil, если PR не найден или его статус уже отличается от from
+func (p *PgxStorage) UpdatePRStatus(ctx context.Context, prID string, from, to en.PRStatus, at time.Time, reviewerIDs []string, reviewerTeams, reviewerRules map[string]string) (*en.PullRequest, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const qStatus = `
 		UPDATE pull_requests
-		SET status = $2, merged_at = $3
-		WHERE pull_request_id = $1
-		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SET status = $3,
+			closed_at = CASE WHEN $3::text = 'CLOSED' THEN $4::timestamp ELSE NULL END
+		WHERE pull_request_id = $1 AND status = $2
+		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, COALESCE(team_name, ''), changed_files, labels
 	`
 	var pr en.PullRequest
 	var status string
-	err := p.pool.QueryRow(ctx, q, prID, string(en.StatusMerged), mergedAt).Scan(
-		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt,
+	err = tx.QueryRow(ctx, qStatus, prID, string(from), string(to), at).Scan(
+		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.TeamName, &pr.ChangedFiles, &pr.Labels,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
-		return nil, errors.Wrap(err, "PgxStorage.MergePR")
+		return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.UpdateStatus")
 	}
 	pr.Status = en.PRStatus(status)
 
-	const qReviewers = `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`
-	rows, err := p.pool.Query(ctx, qReviewers, prID)
+	const qReviewer = `
+		INSERT INTO pr_reviewers (pull_request_id, user_id, source_team, owner_rule) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+		ON CONFLICT (pull_request_id, user_id) DO NOTHING
+	`
+	events := []en.PREvent{{
+		PullRequestID: prID, Type: en.EventStatusChanged, Reason: en.ReasonStatusChange, OldStatus: from, NewStatus: to,
+	}}
+	for _, reviewerID := range reviewerIDs {
+		tag, err := tx.Exec(ctx, qReviewer, prID, reviewerID, reviewerTeams[reviewerID], reviewerRules[reviewerID])
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.AssignReviewer")
+		}
+		if tag.RowsAffected() > 0 {
+			events = append(events, en.PREvent{
+				PullRequestID: prID, Type: en.EventReviewerAssigned, Reason: en.ReasonAutoAssign, NewReviewerID: reviewerID,
+			})
+		}
+	}
+	if err = insertPREvents(ctx, tx, events...); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.InsertEvents")
+	}
+
+	pr.AssignedReviewers, pr.ReviewerTeams, pr.ReviewerRules, err = queryPRReviewers(ctx, tx, prID)
 	if err != nil {
-		return nil, errors.Wrap(err, "PgxStorage.MergePR.GetReviewers")
+		return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.GetReviewers")
 	}
-	defer rows.Close()
 
-	var reviewers []string
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
-			return nil, errors.Wrap(err, "PgxStorage.MergePR.ScanReviewer")
-		}
-		reviewers = append(reviewers, userID)
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdatePRStatus.Commit")
 	}
-	pr.AssignedReviewers = reviewers
 
 	return &pr, nil
 }
@@ -132,3 +222,168 @@ func (p *PgxStorage) PRExists(ctx context.Context, prID string) (bool, error) {
 	}
 	return exists, nil
 }
+
+// ListPRs возвращает PR по фильтрам с keyset-пагинацией по (поле сортировки, pull_request_id)
+func (p *PgxStorage) ListPRs(ctx context.Context, filter en.PRListFilter) ([]*en.PullRequest, error) {
+	sortColumn := "pr.created_at"
+	cursorCast := "::timestamp"
+	if filter.SortBy == en.PRSortName {
+		sortColumn = "pr.pull_request_name"
+		cursorCast = ""
+	}
+	direction, cmp := "DESC", "<"
+	if filter.Order == en.SortAsc {
+		direction, cmp = "ASC", ">"
+	}
+
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conds = append(conds, "pr.status = "+arg(string(filter.Status)))
+	}
+	if filter.AuthorID != "" {
+		conds = append(conds, "pr.author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		conds = append(conds, "EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = pr.pull_request_id AND r.user_id = "+arg(filter.ReviewerID)+")")
+	}
+	if filter.TeamName != "" {
+		conds = append(conds, "pr.team_name = "+arg(filter.TeamName))
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "pr.created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "pr.created_at <= "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conds = append(conds, "pr.merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conds = append(conds, "pr.merged_at <= "+arg(*filter.MergedTo))
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(%s, pr.pull_request_id) %s (%s%s, %s)",
+			sortColumn, cmp, arg(filter.After.SortValue), cursorCast, arg(filter.After.PullRequestID)))
+	}
+
+	q := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.closed_at,
+		       COALESCE(pr.team_name, ''), pr.changed_files, pr.labels
+		FROM pull_requests pr`
+	if len(conds) > 0 {
+		q += "\n\t\tWHERE " + strings.Join(conds, " AND ")
+	}
+	q += fmt.Sprintf("\n\t\tORDER BY %s %s, pr.pull_request_id %s\n\t\tLIMIT %s", sortColumn, direction, direction, arg(filter.Limit))
+
+	rows, err := p.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListPRs")
+	}
+	defer rows.Close()
+
+	var prs []*en.PullRequest
+	byID := make(map[string]*en.PullRequest)
+	ids := make([]string, 0, filter.Limit)
+	for rows.Next() {
+		var pr en.PullRequest
+		var status string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.TeamName,
+			&pr.ChangedFiles, &pr.Labels); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListPRs.Scan")
+		}
+		pr.Status = en.PRStatus(status)
+		pr.AssignedReviewers = []string{}
+		prs = append(prs, &pr)
+		byID[pr.PullRequestID] = &pr
+		ids = append(ids, pr.PullRequestID)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListPRs.RowsError")
+	}
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	const qReviewers = `
+		SELECT pull_request_id, user_id, source_team, owner_rule
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY pull_request_id, user_id
+	`
+	reviewerRows, err := p.pool.Query(ctx, qReviewers, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListPRs.GetReviewers")
+	}
+	defer reviewerRows.Close()
+
+	for reviewerRows.Next() {
+		var prID, userID string
+		var sourceTeam, ownerRule *string
+		if err := reviewerRows.Scan(&prID, &userID, &sourceTeam, &ownerRule); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListPRs.ScanReviewer")
+		}
+		pr := byID[prID]
+		pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+		if sourceTeam != nil {
+			if pr.ReviewerTeams == nil {
+				pr.ReviewerTeams = make(map[string]string)
+			}
+			pr.ReviewerTeams[userID] = *sourceTeam
+		}
+		if ownerRule != nil {
+			if pr.ReviewerRules == nil {
+				pr.ReviewerRules = make(map[string]string)
+			}
+			pr.ReviewerRules[userID] = *ownerRule
+		}
+	}
+	if reviewerRows.Err() != nil {
+		return nil, errors.Wrap(reviewerRows.Err(), "PgxStorage.ListPRs.ReviewersRowsError")
+	}
+
+	return prs, nil
+}
+
+// queryPRReviewers возвращает ревьюверов PR по user_id, команды, из которых они назначены, и правила
+// владения, по которым они выбраны. Назначения без команды или правила в соответствующую карту не попадают
+func queryPRReviewers(ctx context.Context, q querier, prID string) ([]string, map[string]string, map[string]string, error) {
+	const qReviewers = `SELECT user_id, source_team, owner_rule FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY user_id`
+	rows, err := q.Query(ctx, qReviewers, prID)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	var teams, rules map[string]string
+	for rows.Next() {
+		var userID string
+		var sourceTeam, ownerRule *string
+		if err := rows.Scan(&userID, &sourceTeam, &ownerRule); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "scan")
+		}
+		reviewers = append(reviewers, userID)
+		if sourceTeam != nil {
+			if teams == nil {
+				teams = make(map[string]string)
+			}
+			teams[userID] = *sourceTeam
+		}
+		if ownerRule != nil {
+			if rules == nil {
+				rules = make(map[string]string)
+			}
+			rules[userID] = *ownerRule
+		}
+	}
+	if rows.Err() != nil {
+		return nil, nil, nil, errors.Wrap(rows.Err(), "rows")
+	}
+	return reviewers, teams, rules, nil
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// overdueReviewCondition отбирает назначения ревьюверов OPEN PR, у которых к моменту $1 истек SLA команды PR,
+// а вердикта после назначения нет. Ожидает псевдонимы r (pr_reviewers), pr (pull_requests) и t (teams)
+const overdueReviewCondition = `
+	pr.status = 'OPEN' AND t.review_sla_minutes > 0
+	AND r.assigned_at + make_interval(mins => t.review_sla_minutes) <= $1
+	AND NOT EXISTS (
+		SELECT 1 FROM pr_reviews v
+		WHERE v.pull_request_id = r.pull_request_id AND v.reviewer_id = r.user_id AND v.created_at >= r.assigned_at
+	)`
+
+// ListOverdueReviews возвращает просроченные к моменту now назначения команды teamName
+// (или всех команд при пустом teamName) в порядке истечения SLA
+func (p *PgxStorage) ListOverdueReviews(ctx context.Context, teamName string, now time.Time) ([]*en.OverdueReview, error) {
+	q := `
+		SELECT r.pull_request_id, pr.pull_request_name, r.user_id, t.team_name, r.assigned_at,
+		       r.assigned_at + make_interval(mins => t.review_sla_minutes) AS due_at, r.escalated_at
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		JOIN teams t ON t.team_name = pr.team_name
+		WHERE ` + overdueReviewCondition + ` AND ($2 = '' OR t.team_name = $2)
+		ORDER BY due_at, r.pull_request_id, r.user_id
+	`
+	rows, err := p.pool.Query(ctx, q, now, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListOverdueReviews")
+	}
+	defer rows.Close()
+
+	overdue := []*en.OverdueReview{}
+	for rows.Next() {
+		var review en.OverdueReview
+		err := rows.Scan(&review.PullRequestID, &review.PullRequestName, &review.ReviewerID, &review.TeamName,
+			&review.AssignedAt, &review.DueAt, &review.EscalatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListOverdueReviews.Scan")
+		}
+		overdue = append(overdue, &review)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListOverdueReviews.RowsError")
+	}
+	return overdue, nil
+}
+
+// EscalateOverdueReviews забирает до limit еще не эскалированных просроченных назначений и в одной транзакции
+// эскалирует их по настройке команды PR: при reassign ревьювер заменяется, при add_reviewer к PR добавляется
+// еще один ревьювер. Кандидат - активный и доступный участник со свободным лимитом из команды PR, а затем из ее
+// резервных команд по порядку, выбранный стратегией своей команды. Назначение отмечается эскалированным, даже
+// если кандидата не нашлось
+//
+//nolint:funlen
+func (p *PgxStorage) EscalateOverdueReviews(ctx context.Context, now time.Time, limit int, picker func(teamName string) en.ReviewerPicker) ([]en.ReviewEscalation, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// команды блокируются до назначений в алфавитном порядке, как при изменении состава,
+	// чтобы параллельная эскалация и изменение состава не ждали друг друга по кругу
+	const qTeams = `
+		SELECT DISTINCT t.team_name
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		JOIN teams t ON t.team_name = pr.team_name
+		WHERE r.escalated_at IS NULL AND ` + overdueReviewCondition + `
+		ORDER BY t.team_name`
+	teamRows, err := tx.Query(ctx, qTeams, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Teams")
+	}
+	var teams []string
+	for teamRows.Next() {
+		var team string
+		if err := teamRows.Scan(&team); err != nil {
+			teamRows.Close()
+			return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.ScanTeam")
+		}
+		teams = append(teams, team)
+	}
+	teamRows.Close()
+	if teamRows.Err() != nil {
+		return nil, errors.Wrap(teamRows.Err(), "PgxStorage.EscalateOverdueReviews.TeamsRowsError")
+	}
+	if len(teams) == 0 {
+		return []en.ReviewEscalation{}, nil
+	}
+	for _, team := range teams {
+		if err := lockTeam(ctx, tx, team); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.LockTeam")
+		}
+	}
+
+	const qClaim = `
+		SELECT r.pull_request_id, r.user_id, r.assigned_at, pr.author_id, t.team_name, t.sla_escalation
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		JOIN teams t ON t.team_name = pr.team_name
+		WHERE r.escalated_at IS NULL AND ` + overdueReviewCondition + ` AND t.team_name = ANY($3)
+		ORDER BY r.assigned_at, r.pull_request_id, r.user_id
+		LIMIT $2
+		FOR UPDATE OF r, pr SKIP LOCKED`
+	rows, err := tx.Query(ctx, qClaim, now, limit, teams)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Claim")
+	}
+	type overdueAssignment struct {
+		prID, reviewerID, authorID, team string
+		assignedAt                       time.Time
+		action                           en.SLAEscalation
+	}
+	var claimed []overdueAssignment
+	var prIDs []string
+	for rows.Next() {
+		var a overdueAssignment
+		if err := rows.Scan(&a.prID, &a.reviewerID, &a.assignedAt, &a.authorID, &a.team, &a.action); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Scan")
+		}
+		claimed = append(claimed, a)
+		if !containsStr(prIDs, a.prID) {
+			prIDs = append(prIDs, a.prID)
+		}
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.EscalateOverdueReviews.RowsError")
+	}
+
+	reviewers := make(map[string][]string, len(prIDs))
+	for _, prID := range prIDs {
+		reviewers[prID], _, _, err = queryPRReviewers(ctx, tx, prID)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Reviewers")
+		}
+	}
+
+	// кандидаты команд и их резервных команд и нагрузка загружаются один раз на команду PR
+	pools := make(map[string][]reviewerPool)
+	load := make(map[string]int)
+	escalations := []en.ReviewEscalation{}
+	for _, a := range claimed {
+		teamPools, ok := pools[a.team]
+		if !ok {
+			teamPools, err = queryReviewerPools(ctx, tx, a.team, load)
+			if err != nil {
+				return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Candidates")
+			}
+			pools[a.team] = teamPools
+		}
+
+		newID, sourceTeam := pickFromPools(teamPools, load, picker, func(m *en.User) bool {
+			return m.UserID == a.authorID || containsStr(reviewers[a.prID], m.UserID)
+		})
+
+		if err := escalateAssignment(ctx, tx, a.prID, a.reviewerID, newID, sourceTeam, a.action, now); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Escalate")
+		}
+		if newID != "" {
+			reviewers[a.prID] = append(reviewers[a.prID], newID)
+			load[newID]++
+			// снятый ревьювер освобождает место под лимит для следующих назначений пачки
+			if _, known := load[a.reviewerID]; known && a.action == en.EscalationReassign {
+				load[a.reviewerID]--
+			}
+		}
+
+		escalations = append(escalations, en.ReviewEscalation{
+			PullRequestID: a.prID,
+			TeamName:      a.team,
+			Action:        a.action,
+			OldReviewer:   a.reviewerID,
+			NewReviewer:   newID,
+			AssignedAt:    a.assignedAt,
+			EscalatedAt:   now,
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.EscalateOverdueReviews.Commit")
+	}
+	return escalations, nil
+}
+
+// escalateAssignment применяет эскалацию к одному назначению внутри транзакции. sourceTeam - команда, из которой
+// выбран новый ревьювер. Без нового ревьювера назначение только отмечается эскалированным
+func escalateAssignment(ctx context.Context, tx pgx.Tx, prID, oldID, newID, sourceTeam string, action en.SLAEscalation, now time.Time) error {
+	if newID == "" || action == en.EscalationAddReviewer {
+		const qMark = `UPDATE pr_reviewers SET escalated_at = $3 WHERE pull_request_id = $1 AND user_id = $2`
+		if _, err := tx.Exec(ctx, qMark, prID, oldID, now); err != nil {
+			return errors.Wrap(err, "mark escalated")
+		}
+	}
+	if newID == "" {
+		return nil
+	}
+
+	event := en.PREvent{
+		PullRequestID: prID,
+		Type:          en.EventReviewerAssigned,
+		Reason:        en.ReasonReviewSLAExceeded,
+		NewReviewerID: newID,
+	}
+	if action == en.EscalationReassign {
+		const qDel = `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+		if _, err := tx.Exec(ctx, qDel, prID, oldID); err != nil {
+			return errors.Wrap(err, "delete reviewer")
+		}
+		event.Type = en.EventReviewerReassigned
+		event.OldReviewerID = oldID
+	}
+
+	const qIns = `INSERT INTO pr_reviewers (pull_request_id, user_id, source_team) VALUES ($1, $2, $3)
+	              ON CONFLICT (pull_request_id, user_id) DO NOTHING`
+	if _, err := tx.Exec(ctx, qIns, prID, newID, sourceTeam); err != nil {
+		return errors.Wrap(err, "insert reviewer")
+	}
+	if err := insertPREvents(ctx, tx, event); err != nil {
+		return errors.Wrap(err, "insert pr event")
+	}
+	return nil
+}
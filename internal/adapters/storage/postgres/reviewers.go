@@ -9,33 +9,91 @@ import (
 	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
 )
 
-func (p *PgxStorage) ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string) error {
+// ReassignReviewer заменяет ревьювера PR. sourceTeam - команда, из которой выбран новый ревьювер.
+// Пустой oldUserID только добавляет newUserID, пустой newUserID только снимает oldUserID, если у PR останется
+// не меньше minReviewers ревьюверов. PR блокируется на время изменения, изменять можно только ревьюверов OPEN PR
+func (p *PgxStorage) ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string, sourceTeam string, minReviewers int) error {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
 		return errors.Wrap(err, "PgxStorage.ReassignReviewer.BeginTx")
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	const qLock = `SELECT pull_request_id FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`
-	var lockedPRID string
-	err = tx.QueryRow(ctx, qLock, prID).Scan(&lockedPRID)
+	const qLock = `SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`
+	var status string
+	err = tx.QueryRow(ctx, qLock, prID).Scan(&status)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return en.NewNotFoundError("pull request", prID)
+		}
 		return errors.Wrap(err, "PgxStorage.ReassignReviewer.LockPR")
 	}
+	// статус мог измениться после проверки в сервисе
+	switch en.PRStatus(status) {
+	case en.StatusOpen:
+	case en.StatusMerged:
+		return en.NewPRMergedError(prID)
+	default:
+		return en.NewPRNotOpenError(prID, en.PRStatus(status))
+	}
 
-	const qDelete = `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
-	commandTag, err := tx.Exec(ctx, qDelete, prID, oldUserID)
-	if err != nil {
-		return errors.Wrap(err, "PgxStorage.ReassignReviewer.RemoveOld")
+	event := en.PREvent{
+		PullRequestID: prID, Type: en.EventReviewerReassigned, Reason: en.ReasonManualReassign,
+		OldReviewerID: oldUserID, NewReviewerID: newUserID,
 	}
-	if commandTag.RowsAffected() == 0 {
-		return errors.Wrap(pgx.ErrNoRows, "PgxStorage.ReassignReviewer.OldReviewerNotFound")
+	switch {
+	case oldUserID == "":
+		event.Type, event.Reason = en.EventReviewerAssigned, en.ReasonManualAdd
+	case newUserID == "":
+		event.Type, event.Reason = en.EventReviewerRemoved, en.ReasonManualRemove
 	}
 
-	const qInsert = `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
-	_, err = tx.Exec(ctx, qInsert, prID, newUserID)
-	if err != nil {
-		return errors.Wrap(err, "PgxStorage.ReassignReviewer.AssignNew")
+	if oldUserID != "" {
+		const qDelete = `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+		commandTag, err := tx.Exec(ctx, qDelete, prID, oldUserID)
+		if err != nil {
+			return errors.Wrap(err, "PgxStorage.ReassignReviewer.RemoveOld")
+		}
+		if commandTag.RowsAffected() == 0 {
+			return en.NewNotAssignedError(oldUserID, prID)
+		}
+	}
+
+	if newUserID == "" && minReviewers > 0 {
+		// ревьюверы считаются под блокировкой PR, поэтому параллельные снятия не опустят их ниже минимума
+		const qRemaining = `
+			SELECT (SELECT COUNT(*) FROM pr_reviewers WHERE pull_request_id = pr.pull_request_id),
+			       COALESCE(pr.team_name, (SELECT tm.team_name FROM team_members tm
+			                               WHERE tm.user_id = pr.author_id AND tm.is_primary), '')
+			FROM pull_requests pr
+			WHERE pr.pull_request_id = $1
+		`
+		var remaining int
+		var teamName string
+		if err := tx.QueryRow(ctx, qRemaining, prID).Scan(&remaining, &teamName); err != nil {
+			return errors.Wrap(err, "PgxStorage.ReassignReviewer.CountRemaining")
+		}
+		if remaining < minReviewers {
+			return en.NewNotEnoughReviewersError(teamName, minReviewers, remaining)
+		}
+	}
+
+	if newUserID != "" {
+		const qInsert = `
+			INSERT INTO pr_reviewers (pull_request_id, user_id, source_team) VALUES ($1, $2, NULLIF($3, ''))
+			ON CONFLICT DO NOTHING
+		`
+		commandTag, err := tx.Exec(ctx, qInsert, prID, newUserID, sourceTeam)
+		if err != nil {
+			return errors.Wrap(err, "PgxStorage.ReassignReviewer.AssignNew")
+		}
+		if commandTag.RowsAffected() == 0 {
+			return en.NewAlreadyAssignedError(newUserID, prID)
+		}
+	}
+
+	if err = insertPREvents(ctx, tx, event); err != nil {
+		return errors.Wrap(err, "PgxStorage.ReassignReviewer.InsertEvents")
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -83,3 +141,45 @@ func (p *PgxStorage) IsUserAssignedToReviewer(ctx context.Context, prID string,
 	}
 	return exists, nil
 }
+
+func (p *PgxStorage) GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+	load, err := queryOpenReviewLoad(ctx, p.pool, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetOpenReviewLoad")
+	}
+	return load, nil
+}
+
+// querier общий интерфейс пула и транзакции для запросов, которые выполняются в обоих контекстах
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+func queryOpenReviewLoad(ctx context.Context, q querier, userIDs []string) (map[string]int, error) {
+	const qLoad = `
+		SELECT r.user_id, COUNT(*) AS count
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.user_id = ANY($1) AND pr.status = 'OPEN'
+		GROUP BY r.user_id
+	`
+	rows, err := q.Query(ctx, qLoad, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	load := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+		load[userID] = count
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "rows")
+	}
+	return load, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+func (p *PgxStorage) CreateReview(ctx context.Context, review *en.Review) error {
+	const q = `
+		INSERT INTO pr_reviews (pull_request_id, reviewer_id, verdict, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := p.pool.Exec(ctx, q, review.PullRequestID, review.ReviewerID, string(review.Verdict), review.Comment, review.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "PgxStorage.CreateReview")
+	}
+	return nil
+}
+
+func (p *PgxStorage) GetPRReviews(ctx context.Context, prID string) ([]*en.Review, error) {
+	const q = `
+		SELECT pull_request_id, reviewer_id, verdict, comment, created_at
+		FROM pr_reviews
+		WHERE pull_request_id = $1
+		ORDER BY created_at, review_id
+	`
+	rows, err := p.pool.Query(ctx, q, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetPRReviews")
+	}
+	defer rows.Close()
+
+	var reviews []*en.Review
+	for rows.Next() {
+		var review en.Review
+		var verdict string
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &verdict, &review.Comment, &review.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetPRReviews.Scan")
+		}
+		review.Verdict = en.ReviewVerdict(verdict)
+		reviews = append(reviews, &review)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetPRReviews.RowsError")
+	}
+
+	return reviews, nil
+}
@@ -33,10 +33,35 @@ func (p *PgxStorage) GetStats(ctx context.Context) (*en.Stats, error) {
 		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetStats.AssignmentsRowsError")
 	}
 
+	const qOpenAssignments = `
+		SELECT r.user_id, COUNT(*) as count
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE pr.status = 'OPEN'
+		GROUP BY r.user_id
+	`
+	openRows, err := p.pool.Query(ctx, qOpenAssignments)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetStats.QueryOpenAssignments")
+	}
+	defer openRows.Close()
+
+	userOpenAssignments := make(map[string]int)
+	for openRows.Next() {
+		var userID string
+		var count int
+		if err := openRows.Scan(&userID, &count); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.GetStats.ScanOpenAssignment")
+		}
+		userOpenAssignments[userID] = count
+	}
+	if openRows.Err() != nil {
+		return nil, errors.Wrap(openRows.Err(), "PgxStorage.GetStats.OpenAssignmentsRowsError")
+	}
+
 	const qPRStats = `
 		SELECT status, COUNT(*) as count
 		FROM pull_requests
-		WHERE status IN ('OPEN', 'MERGED')
 		GROUP BY status
 	`
 	prRows, err := p.pool.Query(ctx, qPRStats)
@@ -45,17 +70,22 @@ func (p *PgxStorage) GetStats(ctx context.Context) (*en.Stats, error) {
 	}
 	defer prRows.Close()
 
-	var openCount, mergedCount int
+	var prStats en.PRStats
 	for prRows.Next() {
 		var status string
 		var count int
 		if err := prRows.Scan(&status, &count); err != nil {
 			return nil, errors.Wrap(err, "PgxStorage.GetStats.ScanPRStat")
 		}
-		if status == "OPEN" {
-			openCount = count
-		} else if status == "MERGED" {
-			mergedCount = count
+		switch en.PRStatus(status) {
+		case en.StatusDraft:
+			prStats.Draft = count
+		case en.StatusOpen:
+			prStats.Open = count
+		case en.StatusMerged:
+			prStats.Merged = count
+		case en.StatusClosed:
+			prStats.Closed = count
 		}
 	}
 	if prRows.Err() != nil {
@@ -63,10 +93,39 @@ func (p *PgxStorage) GetStats(ctx context.Context) (*en.Stats, error) {
 	}
 
 	return &en.Stats{
-		UserAssignments: userAssignments,
-		PRStats: en.PRStats{
-			Open:   openCount,
-			Merged: mergedCount,
-		},
+		UserAssignments:     userAssignments,
+		UserOpenAssignments: userOpenAssignments,
+		PRStats:             prStats,
 	}, nil
 }
+
+// GetMetricsSnapshot собирает бизнес-метрики для /metrics: PR по статусам и пользователей по активности
+func (p *PgxStorage) GetMetricsSnapshot(ctx context.Context) (*en.MetricsSnapshot, error) {
+	snapshot := &en.MetricsSnapshot{PRsByStatus: map[en.PRStatus]int{
+		en.StatusDraft: 0, en.StatusOpen: 0, en.StatusMerged: 0, en.StatusClosed: 0,
+	}}
+
+	rows, err := p.pool.Query(ctx, `SELECT status, COUNT(*) FROM pull_requests GROUP BY status`)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetMetricsSnapshot.QueryPRs")
+	}
+	for rows.Next() {
+		var status en.PRStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "PgxStorage.GetMetricsSnapshot.ScanPR")
+		}
+		snapshot.PRsByStatus[status] = count
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetMetricsSnapshot.PRsRowsError")
+	}
+
+	const qUsers = `SELECT COUNT(*) FILTER (WHERE is_active), COUNT(*) FILTER (WHERE NOT is_active) FROM users`
+	if err := p.pool.QueryRow(ctx, qUsers).Scan(&snapshot.ActiveUsers, &snapshot.InactiveUsers); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetMetricsSnapshot.Users")
+	}
+	return snapshot, nil
+}
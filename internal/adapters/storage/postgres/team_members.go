@@ -0,0 +1,445 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// reassignLeavingReviewers снимает уходящих пользователей с открытых PR команды teamName и назначает
+// вместо каждого активного и доступного участника со свободным лимитом ревью: из teamName, а если там
+// кандидатов нет - из ее резервных команд по порядку, в каждой ее стратегией picker. Без кандидата ревьювер
+// снимается без замены, но не ниже min_reviewers команды, а при keepUnreplaced остается на PR.
+// Вызывается внутри транзакции изменения состава
+//
+//nolint:funlen
+func reassignLeavingReviewers(ctx context.Context, tx pgx.Tx, teamName string, leaving []string, picker func(teamName string) en.ReviewerPicker, reason string, keepUnreplaced bool) ([]en.PRReassignmentInfo, error) {
+	// активные и доступные сейчас участники команды и резервных команд с их нагрузкой
+	load := make(map[string]int)
+	pools, err := queryReviewerPools(ctx, tx, teamName, load)
+	if err != nil {
+		return nil, errors.Wrap(err, "select candidates")
+	}
+
+	// открытые PR команды с любым из уходящих ревьюверов, полным списком ревьюверов
+	// и минимальным числом ревьюверов из настроек команды
+	const qPRs = `
+		WITH target_prs AS (
+			SELECT DISTINCT pr.pull_request_id, pr.author_id, pr.created_at
+			FROM pull_requests pr
+			JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
+			WHERE r.user_id = ANY($1) AND pr.status = 'OPEN' AND pr.team_name = $2
+		)
+		SELECT t.pull_request_id, t.author_id,
+		       COALESCE(array_agg(r2.user_id ORDER BY r2.user_id) FILTER (WHERE r2.user_id IS NOT NULL), '{}') AS reviewers,
+		       tm.team_name, tm.min_reviewers
+		FROM target_prs t
+		JOIN teams tm ON tm.team_name = $2
+		LEFT JOIN pr_reviewers r2 ON r2.pull_request_id = t.pull_request_id
+		GROUP BY t.pull_request_id, t.author_id, t.created_at, tm.team_name, tm.min_reviewers
+		ORDER BY t.created_at, t.pull_request_id`
+	prRows, err := tx.Query(ctx, qPRs, leaving, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "select prs with reviewers")
+	}
+	type openPR struct {
+		id, authorID, team string
+		reviewers          []string
+		minReviewers       int
+	}
+	var prs []*openPR
+	for prRows.Next() {
+		var pr openPR
+		if err := prRows.Scan(&pr.id, &pr.authorID, &pr.reviewers, &pr.team, &pr.minReviewers); err != nil {
+			prRows.Close()
+			return nil, errors.Wrap(err, "scan pr with reviewers")
+		}
+		prs = append(prs, &pr)
+	}
+	prRows.Close()
+
+	isLeaving := make(map[string]bool, len(leaving))
+	for _, id := range leaving {
+		isLeaving[id] = true
+	}
+
+	infos := []en.PRReassignmentInfo{}
+	for _, pr := range prs {
+		before := len(pr.reviewers)
+		removed := make(map[string]bool)
+		for _, old := range pr.reviewers {
+			if !isLeaving[old] {
+				continue
+			}
+
+			// кандидаты: не автор, не уходящие, не уже назначенные
+			newID, sourceTeam := pickFromPools(pools, load, picker, func(m *en.User) bool {
+				return m.UserID == pr.authorID || isLeaving[m.UserID] || containsStr(pr.reviewers, m.UserID)
+			})
+			if newID == "" && keepUnreplaced {
+				continue
+			}
+
+			const qDel = `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+			if _, err := tx.Exec(ctx, qDel, pr.id, old); err != nil {
+				return nil, errors.Wrap(err, "delete reviewer")
+			}
+			removed[old] = true
+			if newID != "" {
+				const qIns = `INSERT INTO pr_reviewers (pull_request_id, user_id, source_team) VALUES ($1, $2, $3)
+				              ON CONFLICT (pull_request_id, user_id) DO NOTHING`
+				if _, err := tx.Exec(ctx, qIns, pr.id, newID, sourceTeam); err != nil {
+					return nil, errors.Wrap(err, "insert reviewer")
+				}
+				// обновляем локальный список и нагрузку, чтобы следующие PR распределялись с учетом назначения
+				pr.reviewers = append(pr.reviewers, newID)
+				load[newID]++
+			}
+
+			event := en.PREvent{
+				PullRequestID: pr.id,
+				Type:          en.EventReviewerReassigned,
+				Reason:        reason,
+				OldReviewerID: old,
+				NewReviewerID: newID,
+			}
+			if newID == "" {
+				event.Type = en.EventReviewerRemoved
+			}
+			if err := insertPREvents(ctx, tx, event); err != nil {
+				return nil, errors.Wrap(err, "insert pr event")
+			}
+
+			infos = append(infos, en.PRReassignmentInfo{
+				PullRequestID: pr.id,
+				OldReviewer:   old,
+				NewReviewer:   newID,
+			})
+		}
+
+		// снятие ревьюверов без замены не должно опускать PR ниже минимума команды
+		remaining := 0
+		for _, id := range pr.reviewers {
+			if !removed[id] {
+				remaining++
+			}
+		}
+		if remaining < before && remaining < pr.minReviewers {
+			return nil, en.NewNotEnoughReviewersError(pr.team, pr.minReviewers, remaining)
+		}
+	}
+
+	return infos, nil
+}
+
+// reassignInAllTeams переназначает открытые ревью уходящих пользователей во всех командах,
+// из которых назначены их PR, стратегией picker(команда PR). Команды блокируются в алфавитном порядке
+func reassignInAllTeams(ctx context.Context, tx pgx.Tx, leaving []string, picker func(teamName string) en.ReviewerPicker, reason string, keepUnreplaced bool) ([]en.PRReassignmentInfo, error) {
+	const q = `
+		SELECT DISTINCT pr.team_name
+		FROM pull_requests pr
+		JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
+		WHERE r.user_id = ANY($1) AND pr.status = 'OPEN' AND pr.team_name IS NOT NULL
+		ORDER BY pr.team_name`
+	rows, err := tx.Query(ctx, q, leaving)
+	if err != nil {
+		return nil, errors.Wrap(err, "select review teams")
+	}
+	var teams []string
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "scan review team")
+		}
+		teams = append(teams, team)
+	}
+	rows.Close()
+
+	infos := []en.PRReassignmentInfo{}
+	for _, team := range teams {
+		if err := lockTeam(ctx, tx, team); err != nil {
+			return nil, err
+		}
+		teamInfos, err := reassignLeavingReviewers(ctx, tx, team, leaving, picker, reason, keepUnreplaced)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, teamInfos...)
+	}
+	return infos, nil
+}
+
+// reviewerPool активные и доступные участники одной команды, из которой можно назначить ревьювера
+type reviewerPool struct {
+	team    string
+	members []*en.User
+}
+
+// queryReviewerPools возвращает кандидатов команды teamName и затем ее резервных команд в порядке перебора.
+// Нагрузка участников, которых еще нет в load, дописывается в него
+func queryReviewerPools(ctx context.Context, tx pgx.Tx, teamName string, load map[string]int) ([]reviewerPool, error) {
+	fallbacks, err := queryFallbackTeams(ctx, tx, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "select fallback teams")
+	}
+
+	var pools []reviewerPool
+	var unknown []string
+	for _, team := range append([]string{teamName}, fallbacks...) {
+		members, err := queryTeamUsers(ctx, tx, team, true, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "select members")
+		}
+		pools = append(pools, reviewerPool{team: team, members: members})
+		for _, m := range members {
+			if _, seen := load[m.UserID]; !seen && !containsStr(unknown, m.UserID) {
+				unknown = append(unknown, m.UserID)
+			}
+		}
+	}
+
+	teamLoad, err := queryOpenReviewLoad(ctx, tx, unknown)
+	if err != nil {
+		return nil, errors.Wrap(err, "select reviewers load")
+	}
+	for _, id := range unknown {
+		load[id] = teamLoad[id]
+	}
+	return pools, nil
+}
+
+// pickFromPools выбирает ревьювера из первой команды, в которой есть кандидат со свободным лимитом ревью,
+// стратегией этой команды. skip отсекает неподходящих участников. Возвращает пустые строки, если кандидатов нет
+func pickFromPools(pools []reviewerPool, load map[string]int, picker func(teamName string) en.ReviewerPicker, skip func(m *en.User) bool) (string, string) {
+	for _, pool := range pools {
+		var cands []*en.User
+		for _, m := range pool.members {
+			if skip(m) || m.AtCapacity(load[m.UserID]) {
+				continue
+			}
+			cands = append(cands, m)
+		}
+		if len(cands) == 0 {
+			continue
+		}
+		if picked := picker(pool.team)(cands, load, 1); len(picked) > 0 {
+			return picked[0], pool.team
+		}
+	}
+	return "", ""
+}
+
+// lockTeam блокирует строку команды до конца транзакции, чтобы состав не менялся параллельно
+func lockTeam(ctx context.Context, tx pgx.Tx, teamName string) error {
+	const q = `SELECT team_name FROM teams WHERE team_name = $1 FOR UPDATE`
+	var name string
+	if err := tx.QueryRow(ctx, q, teamName).Scan(&name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return en.NewNotFoundError("team", teamName)
+		}
+		return errors.Wrap(err, "lock team")
+	}
+	return nil
+}
+
+// AddTeamMembers добавляет пользователей в команду и обновляет уже существующих.
+// Участники других команд сохраняют прежние команды, основная команда не меняется
+func (p *PgxStorage) AddTeamMembers(ctx context.Context, teamName string, users []*en.User) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "PgxStorage.AddTeamMembers.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return err
+	}
+
+	// навыки без значения (NULL) не меняются
+	const qUser = `
+		INSERT INTO users (user_id, username, is_active, skills)
+		VALUES ($1, $2, $3, COALESCE($4::text[], '{}'))
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			username = EXCLUDED.username,
+			is_active = EXCLUDED.is_active,
+			skills = COALESCE($4::text[], users.skills),
+			updated_at = NOW()
+	`
+	for _, user := range users {
+		if _, err := tx.Exec(ctx, qUser, user.UserID, user.Username, user.IsActive, user.Skills); err != nil {
+			return errors.Wrap(err, "PgxStorage.AddTeamMembers.UpsertUser")
+		}
+		if err := addMembership(ctx, tx, teamName, user.UserID); err != nil {
+			return errors.Wrap(err, "PgxStorage.AddTeamMembers.AddMembership")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "PgxStorage.AddTeamMembers.Commit")
+	}
+	return nil
+}
+
+// RemoveTeamMembers исключает пользователей из команды и переназначает их открытые ревью в PR этой команды
+func (p *PgxStorage) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) en.ReviewerPicker) ([]en.PRReassignmentInfo, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.RemoveTeamMembers.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+	if err := checkTeamMembers(ctx, tx, teamName, userIDs); err != nil {
+		return nil, err
+	}
+
+	infos, err := reassignLeavingReviewers(ctx, tx, teamName, userIDs, picker, en.ReasonLeftTeam, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := removeMemberships(ctx, tx, teamName, userIDs); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.RemoveTeamMembers.Delete")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.RemoveTeamMembers.Commit")
+	}
+	return infos, nil
+}
+
+// MoveTeamMember переводит пользователя из fromTeam в toTeam. Открытые ревью переназначаются
+// внутри fromTeam, PR, где пользователь автор, остаются за ним. Если fromTeam была основной,
+// основной становится toTeam
+func (p *PgxStorage) MoveTeamMember(ctx context.Context, userID, fromTeam, toTeam string, picker func(teamName string) en.ReviewerPicker) ([]en.PRReassignmentInfo, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MoveTeamMember.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// блокируем команды в фиксированном порядке, чтобы встречные переводы не взаимоблокировались
+	first, second := fromTeam, toTeam
+	if second < first {
+		first, second = second, first
+	}
+	if err := lockTeam(ctx, tx, first); err != nil {
+		return nil, err
+	}
+	if err := lockTeam(ctx, tx, second); err != nil {
+		return nil, err
+	}
+	if err := checkTeamMembers(ctx, tx, fromTeam, []string{userID}); err != nil {
+		return nil, err
+	}
+	const qTarget = `SELECT EXISTS (SELECT 1 FROM team_members WHERE user_id = $1 AND team_name = $2)`
+	var already bool
+	if err := tx.QueryRow(ctx, qTarget, userID, toTeam).Scan(&already); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MoveTeamMember.CheckTarget")
+	}
+	if already {
+		return nil, en.NewInvalidTeamUserError(userID, toTeam, "already belongs to")
+	}
+
+	infos, err := reassignLeavingReviewers(ctx, tx, fromTeam, []string{userID}, picker, en.ReasonLeftTeam, false)
+	if err != nil {
+		return nil, err
+	}
+
+	const q = `UPDATE team_members SET team_name = $3, created_at = NOW() WHERE user_id = $1 AND team_name = $2`
+	if _, err := tx.Exec(ctx, q, userID, fromTeam, toTeam); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MoveTeamMember.Update")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.MoveTeamMember.Commit")
+	}
+	return infos, nil
+}
+
+// checkTeamMembers проверяет, что все пользователи существуют и состоят в teamName
+func checkTeamMembers(ctx context.Context, tx pgx.Tx, teamName string, userIDs []string) error {
+	const q = `
+		SELECT u.user_id, EXISTS (SELECT 1 FROM team_members m WHERE m.user_id = u.user_id AND m.team_name = $2)
+		FROM users u
+		WHERE u.user_id = ANY($1)`
+	rows, err := tx.Query(ctx, q, userIDs, teamName)
+	if err != nil {
+		return errors.Wrap(err, "check users")
+	}
+	member := make(map[string]bool, len(userIDs))
+	for rows.Next() {
+		var uid string
+		var ok bool
+		if err := rows.Scan(&uid, &ok); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "scan user check")
+		}
+		member[uid] = ok
+	}
+	rows.Close()
+
+	for _, uid := range userIDs {
+		ok, found := member[uid]
+		if !found {
+			return en.NewNotFoundError("user", uid)
+		}
+		if !ok {
+			return en.NewInvalidTeamUserError(uid, teamName, "does not belong to")
+		}
+	}
+	return nil
+}
+
+// RenameTeam переименовывает команду, членства и PR обновляются каскадом по внешнему ключу
+func (p *PgxStorage) RenameTeam(ctx context.Context, oldName, newName string) (bool, error) {
+	const q = `UPDATE teams SET team_name = $2 WHERE team_name = $1`
+	tag, err := p.pool.Exec(ctx, q, oldName, newName)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.RenameTeam")
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteTeam удаляет команду без участников
+func (p *PgxStorage) DeleteTeam(ctx context.Context, teamName string) (bool, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteTeam.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		var appErr *en.AppError
+		if errors.As(err, &appErr) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	const qCount = `SELECT COUNT(*) FROM team_members WHERE team_name = $1`
+	var members int
+	if err := tx.QueryRow(ctx, qCount, teamName).Scan(&members); err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteTeam.CountMembers")
+	}
+	if members > 0 {
+		return false, en.NewTeamNotEmptyError(teamName, members)
+	}
+
+	const qDelete = `DELETE FROM teams WHERE team_name = $1`
+	if _, err := tx.Exec(ctx, qDelete, teamName); err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteTeam.Delete")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteTeam.Commit")
+	}
+	return true, nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"sort"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/pkg/errors"
@@ -9,58 +10,167 @@ import (
 	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
 )
 
-func (p *PgxStorage) CreateTeamWithUsers(ctx context.Context, teamName string, users []*en.User) error {
+// CreateTeamWithUsers создает команду и пользователей в одной транзакции. Пользователи других команд
+// обрабатываются по opts.ConflictMode: при переводе их открытые ревью переназначаются в прежних командах
+// стратегией picker(прежняя команда), при join прежние команды сохраняются, в режиме dry_run транзакция откатывается
+//
+//nolint:funlen
+func (p *PgxStorage) CreateTeamWithUsers(ctx context.Context, teamName string, settings *en.TeamSettings, users []*en.User, opts en.TeamCreateOptions, picker func(teamName string) en.ReviewerPicker) (*en.TeamCreateResult, error) {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		return errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.BeginTx")
+		return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.BeginTx")
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	const qTeam = `INSERT INTO teams (team_name) VALUES ($1)`
-	_, err = tx.Exec(ctx, qTeam, teamName)
+	const qTeam = `INSERT INTO teams (team_name, min_reviewers, max_reviewers) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(ctx, qTeam, teamName, settings.MinReviewers, settings.MaxReviewers)
 	if err != nil {
-		return errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.CreateTeam")
+		return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.CreateTeam")
 	}
 
+	userIDs := make([]string, len(users))
+	for i, u := range users {
+		userIDs[i] = u.UserID
+	}
+	conflicts, err := queryTeamConflicts(ctx, tx, teamName, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.Conflicts")
+	}
+	if len(conflicts) > 0 && opts.ConflictMode == en.ConflictReject {
+		return nil, en.NewTeamMemberConflictError(teamName, conflicts)
+	}
+
+	// уходящие участники по прежним командам; команды блокируются в алфавитном порядке.
+	// При join участники остаются в прежних командах
+	var moving []en.TeamMemberConflict
+	if opts.ConflictMode != en.ConflictJoin {
+		moving = conflicts
+	}
+	leaving := make(map[string][]string)
+	var oldTeams []string
+	for _, c := range moving {
+		if _, ok := leaving[c.CurrentTeam]; !ok {
+			oldTeams = append(oldTeams, c.CurrentTeam)
+		}
+		leaving[c.CurrentTeam] = append(leaving[c.CurrentTeam], c.UserID)
+	}
+	sort.Strings(oldTeams)
+	reassignments := []en.PRReassignmentInfo{}
+	for _, oldTeam := range oldTeams {
+		if err := lockTeam(ctx, tx, oldTeam); err != nil {
+			return nil, err
+		}
+		infos, err := reassignLeavingReviewers(ctx, tx, oldTeam, leaving[oldTeam], picker, en.ReasonLeftTeam, false)
+		if err != nil {
+			return nil, err
+		}
+		reassignments = append(reassignments, infos...)
+		if err := removeMemberships(ctx, tx, oldTeam, leaving[oldTeam]); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.LeaveTeam")
+		}
+	}
+
+	// навыки без значения (NULL) не меняются
 	const qUser = `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, is_active, skills)
+		VALUES ($1, $2, $3, COALESCE($4::text[], '{}'))
 		ON CONFLICT (user_id)
 		DO UPDATE SET
-			team_name = EXCLUDED.team_name,
 			is_active = EXCLUDED.is_active,
+			skills = COALESCE($4::text[], users.skills),
 			updated_at = NOW()
 	`
 	for _, user := range users {
-		_, err = tx.Exec(ctx, qUser, user.UserID, user.Username, teamName, user.IsActive)
+		_, err = tx.Exec(ctx, qUser, user.UserID, user.Username, user.IsActive, user.Skills)
 		if err != nil {
-			return errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.UpsertUser")
+			return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.UpsertUser")
+		}
+		if err := addMembership(ctx, tx, teamName, user.UserID); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.AddMembership")
 		}
 	}
 
+	result := &en.TeamCreateResult{Conflicts: conflicts, Reassignments: reassignments}
+	if opts.ConflictMode == en.ConflictDryRun {
+		result.DryRun = true
+		return result, nil
+	}
+
 	if err = tx.Commit(ctx); err != nil {
-		return errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.Commit")
+		return nil, errors.Wrap(err, "PgxStorage.CreateTeamWithUsers.Commit")
+	}
+
+	return result, nil
+}
+
+// queryTeamConflicts возвращает членства пользователей из userIDs в других командах
+// с их открытыми ревью в PR этих команд
+func queryTeamConflicts(ctx context.Context, tx pgx.Tx, teamName string, userIDs []string) ([]en.TeamMemberConflict, error) {
+	const q = `
+		SELECT m.user_id, m.team_name,
+		       COALESCE(array_agg(pr.pull_request_id ORDER BY pr.pull_request_id)
+		                FILTER (WHERE pr.pull_request_id IS NOT NULL), '{}')
+		FROM team_members m
+		LEFT JOIN pr_reviewers r ON r.user_id = m.user_id
+		LEFT JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		                          AND pr.status = 'OPEN' AND pr.team_name = m.team_name
+		WHERE m.user_id = ANY($1) AND m.team_name <> $2
+		GROUP BY m.user_id, m.team_name
+		ORDER BY m.user_id, m.team_name
+	`
+	rows, err := tx.Query(ctx, q, userIDs, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []en.TeamMemberConflict{}
+	for rows.Next() {
+		var c en.TeamMemberConflict
+		if err := rows.Scan(&c.UserID, &c.CurrentTeam, &c.OpenReviews); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
 	}
+	return conflicts, rows.Err()
+}
+
+const teamSettingsColumns = `team_name, min_reviewers, max_reviewers, review_sla_minutes, sla_escalation`
 
-	return nil
+func scanTeamSettings(row pgx.Row) (*en.TeamSettings, error) {
+	var settings en.TeamSettings
+	err := row.Scan(&settings.TeamName, &settings.MinReviewers, &settings.MaxReviewers,
+		&settings.ReviewSLAMinutes, &settings.SLAEscalation)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
 }
 
 func (p *PgxStorage) GetTeamByName(ctx context.Context, teamName string) (*en.Team, error) {
-	const qTeam = `SELECT team_name FROM teams WHERE team_name = $1`
-	var tn string
-	err := p.pool.QueryRow(ctx, qTeam, teamName).Scan(&tn)
+	const qTeam = `SELECT ` + teamSettingsColumns + ` FROM teams WHERE team_name = $1`
+	settings, err := scanTeamSettings(p.pool.QueryRow(ctx, qTeam, teamName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, errors.Wrap(err, "PgxStorage.GetTeamByName")
 	}
+	settings.FallbackTeams, err = queryFallbackTeams(ctx, p.pool, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetTeamByName.FallbackTeams")
+	}
+	settings.OwnerRules, err = queryOwnerRules(ctx, p.pool, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetTeamByName.OwnerRules")
+	}
 
 	const qUsers = `
-		SELECT user_id, username, is_active
-		FROM users
-		WHERE team_name = $1
-		ORDER BY user_id
+		SELECT u.user_id, u.username, u.is_active, u.skills
+		FROM users u
+		JOIN team_members m ON m.user_id = u.user_id
+		WHERE m.team_name = $1
+		ORDER BY u.user_id
 	`
 	rows, err := p.pool.Query(ctx, qUsers, teamName)
 	if err != nil {
@@ -71,7 +181,7 @@ func (p *PgxStorage) GetTeamByName(ctx context.Context, teamName string) (*en.Te
 	var members []en.TeamMember
 	for rows.Next() {
 		var member en.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Skills); err != nil {
 			return nil, errors.Wrap(err, "PgxStorage.GetTeamByName.Scan")
 		}
 		members = append(members, member)
@@ -80,7 +190,7 @@ func (p *PgxStorage) GetTeamByName(ctx context.Context, teamName string) (*en.Te
 		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetTeamByName.RowsError")
 	}
 
-	return &en.Team{TeamName: teamName, TeamMembers: members}, nil
+	return &en.Team{TeamName: teamName, TeamMembers: members, Settings: settings}, nil
 }
 
 func (p *PgxStorage) TeamExists(ctx context.Context, teamName string) (bool, error) {
@@ -92,3 +202,151 @@ func (p *PgxStorage) TeamExists(ctx context.Context, teamName string) (bool, err
 	}
 	return exists, nil
 }
+
+func (p *PgxStorage) GetTeamSettings(ctx context.Context, teamName string) (*en.TeamSettings, error) {
+	const q = `SELECT ` + teamSettingsColumns + ` FROM teams WHERE team_name = $1`
+	settings, err := scanTeamSettings(p.pool.QueryRow(ctx, q, teamName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.GetTeamSettings")
+	}
+	settings.FallbackTeams, err = queryFallbackTeams(ctx, p.pool, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetTeamSettings.FallbackTeams")
+	}
+	settings.OwnerRules, err = queryOwnerRules(ctx, p.pool, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetTeamSettings.OwnerRules")
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings изменяет настройки команды. Незаданные поля settings сохраняют прежние значения,
+// списки резервных команд и правил владения заменяются целиком, если не nil. Диапазон ревьюверов проверяется
+// по строке команды под блокировкой, чтобы одновременные изменения min и max не нарушили min <= max
+func (p *PgxStorage) UpdateTeamSettings(ctx context.Context, settings *en.TeamSettingsUpdate) (*en.TeamSettings, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const qLock = `SELECT min_reviewers, max_reviewers FROM teams WHERE team_name = $1 FOR UPDATE`
+	var minReviewers, maxReviewers int
+	if err = tx.QueryRow(ctx, qLock, settings.TeamName).Scan(&minReviewers, &maxReviewers); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.Lock")
+	}
+	if settings.MinReviewers != nil {
+		minReviewers = *settings.MinReviewers
+	}
+	if settings.MaxReviewers != nil {
+		maxReviewers = *settings.MaxReviewers
+	}
+	if minReviewers > maxReviewers {
+		return nil, en.ErrReviewersRange
+	}
+
+	const q = `
+		UPDATE teams
+		SET min_reviewers = $2, max_reviewers = $3,
+		    review_sla_minutes = COALESCE($4::int, review_sla_minutes),
+		    sla_escalation = COALESCE(NULLIF($5, ''), sla_escalation)
+		WHERE team_name = $1
+		RETURNING ` + teamSettingsColumns
+	updated, err := scanTeamSettings(tx.QueryRow(ctx, q, settings.TeamName, minReviewers, maxReviewers,
+		settings.ReviewSLAMinutes, string(settings.SLAEscalation)))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings")
+	}
+
+	if settings.FallbackTeams != nil {
+		const qDelete = `DELETE FROM team_fallbacks WHERE team_name = $1`
+		if _, err = tx.Exec(ctx, qDelete, settings.TeamName); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.ClearFallbacks")
+		}
+		const qInsert = `INSERT INTO team_fallbacks (team_name, fallback_team, position) VALUES ($1, $2, $3)`
+		for i, fallback := range settings.FallbackTeams {
+			if _, err = tx.Exec(ctx, qInsert, settings.TeamName, fallback, i); err != nil {
+				return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.InsertFallback")
+			}
+		}
+	}
+
+	if settings.OwnerRules != nil {
+		const qDelete = `DELETE FROM team_owner_rules WHERE team_name = $1`
+		if _, err = tx.Exec(ctx, qDelete, settings.TeamName); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.ClearOwnerRules")
+		}
+		const qInsert = `INSERT INTO team_owner_rules (team_name, position, pattern, owners) VALUES ($1, $2, $3, $4)`
+		for i, rule := range settings.OwnerRules {
+			if _, err = tx.Exec(ctx, qInsert, settings.TeamName, i, rule.Pattern, rule.Owners); err != nil {
+				return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.InsertOwnerRule")
+			}
+		}
+	}
+
+	updated.FallbackTeams, err = queryFallbackTeams(ctx, tx, settings.TeamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.FallbackTeams")
+	}
+	updated.OwnerRules, err = queryOwnerRules(ctx, tx, settings.TeamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.OwnerRules")
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.UpdateTeamSettings.Commit")
+	}
+	return updated, nil
+}
+
+// queryFallbackTeams возвращает резервные команды teamName в порядке перебора
+func queryFallbackTeams(ctx context.Context, q querier, teamName string) ([]string, error) {
+	const qFallbacks = `SELECT fallback_team FROM team_fallbacks WHERE team_name = $1 ORDER BY position`
+	rows, err := q.Query(ctx, qFallbacks, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	teams := []string{}
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+		teams = append(teams, team)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "rows")
+	}
+	return teams, nil
+}
+
+// queryOwnerRules возвращает правила владения кодом teamName в порядке объявления
+func queryOwnerRules(ctx context.Context, q querier, teamName string) ([]en.OwnerRule, error) {
+	const qRules = `SELECT pattern, owners FROM team_owner_rules WHERE team_name = $1 ORDER BY position`
+	rows, err := q.Query(ctx, qRules, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	defer rows.Close()
+
+	rules := []en.OwnerRule{}
+	for rows.Next() {
+		var rule en.OwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.Owners); err != nil {
+			return nil, errors.Wrap(err, "scan")
+		}
+		rules = append(rules, rule)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "rows")
+	}
+	return rules, nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/storage/postgres"
+
+// ClientOption настраивает пул соединений при создании
+type ClientOption func(*pgxpool.Config)
+
+// WithQueryTracing создает спан на каждый запрос к базе. В pgx v4 нет QueryTracer, поэтому спан строится
+// по записи журнала pgx о завершенном запросе: она приходит с контекстом вызова и длительностью запроса.
+// Запросы вне трассировки (фоновые планировщики) спанов не порождают
+func WithQueryTracing(provider trace.TracerProvider) ClientOption {
+	return func(cfg *pgxpool.Config) {
+		cfg.ConnConfig.Logger = &queryTracer{tracer: provider.Tracer(tracerName)}
+		cfg.ConnConfig.LogLevel = pgx.LogLevelInfo
+	}
+}
+
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *queryTracer) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	elapsed, ok := data["time"].(time.Duration)
+	if !ok || !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	end := time.Now()
+	attrs := []attribute.KeyValue{semconv.DBSystemPostgreSQL, semconv.DBOperationName(msg)}
+	// аргументы запроса в спан не попадают: среди них бывают токены и адреса
+	if sql, ok := data["sql"].(string); ok {
+		attrs = append(attrs, semconv.DBQueryText(sql))
+	}
+	if rows, ok := data["rowCount"].(int); ok {
+		attrs = append(attrs, attribute.Int("db.rows", rows))
+	}
+
+	_, span := t.tracer.Start(ctx, "postgres "+msg,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(end.Add(-elapsed)),
+		trace.WithAttributes(attrs...),
+	)
+	if err, ok := data["err"].(error); ok && level <= pgx.LogLevelError {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
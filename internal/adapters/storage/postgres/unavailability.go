@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+const unavailabilityColumns = `period_id, user_id, starts_at, ends_at, reason, created_at, processed_at`
+
+func scanUnavailability(row pgx.Row) (*en.Unavailability, error) {
+	var period en.Unavailability
+	err := row.Scan(&period.PeriodID, &period.UserID, &period.StartsAt, &period.EndsAt, &period.Reason,
+		&period.CreatedAt, &period.ProcessedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (p *PgxStorage) CreateUnavailability(ctx context.Context, period *en.Unavailability) (*en.Unavailability, error) {
+	q := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + unavailabilityColumns
+	created, err := scanUnavailability(p.pool.QueryRow(ctx, q, period.UserID, period.StartsAt, period.EndsAt, period.Reason))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.CreateUnavailability")
+	}
+	return created, nil
+}
+
+func (p *PgxStorage) GetUnavailability(ctx context.Context, periodID int64) (*en.Unavailability, error) {
+	q := `SELECT ` + unavailabilityColumns + ` FROM user_unavailability WHERE period_id = $1`
+	period, err := scanUnavailability(p.pool.QueryRow(ctx, q, periodID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.GetUnavailability")
+	}
+	return period, nil
+}
+
+// ListUnavailability возвращает периоды пользователя (или всех пользователей при пустом userID),
+// которые еще не закончились к моменту from, в порядке начала
+func (p *PgxStorage) ListUnavailability(ctx context.Context, userID string, from time.Time) ([]*en.Unavailability, error) {
+	q := `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE ($1 = '' OR user_id = $1) AND ends_at > $2
+		ORDER BY starts_at, period_id
+	`
+	rows, err := p.pool.Query(ctx, q, userID, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListUnavailability")
+	}
+	defer rows.Close()
+
+	var periods []*en.Unavailability
+	for rows.Next() {
+		period, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListUnavailability.Scan")
+		}
+		periods = append(periods, period)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListUnavailability.RowsError")
+	}
+	return periods, nil
+}
+
+func (p *PgxStorage) DeleteUnavailability(ctx context.Context, periodID int64) (bool, error) {
+	const q = `DELETE FROM user_unavailability WHERE period_id = $1`
+	tag, err := p.pool.Exec(ctx, q, periodID)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteUnavailability")
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// StartUnavailabilityPeriods забирает до limit необработанных периодов, начавшихся к моменту now, и в одной
+// транзакции переназначает открытые ревью их пользователей стратегией picker(команда PR). Если замены нет,
+// ревьювер остается на PR. Периоды, закончившиеся до обработки, только отмечаются обработанными
+func (p *PgxStorage) StartUnavailabilityPeriods(ctx context.Context, now time.Time, limit int, picker func(teamName string) en.ReviewerPicker) ([]en.PRReassignmentInfo, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.BeginTx")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const qClaim = `
+		SELECT period_id, user_id, ends_at > $1
+		FROM user_unavailability
+		WHERE processed_at IS NULL AND starts_at <= $1
+		ORDER BY starts_at, period_id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(ctx, qClaim, now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.Claim")
+	}
+	var periodIDs []int64
+	var userIDs []string
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var periodID int64
+		var userID string
+		var current bool
+		if err := rows.Scan(&periodID, &userID, &current); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.Scan")
+		}
+		periodIDs = append(periodIDs, periodID)
+		if current && !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.StartUnavailabilityPeriods.RowsError")
+	}
+	if len(periodIDs) == 0 {
+		return []en.PRReassignmentInfo{}, nil
+	}
+
+	infos := []en.PRReassignmentInfo{}
+	if len(userIDs) > 0 {
+		infos, err = reassignInAllTeams(ctx, tx, userIDs, picker, en.ReasonReviewerUnavailable, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.Reassign")
+		}
+	}
+
+	const qProcessed = `UPDATE user_unavailability SET processed_at = $2 WHERE period_id = ANY($1)`
+	if _, err = tx.Exec(ctx, qProcessed, periodIDs, now); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.MarkProcessed")
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.StartUnavailabilityPeriods.Commit")
+	}
+	return infos, nil
+}
@@ -9,16 +9,33 @@ import (
 	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
 )
 
+// userColumns поля пользователя вместе с основной командой, списком всех команд, концом текущего
+// периода недоступности, лимитом ревью и навыками, u - алиас users. Порядок совпадает с scanUser
+const userColumns = `u.user_id, u.username,
+		COALESCE((SELECT m.team_name FROM team_members m WHERE m.user_id = u.user_id AND m.is_primary), ''),
+		ARRAY(SELECT m.team_name FROM team_members m WHERE m.user_id = u.user_id ORDER BY m.team_name),
+		u.is_active, u.created_at, u.updated_at,
+		(SELECT MAX(a.ends_at) FROM user_unavailability a
+		 WHERE a.user_id = u.user_id AND a.starts_at <= NOW() AT TIME ZONE 'UTC' AND a.ends_at > NOW() AT TIME ZONE 'UTC'),
+		u.max_open_reviews, u.skills`
+
+// unavailableNow условие "пользователь u недоступен в текущий момент"
+const unavailableNow = `EXISTS (SELECT 1 FROM user_unavailability a
+		WHERE a.user_id = u.user_id AND a.starts_at <= NOW() AT TIME ZONE 'UTC' AND a.ends_at > NOW() AT TIME ZONE 'UTC')`
+
+func scanUser(row pgx.Row, user *en.User) error {
+	return row.Scan(&user.UserID, &user.Username, &user.TeamName, &user.Teams, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.UnavailableUntil, &user.MaxOpenReviews, &user.Skills)
+}
+
 func (p *PgxStorage) GetUser(ctx context.Context, userID string) (*en.User, error) {
 	const q = `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
-		FROM users
-		WHERE user_id = $1
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.user_id = $1
 	`
 	var user en.User
-	err := p.pool.QueryRow(ctx, q, userID).Scan(
-		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
-	)
+	err := scanUser(p.pool.QueryRow(ctx, q, userID), &user)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -28,43 +45,42 @@ func (p *PgxStorage) GetUser(ctx context.Context, userID string) (*en.User, erro
 	return &user, nil
 }
 
+// GetUsersByTeam возвращает участников команды. TeamName у пользователя - его основная команда,
+// она может отличаться от teamName
 func (p *PgxStorage) GetUsersByTeam(ctx context.Context, teamName string, activeOnly bool) ([]*en.User, error) {
-	var q string
-	if activeOnly {
-		q = `
-			SELECT user_id, username, team_name, is_active, created_at, updated_at
-			FROM users
-			WHERE team_name = $1 AND is_active = true
-			ORDER BY user_id
-		`
-	} else {
-		q = `
-			SELECT user_id, username, team_name, is_active, created_at, updated_at
-			FROM users
-			WHERE team_name = $1
-			ORDER BY user_id
-		`
+	users, err := queryTeamUsers(ctx, p.pool, teamName, activeOnly, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.GetUsersByTeam")
 	}
+	return users, nil
+}
 
-	rows, err := p.pool.Query(ctx, q, teamName)
-
+// queryTeamUsers возвращает участников команды. availableOnly дополнительно исключает пользователей,
+// недоступных в текущий момент
+func queryTeamUsers(ctx context.Context, q querier, teamName string, activeOnly, availableOnly bool) ([]*en.User, error) {
+	const qUsers = `
+		SELECT ` + userColumns + `
+		FROM users u
+		JOIN team_members t ON t.user_id = u.user_id
+		WHERE t.team_name = $1 AND (u.is_active OR NOT $2) AND NOT ($3 AND ` + unavailableNow + `)
+		ORDER BY u.user_id
+	`
+	rows, err := q.Query(ctx, qUsers, teamName, activeOnly, availableOnly)
 	if err != nil {
-		return nil, errors.Wrap(err, "PgxStorage.GetUsersByTeam")
+		return nil, errors.Wrap(err, "query")
 	}
 	defer rows.Close()
 
 	var users []*en.User
 	for rows.Next() {
 		var user en.User
-		if err := rows.Scan(
-			&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
-		); err != nil {
-			return nil, errors.Wrap(err, "PgxStorage.GetUsersByTeam.Scan")
+		if err := scanUser(rows, &user); err != nil {
+			return nil, errors.Wrap(err, "scan")
 		}
 		users = append(users, &user)
 	}
 	if rows.Err() != nil {
-		return nil, errors.Wrap(rows.Err(), "PgxStorage.GetUsersByTeam.RowsError")
+		return nil, errors.Wrap(rows.Err(), "rows")
 	}
 
 	return users, nil
@@ -72,15 +88,13 @@ func (p *PgxStorage) GetUsersByTeam(ctx context.Context, teamName string, active
 
 func (p *PgxStorage) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*en.User, error) {
 	const q = `
-		UPDATE users
+		UPDATE users u
 		SET is_active = $2, updated_at = NOW()
-		WHERE user_id = $1
-		RETURNING user_id, username, team_name, is_active, created_at, updated_at
+		WHERE u.user_id = $1
+		RETURNING ` + userColumns + `
 	`
 	var user en.User
-	err := p.pool.QueryRow(ctx, q, userID, isActive).Scan(
-		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
-	)
+	err := scanUser(p.pool.QueryRow(ctx, q, userID, isActive), &user)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -89,3 +103,78 @@ func (p *PgxStorage) SetUserActiveStatus(ctx context.Context, userID string, isA
 	}
 	return &user, nil
 }
+
+// SetUserReviewCapacity задает лимит открытых ревью пользователя, nil снимает ограничение
+func (p *PgxStorage) SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*en.User, error) {
+	const q = `
+		UPDATE users u
+		SET max_open_reviews = $2, updated_at = NOW()
+		WHERE u.user_id = $1
+		RETURNING ` + userColumns + `
+	`
+	var user en.User
+	err := scanUser(p.pool.QueryRow(ctx, q, userID, maxOpenReviews), &user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.SetUserReviewCapacity")
+	}
+	return &user, nil
+}
+
+// SetUserSkills заменяет навыки пользователя
+func (p *PgxStorage) SetUserSkills(ctx context.Context, userID string, skills []string) (*en.User, error) {
+	const q = `
+		UPDATE users u
+		SET skills = $2, updated_at = NOW()
+		WHERE u.user_id = $1
+		RETURNING ` + userColumns + `
+	`
+	var user en.User
+	err := scanUser(p.pool.QueryRow(ctx, q, userID, skills), &user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.SetUserSkills")
+	}
+	return &user, nil
+}
+
+// addMembership добавляет пользователя в команду. Команда становится основной, если у пользователя
+// ее еще нет. Повторное добавление ничего не меняет
+func addMembership(ctx context.Context, tx pgx.Tx, teamName, userID string) error {
+	const q = `
+		INSERT INTO team_members (team_name, user_id, is_primary)
+		VALUES ($1, $2, NOT EXISTS (SELECT 1 FROM team_members WHERE user_id = $2 AND is_primary))
+		ON CONFLICT (team_name, user_id) DO NOTHING
+	`
+	_, err := tx.Exec(ctx, q, teamName, userID)
+	return err
+}
+
+// removeMemberships исключает пользователей из команды. Тем, у кого она была основной,
+// основной назначается самая ранняя из оставшихся команд
+func removeMemberships(ctx context.Context, tx pgx.Tx, teamName string, userIDs []string) error {
+	const qDelete = `DELETE FROM team_members WHERE team_name = $1 AND user_id = ANY($2)`
+	if _, err := tx.Exec(ctx, qDelete, teamName, userIDs); err != nil {
+		return errors.Wrap(err, "delete memberships")
+	}
+
+	const qPromote = `
+		UPDATE team_members m
+		SET is_primary = true
+		WHERE m.user_id = ANY($1)
+		  AND NOT EXISTS (SELECT 1 FROM team_members p WHERE p.user_id = m.user_id AND p.is_primary)
+		  AND m.team_name = (
+			SELECT f.team_name FROM team_members f
+			WHERE f.user_id = m.user_id
+			ORDER BY f.created_at, f.team_name
+			LIMIT 1)
+	`
+	if _, err := tx.Exec(ctx, qPromote, userIDs); err != nil {
+		return errors.Wrap(err, "promote primary team")
+	}
+	return nil
+}
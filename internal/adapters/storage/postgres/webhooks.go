@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+const webhookSubscriptionColumns = `subscription_id, url, secret, event_types, is_active, created_at`
+
+func scanWebhookSubscription(row pgx.Row) (*en.WebhookSubscription, error) {
+	var sub en.WebhookSubscription
+	var eventTypes []string
+	if err := row.Scan(&sub.SubscriptionID, &sub.URL, &sub.Secret, &eventTypes, &sub.IsActive, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+	sub.EventTypes = make([]en.PREventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = en.PREventType(t)
+	}
+	return &sub, nil
+}
+
+func eventTypeStrings(types []en.PREventType) []string {
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result
+}
+
+func (p *PgxStorage) CreateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	q := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookSubscriptionColumns
+	created, err := scanWebhookSubscription(p.pool.QueryRow(ctx, q, sub.URL, sub.Secret, eventTypeStrings(sub.EventTypes), sub.IsActive))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.CreateWebhookSubscription")
+	}
+	return created, nil
+}
+
+func (p *PgxStorage) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*en.WebhookSubscription, error) {
+	q := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE subscription_id = $1`
+	sub, err := scanWebhookSubscription(p.pool.QueryRow(ctx, q, subscriptionID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.GetWebhookSubscription")
+	}
+	return sub, nil
+}
+
+func (p *PgxStorage) ListWebhookSubscriptions(ctx context.Context) ([]*en.WebhookSubscription, error) {
+	q := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY subscription_id`
+	rows, err := p.pool.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListWebhookSubscriptions")
+	}
+	defer rows.Close()
+
+	var subs []*en.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListWebhookSubscriptions.Scan")
+		}
+		subs = append(subs, sub)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListWebhookSubscriptions.RowsError")
+	}
+	return subs, nil
+}
+
+func (p *PgxStorage) UpdateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	q := `
+		UPDATE webhook_subscriptions
+		SET url = $2, secret = $3, event_types = $4, is_active = $5
+		WHERE subscription_id = $1
+		RETURNING ` + webhookSubscriptionColumns
+	updated, err := scanWebhookSubscription(p.pool.QueryRow(ctx, q,
+		sub.SubscriptionID, sub.URL, sub.Secret, eventTypeStrings(sub.EventTypes), sub.IsActive))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PgxStorage.UpdateWebhookSubscription")
+	}
+	return updated, nil
+}
+
+func (p *PgxStorage) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) (bool, error) {
+	const q = `DELETE FROM webhook_subscriptions WHERE subscription_id = $1`
+	tag, err := p.pool.Exec(ctx, q, subscriptionID)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.DeleteWebhookSubscription")
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ClaimWebhookDeliveries забирает до limit готовых к отправке доставок и откладывает их на lease,
+// чтобы параллельные воркеры не отправили одну доставку дважды
+func (p *PgxStorage) ClaimWebhookDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]*en.WebhookDelivery, error) {
+	const q = `
+		WITH claimed AS (
+			SELECT delivery_id
+			FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, delivery_id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $3
+		FROM claimed, webhook_subscriptions s
+		WHERE d.delivery_id = claimed.delivery_id AND s.subscription_id = d.subscription_id
+		RETURNING d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status,
+		          d.attempts, d.next_attempt_at, d.last_error, d.created_at, d.delivered_at, s.url, s.secret
+	`
+	rows, err := p.pool.Query(ctx, q, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ClaimWebhookDeliveries")
+	}
+	defer rows.Close()
+
+	var deliveries []*en.WebhookDelivery
+	for rows.Next() {
+		var d en.WebhookDelivery
+		var eventType, status string
+		if err := rows.Scan(&d.DeliveryID, &d.SubscriptionID, &d.EventID, &eventType, &d.Payload, &status,
+			&d.Attempts, &d.NextAttemptAt, &d.LastError, &d.CreatedAt, &d.DeliveredAt, &d.URL, &d.Secret); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ClaimWebhookDeliveries.Scan")
+		}
+		d.EventType = en.PREventType(eventType)
+		d.Status = en.DeliveryStatus(status)
+		deliveries = append(deliveries, &d)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ClaimWebhookDeliveries.RowsError")
+	}
+	return deliveries, nil
+}
+
+func (p *PgxStorage) MarkWebhookDelivered(ctx context.Context, deliveryID int64, at time.Time) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = 'DELIVERED', attempts = attempts + 1, delivered_at = $2, last_error = ''
+		WHERE delivery_id = $1
+	`
+	if _, err := p.pool.Exec(ctx, q, deliveryID, at); err != nil {
+		return errors.Wrap(err, "PgxStorage.MarkWebhookDelivered")
+	}
+	return nil
+}
+
+// MarkWebhookFailed фиксирует неудачную попытку. nextAttemptAt == nil переводит доставку в DEAD
+func (p *PgxStorage) MarkWebhookFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt *time.Time) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			last_error = $2,
+			status = CASE WHEN $3::timestamp IS NULL THEN 'DEAD' ELSE 'PENDING' END,
+			next_attempt_at = COALESCE($3::timestamp, next_attempt_at)
+		WHERE delivery_id = $1
+	`
+	if _, err := p.pool.Exec(ctx, q, deliveryID, lastError, nextAttemptAt); err != nil {
+		return errors.Wrap(err, "PgxStorage.MarkWebhookFailed")
+	}
+	return nil
+}
+
+func (p *PgxStorage) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status en.DeliveryStatus) ([]*en.WebhookDelivery, error) {
+	const q = `
+		SELECT delivery_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY delivery_id DESC
+		LIMIT 100
+	`
+	rows, err := p.pool.Query(ctx, q, subscriptionID, string(status))
+	if err != nil {
+		return nil, errors.Wrap(err, "PgxStorage.ListWebhookDeliveries")
+	}
+	defer rows.Close()
+
+	var deliveries []*en.WebhookDelivery
+	for rows.Next() {
+		var d en.WebhookDelivery
+		var eventType, st string
+		if err := rows.Scan(&d.DeliveryID, &d.SubscriptionID, &d.EventID, &eventType, &st,
+			&d.Attempts, &d.NextAttemptAt, &d.LastError, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			return nil, errors.Wrap(err, "PgxStorage.ListWebhookDeliveries.Scan")
+		}
+		d.EventType = en.PREventType(eventType)
+		d.Status = en.DeliveryStatus(st)
+		deliveries = append(deliveries, &d)
+	}
+	if rows.Err() != nil {
+		return nil, errors.Wrap(rows.Err(), "PgxStorage.ListWebhookDeliveries.RowsError")
+	}
+	return deliveries, nil
+}
+
+// RequeueWebhookDelivery возвращает DEAD доставку в очередь со сброшенным счетчиком попыток
+func (p *PgxStorage) RequeueWebhookDelivery(ctx context.Context, deliveryID int64, at time.Time) (bool, error) {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = $2
+		WHERE delivery_id = $1 AND status = 'DEAD'
+	`
+	tag, err := p.pool.Exec(ctx, q, deliveryID, at)
+	if err != nil {
+		return false, errors.Wrap(err, "PgxStorage.RequeueWebhookDelivery")
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Экспортеры спанов
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// Config настройки трассировки
+type Config struct {
+	// Exporter куда отправляются спаны: none, stdout, file или otlp
+	Exporter string
+	// Endpoint URL коллектора OTLP/HTTP, например http://localhost:4318. Пустое значение -
+	// переменные окружения OTEL_EXPORTER_OTLP_* или localhost:4318
+	Endpoint string
+	// File файл для экспортера file, спаны дописываются в него построчно в JSON
+	File string
+	// SampleRatio доля трассировок, начатых сервисом; входящий traceparent решает за родителя
+	SampleRatio float64
+	ServiceName string
+}
+
+// Provider провайдер спанов, который при остановке дописывает буфер и закрывает файл экспортера
+type Provider struct {
+	*sdktrace.TracerProvider
+	closer io.Closer
+}
+
+// NewProvider создает провайдер по конфигурации. При экспортере none возвращает nil
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		if cfg.File == "" {
+			return nil, errors.New("tracing file is required")
+		}
+		file, openErr := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if openErr != nil {
+			return nil, errors.Wrap(openErr, "open tracing file")
+		}
+		closer = file
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter '%s'", cfg.Exporter)
+	}
+	if err != nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, errors.Wrap(err, "create span exporter")
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	)
+	return &Provider{TracerProvider: provider, closer: closer}, nil
+}
+
+// Shutdown отправляет накопленные спаны и останавливает экспортер
+func (p *Provider) Shutdown(ctx context.Context) error {
+	err := p.TracerProvider.Shutdown(ctx)
+	if p.closer != nil {
+		if closeErr := p.closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
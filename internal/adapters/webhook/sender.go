@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// Заголовки исходящих webhook
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// HTTPSender отправляет доставки POST-запросом с JSON телом события
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+// Sign возвращает подпись "sha256=<hex>" от HMAC-SHA256(secret, timestamp + "." + body).
+// Получатель проверяет подпись и отбрасывает запросы со старым timestamp, чтобы исключить повтор
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HTTPSender) Send(ctx context.Context, delivery *en.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return errors.Wrap(err, "build request")
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.DeliveryID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "context"
+
+// SystemActor автор изменений, выполненных без указания пользователя
+const SystemActor = "system"
+
+type actorKey struct{}
+
+// ContextWithActor сохраняет в контексте инициатора запроса для журнала изменений
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает инициатора запроса или SystemActor
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
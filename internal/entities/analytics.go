@@ -0,0 +1,67 @@
+package entities
+
+import "time"
+
+// AnalyticsBucket шаг временного ряда аналитики
+type AnalyticsBucket string
+
+const (
+	BucketDay  AnalyticsBucket = "day"
+	BucketWeek AnalyticsBucket = "week" // неделя начинается с понедельника
+)
+
+// AnalyticsFilter период [From, To) и фильтры аналитики. Команда PR - команда его ревьюверов
+type AnalyticsFilter struct {
+	TeamName string
+	UserID   string // только для нагрузки ревьюверов
+	From     time.Time
+	To       time.Time
+	Bucket   AnalyticsBucket
+}
+
+// ThroughputPoint сколько PR команды создано и смержено в интервале, начинающемся с BucketStart.
+// Интервалы без PR не возвращаются
+type ThroughputPoint struct {
+	TeamName    string    `json:"team_name"`
+	BucketStart time.Time `json:"bucket_start"`
+	Created     int       `json:"created"`
+	Merged      int       `json:"merged"`
+}
+
+// DurationStats медиана и 90-й перцентиль длительностей в секундах, nil при отсутствии данных
+type DurationStats struct {
+	Count         int      `json:"count"`
+	MedianSeconds *float64 `json:"median_seconds"`
+	P90Seconds    *float64 `json:"p90_seconds"`
+}
+
+// TeamTimings время до мержа (от создания PR) для PR, смерженных в периоде, и время до первого вердикта
+// (от первого назначения ревьювера) для PR, получивших первый вердикт в периоде
+type TeamTimings struct {
+	TeamName          string        `json:"team_name,omitempty"`
+	TimeToMerge       DurationStats `json:"time_to_merge"`
+	TimeToFirstReview DurationStats `json:"time_to_first_review"`
+}
+
+// ReviewTimings сроки по всем выбранным командам и по каждой из них
+type ReviewTimings struct {
+	Overall TeamTimings   `json:"overall"`
+	Teams   []TeamTimings `json:"teams"`
+}
+
+// TeamReassignments назначения и замены ревьюверов в PR команды за период. Rate - доля замен среди всех назначений
+type TeamReassignments struct {
+	TeamName      string         `json:"team_name"`
+	Assignments   int            `json:"assignments"`
+	Reassignments int            `json:"reassignments"`
+	Rate          float64        `json:"rate"`
+	Reasons       map[string]int `json:"reasons"`
+}
+
+// WorkloadPoint сколько ревью пользователю назначено и сколько вердиктов он оставил в интервале
+type WorkloadPoint struct {
+	UserID      string    `json:"user_id"`
+	BucketStart time.Time `json:"bucket_start"`
+	Assigned    int       `json:"assigned"`
+	Reviewed    int       `json:"reviewed"`
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin" // управление командами, пользователями и интеграциями
+	RoleUser  Role = "user"
+)
+
+// AdminPrincipal идентификатор владельца статического токена администратора
+const AdminPrincipal = "admin"
+
+// Principal аутентифицированный клиент API
+type Principal struct {
+	UserID string
+	Role   Role
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p != nil && p.Role == RoleAdmin
+}
+
+// APIToken персональный токен пользователя. В базе хранится только sha256 хэш,
+// сам токен возвращается один раз при выпуске
+type APIToken struct {
+	TokenID   int64      `json:"token_id"`
+	UserID    string     `json:"user_id"`
+	Role      Role       `json:"role"`
+	Token     string     `json:"token,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal сохраняет в контексте аутентифицированного клиента
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext возвращает клиента запроса или nil, если аутентификация выключена
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
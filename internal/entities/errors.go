@@ -2,22 +2,36 @@ package entities
 
 import (
 	"fmt"
+	"strings"
 )
 
 var (
 	ErrNilDependency = fmt.Errorf("nil dependency provided")
+	// ErrReviewersRange настройки команды, в которых min_reviewers больше max_reviewers
+	ErrReviewersRange = fmt.Errorf("min_reviewers cannot be greater than max_reviewers")
 )
 
 type ErrorCode string
 
 const (
-	ErrCodeTeamExists       ErrorCode = "TEAM_EXISTS"
-	ErrCodePRExists         ErrorCode = "PR_EXISTS"
-	ErrCodePRMerged         ErrorCode = "PR_MERGED"
-	ErrCodeNotAssigned      ErrorCode = "NOT_ASSIGNED"
-	ErrCodeNoCandidate      ErrorCode = "NO_CANDIDATE"
-	ErrCodeNotFound         ErrorCode = "NOT_FOUND"
-	ErrCodeInvalidTeamUser  ErrorCode = "INVALID_TEAM_USER"
+	ErrCodeTeamExists         ErrorCode = "TEAM_EXISTS"
+	ErrCodePRExists           ErrorCode = "PR_EXISTS"
+	ErrCodePRMerged           ErrorCode = "PR_MERGED"
+	ErrCodeNotAssigned        ErrorCode = "NOT_ASSIGNED"
+	ErrCodeAlreadyAssigned    ErrorCode = "ALREADY_ASSIGNED"
+	ErrCodeInvalidReviewer    ErrorCode = "INVALID_REVIEWER"
+	ErrCodeNoCandidate        ErrorCode = "NO_CANDIDATE"
+	ErrCodeNoCapacity         ErrorCode = "NO_CAPACITY"
+	ErrCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrCodeInvalidTeamUser    ErrorCode = "INVALID_TEAM_USER"
+	ErrCodeNotEnoughReviewers ErrorCode = "NOT_ENOUGH_REVIEWERS"
+	ErrCodeInvalidTransition  ErrorCode = "INVALID_STATUS_TRANSITION"
+	ErrCodePRNotOpen          ErrorCode = "PR_NOT_OPEN"
+	ErrCodeMergeBlocked       ErrorCode = "MERGE_BLOCKED"
+	ErrCodeTeamNotEmpty       ErrorCode = "TEAM_NOT_EMPTY"
+	ErrCodeTeamMemberConflict ErrorCode = "TEAM_MEMBER_CONFLICT"
+	ErrCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden          ErrorCode = "FORBIDDEN"
 )
 
 type AppError struct {
@@ -57,6 +71,21 @@ func NewNotAssignedError(userID, prID string) *AppError {
 	}
 }
 
+func NewAlreadyAssignedError(userID, prID string) *AppError {
+	return &AppError{
+		Code:    ErrCodeAlreadyAssigned,
+		Message: fmt.Sprintf("user '%s' is already assigned to PR '%s'", userID, prID),
+	}
+}
+
+// NewInvalidReviewerError пользователь не может быть ревьювером PR, reason - почему
+func NewInvalidReviewerError(userID, prID, reason string) *AppError {
+	return &AppError{
+		Code:    ErrCodeInvalidReviewer,
+		Message: fmt.Sprintf("user '%s' cannot review PR '%s': %s", userID, prID, reason),
+	}
+}
+
 func NewNoCandidateError(teamName string) *AppError {
 	return &AppError{
 		Code:    ErrCodeNoCandidate,
@@ -64,6 +93,13 @@ func NewNoCandidateError(teamName string) *AppError {
 	}
 }
 
+func NewNoCapacityError(teamName string) *AppError {
+	return &AppError{
+		Code:    ErrCodeNoCapacity,
+		Message: fmt.Sprintf("all candidates in team '%s' have reached their review capacity", teamName),
+	}
+}
+
 func NewNotFoundError(resource, id string) *AppError {
 	return &AppError{
 		Code:    ErrCodeNotFound,
@@ -77,3 +113,63 @@ func NewInvalidTeamUserError(userID, teamName string, reason string) *AppError {
 		Message: fmt.Sprintf("user '%s' %s team '%s'", userID, reason, teamName),
 	}
 }
+
+func NewNotEnoughReviewersError(teamName string, minReviewers, available int) *AppError {
+	return &AppError{
+		Code:    ErrCodeNotEnoughReviewers,
+		Message: fmt.Sprintf("team '%s' requires at least %d reviewers, only %d available", teamName, minReviewers, available),
+	}
+}
+
+func NewInvalidTransitionError(prID string, from, to PRStatus) *AppError {
+	return &AppError{
+		Code:    ErrCodeInvalidTransition,
+		Message: fmt.Sprintf("cannot change PR '%s' status from %s to %s", prID, from, to),
+	}
+}
+
+func NewPRNotOpenError(prID string, status PRStatus) *AppError {
+	return &AppError{
+		Code:    ErrCodePRNotOpen,
+		Message: fmt.Sprintf("PR '%s' is %s, reviewers can be changed only on OPEN PR", prID, status),
+	}
+}
+
+func NewMergeBlockedError(prID string, reason string) *AppError {
+	return &AppError{
+		Code:    ErrCodeMergeBlocked,
+		Message: fmt.Sprintf("PR '%s' cannot be merged: %s", prID, reason),
+	}
+}
+
+func NewTeamNotEmptyError(teamName string, members int) *AppError {
+	return &AppError{
+		Code:    ErrCodeTeamNotEmpty,
+		Message: fmt.Sprintf("team '%s' still has %d members", teamName, members),
+	}
+}
+
+func NewTeamMemberConflictError(teamName string, conflicts []TeamMemberConflict) *AppError {
+	users := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		users[i] = fmt.Sprintf("%s (%s, %d open reviews)", c.UserID, c.CurrentTeam, len(c.OpenReviews))
+	}
+	return &AppError{
+		Code:    ErrCodeTeamMemberConflict,
+		Message: fmt.Sprintf("cannot create team '%s': users already belong to other teams: %s", teamName, strings.Join(users, ", ")),
+	}
+}
+
+func NewUnauthorizedError(reason string) *AppError {
+	return &AppError{
+		Code:    ErrCodeUnauthorized,
+		Message: reason,
+	}
+}
+
+func NewForbiddenError(reason string) *AppError {
+	return &AppError{
+		Code:    ErrCodeForbidden,
+		Message: reason,
+	}
+}
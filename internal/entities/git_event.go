@@ -0,0 +1,55 @@
+package entities
+
+import "fmt"
+
+type GitProvider string
+
+const (
+	GitProviderGitHub GitProvider = "github"
+	GitProviderGitLab GitProvider = "gitlab"
+)
+
+type GitPRAction string
+
+const (
+	GitActionOpened         GitPRAction = "opened"
+	GitActionClosed         GitPRAction = "closed"
+	GitActionMerged         GitPRAction = "merged"
+	GitActionReopened       GitPRAction = "reopened"
+	GitActionReadyForReview GitPRAction = "ready_for_review"
+)
+
+// GitPREvent событие pull/merge request из git-хостинга, приведенное к общему виду
+type GitPREvent struct {
+	Provider    GitProvider
+	Action      GitPRAction
+	Repository  string // owner/repo
+	Number      int64
+	Title       string
+	AuthorLogin string // логин автора PR во внешней системе
+	SenderLogin string // логин инициатора события во внешней системе
+	Draft       bool
+}
+
+// PullRequestID идентификатор PR в сервисе: <provider>:<repository>#<number>
+func (e GitPREvent) PullRequestID() string {
+	return fmt.Sprintf("%s:%s#%d", e.Provider, e.Repository, e.Number)
+}
+
+// GitEventResult итог обработки события: processed — PR изменен, ignored — событие не требует действий
+type GitEventResult struct {
+	Status string       `json:"status"`
+	PR     *PullRequest `json:"pr,omitempty"`
+}
+
+const (
+	GitEventProcessed = "processed"
+	GitEventIgnored   = "ignored"
+)
+
+// ExternalIdentity соответствие логина во внешней системе пользователю сервиса
+type ExternalIdentity struct {
+	Provider GitProvider `json:"provider"`
+	Login    string      `json:"login"`
+	UserID   string      `json:"user_id"`
+}
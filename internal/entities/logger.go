@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger сохраняет в контексте логгер с атрибутами запроса или фоновой задачи
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext возвращает логгер из контекста или логгер по умолчанию
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,8 @@
+package entities
+
+// MetricsSnapshot текущие значения бизнес-метрик из базы. Общие для всех экземпляров сервиса
+type MetricsSnapshot struct {
+	PRsByStatus   map[PRStatus]int
+	ActiveUsers   int
+	InactiveUsers int
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimezone часовой пояс пользователя без настроек уведомлений
+const DefaultTimezone = "UTC"
+
+// DefaultDigestTime местное время отправки дайджеста, если оно не задано ни пользователем, ни конфигурацией
+const DefaultDigestTime = "09:00"
+
+// NotificationSettings настройки напоминаний пользователя о назначенных ревью
+type NotificationSettings struct {
+	UserID string `json:"user_id"`
+	// Email адрес для SMTP-уведомлений, пустой - письма не отправляются
+	Email        string `json:"email"`
+	DigestOptOut bool   `json:"digest_opt_out"`
+	// QuietHoursStart и QuietHoursEnd - "HH:MM" местного времени, в этот промежуток дайджест не отправляется.
+	// Промежуток может переходить через полночь, пустые значения - без тихих часов
+	QuietHoursStart string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string `json:"quiet_hours_end,omitempty"`
+	// DigestTime "HH:MM" местного времени, начиная с которого отправляется дайджест; пустое - время сервиса
+	DigestTime string `json:"digest_time,omitempty"`
+	// Timezone часовой пояс IANA, в котором считаются тихие часы, время отправки и сутки дайджеста
+	Timezone     string     `json:"timezone"`
+	LastDigestAt *time.Time `json:"last_digest_at,omitempty"`
+}
+
+func NewDefaultNotificationSettings(userID string) *NotificationSettings {
+	return &NotificationSettings{UserID: userID, Timezone: DefaultTimezone}
+}
+
+// ParseTimeOfDay переводит "HH:MM" в минуты от начала суток
+func ParseTimeOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day '%s', expected HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// DigestReview открытый PR в дайджесте ревьювера
+type DigestReview struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	// AgeSeconds сколько прошло с назначения к моменту формирования дайджеста
+	AgeSeconds int64 `json:"age_seconds"`
+}
+
+// ReviewDigest сводка открытых ревью пользователя, самые старые назначения первыми
+type ReviewDigest struct {
+	UserID      string         `json:"user_id"`
+	Username    string         `json:"username"`
+	Email       string         `json:"email,omitempty"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Reviews     []DigestReview `json:"reviews"`
+	// Settings настройки уведомлений получателя, по ним планировщик решает, отправлять ли дайджест
+	Settings *NotificationSettings `json:"-"`
+}
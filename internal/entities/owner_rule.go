@@ -0,0 +1,11 @@
+package entities
+
+// OwnerTeamPrefix отличает команду-владельца от пользователя в OwnerRule.Owners
+const OwnerTeamPrefix = "@"
+
+// OwnerRule правило владения кодом в стиле CODEOWNERS: изменения файлов, подходящих под Pattern,
+// в первую очередь ревьюят Owners. Владелец - user_id или имя команды с префиксом "@"
+type OwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+type PREventType string
+
+const (
+	EventPRCreated          PREventType = "PR_CREATED"
+	EventStatusChanged      PREventType = "STATUS_CHANGED"
+	EventReviewerAssigned   PREventType = "REVIEWER_ASSIGNED"
+	EventReviewerReassigned PREventType = "REVIEWER_REASSIGNED"
+	EventReviewerRemoved    PREventType = "REVIEWER_REMOVED"
+)
+
+// Причины изменений в журнале PR
+const (
+	ReasonCreated             = "pr_created"
+	ReasonAutoAssign          = "auto_assign"
+	ReasonManualReassign      = "manual_reassign"
+	ReasonManualAdd           = "manual_add"
+	ReasonManualRemove        = "manual_remove"
+	ReasonReviewerDeactivated = "reviewer_deactivated"
+	ReasonLeftTeam            = "left_team"
+	ReasonReviewerUnavailable = "reviewer_unavailable"
+	ReasonReviewSLAExceeded   = "review_sla_exceeded"
+	ReasonStatusChange        = "status_change"
+)
+
+// PREvent запись журнала изменений PR. Журнал только дополняется и пишется в той же транзакции, что и изменение
+type PREvent struct {
+	EventID       int64       `json:"event_id"`
+	PullRequestID string      `json:"pull_request_id"`
+	Type          PREventType `json:"type"`
+	Actor         string      `json:"actor"`
+	Reason        string      `json:"reason"`
+	OldReviewerID string      `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string      `json:"new_reviewer_id,omitempty"`
+	OldStatus     PRStatus    `json:"old_status,omitempty"`
+	NewStatus     PRStatus    `json:"new_status,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// Поля сортировки списка PR
+const (
+	PRSortCreatedAt = "created_at"
+	PRSortName      = "pull_request_name"
+)
+
+// Направления сортировки
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// PRListFilter фильтры, сортировка и пагинация списка PR. Пустые поля не ограничивают выборку
+type PRListFilter struct {
+	Status      PRStatus
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string // команда ревьюверов PR
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	SortBy      string
+	Order       string
+	Limit       int
+	Cursor      string    // непрозрачный курсор из предыдущей страницы
+	After       *PRCursor // декодированный курсор, заполняется юзкейсом для хранилища
+}
+
+// PRCursor позиция в отсортированном списке: значение поля сортировки и id последнего PR страницы
+type PRCursor struct {
+	SortBy        string `json:"s"`
+	SortValue     string `json:"v"`
+	PullRequestID string `json:"id"`
+}
+
+// PRPage страница списка PR. NextCursor пустой, если страница последняя
+type PRPage struct {
+	PullRequests []*PullRequest `json:"pull_requests"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
+}
@@ -5,8 +5,10 @@ import "time"
 type PRStatus string
 
 const (
+	StatusDraft  PRStatus = "DRAFT"
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
 type PullRequest struct {
@@ -15,8 +17,25 @@ type PullRequest struct {
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	TeamName          string     `json:"team_name,omitempty"` // команда, из которой назначаются ревьюверы
 	CreatedAt         time.Time  `json:"created_at,omitempty"`
 	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+	// ReviewerTeams команда, из которой назначен каждый ревьювер; отличается от TeamName для резервных команд
+	ReviewerTeams map[string]string `json:"reviewer_teams,omitempty"`
+	// ChangedFiles измененные файлы PR, ReviewerRules - шаблон правила владения, по которому выбран ревьювер
+	ChangedFiles  []string          `json:"changed_files,omitempty"`
+	ReviewerRules map[string]string `json:"reviewer_rules,omitempty"`
+	// Labels метки PR, ревьюверы с совпадающими навыками выбираются в первую очередь
+	Labels []string `json:"labels,omitempty"`
+}
+
+// PRCreateOptions дополнительные параметры создания PR
+type PRCreateOptions struct {
+	Draft        bool     // черновик создается без ревьюверов, они назначаются при markReady
+	TeamName     string   // команда ревьюверов; по умолчанию основная команда автора
+	ChangedFiles []string // пути измененных файлов для выбора владельцев кода
+	Labels       []string // метки PR для подбора ревьюверов по навыкам
 }
 
 func NewPullRequest(id string, name string, authorID string, status PRStatus, reviewers []string, createdAt time.Time, mergedAt *time.Time) *PullRequest {
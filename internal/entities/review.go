@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type ReviewVerdict string
+
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	VerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+// Review вердикт назначенного ревьювера по PR. Ревьювер может оставлять несколько ревью, учитывается последнее
+type Review struct {
+	PullRequestID string        `json:"pull_request_id"`
+	ReviewerID    string        `json:"reviewer_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+	Comment       string        `json:"comment,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
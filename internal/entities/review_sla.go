@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// SLAEscalation действие с назначением, по которому ревьювер не оставил вердикт за SLA команды
+type SLAEscalation string
+
+const (
+	// EscalationReassign заменяет просрочившего ревьювера другим участником команды
+	EscalationReassign SLAEscalation = "reassign"
+	// EscalationAddReviewer оставляет ревьювера и добавляет к PR еще одного
+	EscalationAddReviewer SLAEscalation = "add_reviewer"
+)
+
+// OverdueReview назначение ревьювера OPEN PR без вердикта, у которого истек SLA команды PR
+type OverdueReview struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerID      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	DueAt           time.Time `json:"due_at"`
+	// EscalatedAt когда назначение было эскалировано; при add_reviewer ревьювер остается на PR
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+}
+
+// ReviewEscalation результат эскалации просроченного назначения
+type ReviewEscalation struct {
+	PullRequestID string        `json:"pull_request_id"`
+	TeamName      string        `json:"team_name"`
+	Action        SLAEscalation `json:"action"`
+	OldReviewer   string        `json:"old_reviewer"`
+	NewReviewer   string        `json:"new_reviewer"` // пустая строка, если подходящего кандидата не нашлось
+	AssignedAt    time.Time     `json:"assigned_at"`
+	EscalatedAt   time.Time     `json:"escalated_at"`
+}
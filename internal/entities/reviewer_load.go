@@ -0,0 +1,8 @@
+package entities
+
+// ReviewerLoad текущая нагрузка пользователя как ревьювера
+type ReviewerLoad struct {
+	UserID         string `json:"user_id"`
+	OpenReviews    int    `json:"open_reviews"`               // назначенные ревью OPEN PR
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"` // nil - без ограничения
+}
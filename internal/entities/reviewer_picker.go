@@ -0,0 +1,5 @@
+package entities
+
+// ReviewerPicker выбирает до count ревьюверов из кандидатов.
+// load содержит количество OPEN PR на ревью у каждого кандидата
+type ReviewerPicker func(candidates []*User, load map[string]int, count int) []string
@@ -1,11 +1,14 @@
 package entities
 
 type Stats struct {
-	UserAssignments map[string]int `json:"user_assignments"`
-	PRStats         PRStats        `json:"pr_stats"`
+	UserAssignments     map[string]int `json:"user_assignments"`
+	UserOpenAssignments map[string]int `json:"user_open_assignments"` // текущая нагрузка: назначения только на OPEN PR
+	PRStats             PRStats        `json:"pr_stats"`
 }
 
 type PRStats struct {
+	Draft  int `json:"draft"`
 	Open   int `json:"open"`
 	Merged int `json:"merged"`
+	Closed int `json:"closed"`
 }
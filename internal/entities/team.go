@@ -1,8 +1,9 @@
 package entities
 
 type Team struct {
-	TeamName    string       `json:"team_name"`
-	TeamMembers []TeamMember `json:"members"`
+	TeamName    string        `json:"team_name"`
+	TeamMembers []TeamMember  `json:"members"`
+	Settings    *TeamSettings `json:"settings,omitempty"`
 }
 
 func NewTeam(name string, members []TeamMember) *Team {
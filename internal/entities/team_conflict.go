@@ -0,0 +1,32 @@
+package entities
+
+// TeamConflictMode поведение /team/add, если пользователь из запроса уже состоит в другой команде
+type TeamConflictMode string
+
+const (
+	ConflictMove   TeamConflictMode = "move"    // перевести, открытые ревью переназначить в прежних командах
+	ConflictJoin   TeamConflictMode = "join"    // добавить в новую команду, сохранив прежние
+	ConflictReject TeamConflictMode = "reject"  // отказать с TEAM_MEMBER_CONFLICT
+	ConflictDryRun TeamConflictMode = "dry_run" // выполнить перевод и откатить транзакцию, вернув отчет
+)
+
+// TeamCreateOptions дополнительные параметры создания команды
+type TeamCreateOptions struct {
+	ConflictMode TeamConflictMode
+}
+
+// TeamMemberConflict пользователь из запроса, уже состоящий в другой команде. Для участника
+// нескольких команд возвращается по записи на каждую
+type TeamMemberConflict struct {
+	UserID      string   `json:"user_id"`
+	CurrentTeam string   `json:"current_team"`
+	OpenReviews []string `json:"open_reviews"` // OPEN PR команды, где пользователь назначен ревьювером
+}
+
+// TeamCreateResult итог создания команды. Team не заполняется хранилищем
+type TeamCreateResult struct {
+	Team          *Team
+	Conflicts     []TeamMemberConflict
+	Reassignments []PRReassignmentInfo
+	DryRun        bool
+}
@@ -4,4 +4,6 @@ type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	// Skills навыки пользователя; nil оставляет сохраненные навыки без изменений
+	Skills []string `json:"skills,omitempty"`
 }
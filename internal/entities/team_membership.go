@@ -0,0 +1,10 @@
+package entities
+
+// TeamMembershipChange результат исключения участников или перевода пользователя в другую команду.
+// Открытые ревью ушедших участников переназначаются внутри прежней команды
+type TeamMembershipChange struct {
+	UserIDs       []string             `json:"user_ids"`
+	FromTeam      string               `json:"from_team"`
+	ToTeam        string               `json:"to_team,omitempty"`
+	Reassignments []PRReassignmentInfo `json:"reassigned_prs"`
+}
@@ -0,0 +1,45 @@
+package entities
+
+// Значения по умолчанию совпадают с исходным поведением: "до 2 ревьюверов"
+const (
+	DefaultMinReviewers = 0
+	DefaultMaxReviewers = 2
+)
+
+// TeamSettings настройки назначения ревьюверов для PR команды
+type TeamSettings struct {
+	TeamName     string `json:"team_name"`
+	MinReviewers int    `json:"min_reviewers"`
+	MaxReviewers int    `json:"max_reviewers"`
+	// FallbackTeams команды, из которых по порядку добираются ревьюверы, когда в команде не хватает кандидатов
+	FallbackTeams []string `json:"fallback_teams"`
+	// OwnerRules правила владения кодом; владельцы измененных файлов выбираются раньше остальных кандидатов
+	OwnerRules []OwnerRule `json:"owner_rules"`
+	// ReviewSLAMinutes время на вердикт с момента назначения ревьювера, 0 - без SLA
+	ReviewSLAMinutes int `json:"review_sla_minutes"`
+	// SLAEscalation действие при просрочке SLA
+	SLAEscalation SLAEscalation `json:"sla_escalation"`
+}
+
+// TeamSettingsUpdate частичное изменение настроек команды: nil-поля и пустая эскалация оставляют прежние значения
+type TeamSettingsUpdate struct {
+	TeamName     string
+	MinReviewers *int
+	MaxReviewers *int
+	// FallbackTeams и OwnerRules, если не nil, заменяют список целиком
+	FallbackTeams    []string
+	OwnerRules       []OwnerRule
+	ReviewSLAMinutes *int
+	SLAEscalation    SLAEscalation
+}
+
+func NewDefaultTeamSettings(teamName string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:      teamName,
+		MinReviewers:  DefaultMinReviewers,
+		MaxReviewers:  DefaultMaxReviewers,
+		FallbackTeams: []string{},
+		OwnerRules:    []OwnerRule{},
+		SLAEscalation: EscalationReassign,
+	}
+}
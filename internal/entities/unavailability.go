@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// Unavailability период, когда пользователь не назначается ревьювером: отпуск, отсутствие в офисе.
+// В отличие от is_active период ограничен по времени и снимается сам
+type Unavailability struct {
+	PeriodID    int64      `json:"period_id"`
+	UserID      string     `json:"user_id"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"` // когда открытые ревью пользователя были переназначены
+}
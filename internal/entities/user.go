@@ -5,10 +5,51 @@ import "time"
 type User struct {
 	UserID    string    `json:"user_id"`
 	Username  string    `json:"username"`
-	TeamName  string    `json:"team_name"`
+	TeamName  string    `json:"team_name"` // основная команда
+	Teams     []string  `json:"teams"`     // все команды пользователя, включая основную
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// UnavailableUntil конец текущего периода недоступности, nil - пользователь доступен
+	UnavailableUntil *time.Time `json:"unavailable_until,omitempty"`
+	// MaxOpenReviews максимум одновременных ревью OPEN PR, nil - без ограничения
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Skills навыки пользователя в нижнем регистре, по ним ревьюверы подбираются к меткам PR
+	Skills []string `json:"skills,omitempty"`
+}
+
+// UnavailableAt сообщает, идет ли у пользователя период недоступности в момент at
+func (u *User) UnavailableAt(at time.Time) bool {
+	return u.UnavailableUntil != nil && u.UnavailableUntil.After(at)
+}
+
+// AtCapacity сообщает, достиг ли пользователь с openReviews открытыми ревью своего лимита
+func (u *User) AtCapacity(openReviews int) bool {
+	return u.MaxOpenReviews != nil && openReviews >= *u.MaxOpenReviews
+}
+
+// SkillOverlap возвращает, сколько меток labels совпадает с навыками пользователя
+func (u *User) SkillOverlap(labels []string) int {
+	overlap := 0
+	for _, label := range labels {
+		for _, skill := range u.Skills {
+			if skill == label {
+				overlap++
+				break
+			}
+		}
+	}
+	return overlap
+}
+
+// InTeam сообщает, состоит ли пользователь в команде teamName
+func (u *User) InTeam(teamName string) bool {
+	for _, t := range u.Teams {
+		if t == teamName {
+			return true
+		}
+	}
+	return false
 }
 
 func NewUser(id string, name string, team string, active bool, createdAt time.Time, updatedAt time.Time) *User {
@@ -16,6 +57,7 @@ func NewUser(id string, name string, team string, active bool, createdAt time.Ti
 		UserID:    id,
 		Username:  name,
 		TeamName:  team,
+		Teams:     []string{team},
 		IsActive:  active,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
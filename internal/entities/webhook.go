@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+// WebhookSubscription подписка внешней системы на события PR.
+// Пустой EventTypes означает подписку на все события
+type WebhookSubscription struct {
+	SubscriptionID int64         `json:"subscription_id"`
+	URL            string        `json:"url"`
+	Secret         string        `json:"-"`
+	EventTypes     []PREventType `json:"event_types"`
+	IsActive       bool          `json:"is_active"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD" // попытки исчерпаны, доставка ждет ручного повтора
+)
+
+// WebhookDelivery доставка одного события одной подписке. Строки создаются в транзакции изменения PR (outbox)
+type WebhookDelivery struct {
+	DeliveryID     int64          `json:"delivery_id"`
+	SubscriptionID int64          `json:"subscription_id"`
+	EventID        int64          `json:"event_id"`
+	EventType      PREventType    `json:"event_type"`
+	Payload        []byte         `json:"-"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastError      string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+
+	// адрес и секрет подписки, заполняются при выборке доставок воркером
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
@@ -0,0 +1,123 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+type ThroughputResponse struct {
+	Points []entities.ThroughputPoint `json:"points"`
+}
+
+type ReviewTimingsResponse struct {
+	Timings *entities.ReviewTimings `json:"timings"`
+}
+
+type ReassignmentStatsResponse struct {
+	Teams []entities.TeamReassignments `json:"teams"`
+}
+
+type WorkloadTrendResponse struct {
+	Points []entities.WorkloadPoint `json:"points"`
+}
+
+// parseAnalyticsFilter разбирает query-параметры /stats/*. Границы периода принимаются в RFC3339 или как дата YYYY-MM-DD (UTC)
+func parseAnalyticsFilter(query url.Values) (entities.AnalyticsFilter, error) {
+	filter := entities.AnalyticsFilter{
+		TeamName: query.Get("team_name"),
+		UserID:   query.Get("user_id"),
+		Bucket:   entities.AnalyticsBucket(query.Get("bucket")),
+	}
+
+	dates := []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	}
+	for _, d := range dates {
+		raw := query.Get(d.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, raw)
+		}
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s '%s': expected RFC3339 or YYYY-MM-DD", d.name, raw)
+		}
+		*d.dst = t.UTC()
+	}
+
+	return filter, nil
+}
+
+func (s *Server) handleGetPRThroughput(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAnalyticsFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	points, err := s.service.GetPRThroughput(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ThroughputResponse{Points: points})
+}
+
+func (s *Server) handleGetReviewTimings(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAnalyticsFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	timings, err := s.service.GetReviewTimings(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ReviewTimingsResponse{Timings: timings})
+}
+
+func (s *Server) handleGetReassignmentStats(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAnalyticsFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	teams, err := s.service.GetReassignmentStats(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ReassignmentStatsResponse{Teams: teams})
+}
+
+func (s *Server) handleGetWorkloadTrend(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAnalyticsFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	points, err := s.service.GetWorkloadTrend(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, WorkloadTrendResponse{Points: points})
+}
@@ -0,0 +1,158 @@
+package public
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// TokenVerifier проверяет подписанный токен (JWT) без обращения к хранилищу
+type TokenVerifier interface {
+	Verify(token string) (*entities.Principal, error)
+}
+
+// AuthConfig настройки аутентификации. Аутентификация включается, если задан
+// токен администратора или JWT; персональные токены пользователей проверяются через сервис
+type AuthConfig struct {
+	AdminToken string
+	JWT        TokenVerifier
+}
+
+func (c AuthConfig) enabled() bool {
+	return c.AdminToken != "" || c.JWT != nil
+}
+
+// WithAuth включает bearer-аутентификацию и проверку ролей
+func WithAuth(cfg AuthConfig) ServerOption {
+	return func(s *Server) {
+		s.auth = cfg
+	}
+}
+
+// authenticate проверяет заголовок Authorization: Bearer <token>. Инициатором в журнале
+// становится владелец токена, заголовок X-Actor-ID при включенной аутентификации игнорируется
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.auth.enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.respondWithError(w, http.StatusUnauthorized, "UNAUTHORIZED", "missing bearer token")
+			return
+		}
+
+		principal, err := s.resolvePrincipal(r, token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			s.handleError(w, err)
+			return
+		}
+
+		ctx := entities.ContextWithPrincipal(r.Context(), principal)
+		ctx = entities.ContextWithActor(ctx, principal.UserID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (s *Server) resolvePrincipal(r *http.Request, token string) (*entities.Principal, error) {
+	if s.auth.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.auth.AdminToken)) == 1 {
+		return &entities.Principal{UserID: entities.AdminPrincipal, Role: entities.RoleAdmin}, nil
+	}
+	if s.auth.JWT != nil && strings.Count(token, ".") == 2 {
+		principal, err := s.auth.JWT.Verify(token)
+		if err != nil {
+			return nil, entities.NewUnauthorizedError(err.Error())
+		}
+		return principal, nil
+	}
+	return s.service.AuthenticateAPIToken(r.Context(), token)
+}
+
+// requireAdmin пропускает только администраторов
+func (s *Server) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.auth.enabled() && !entities.PrincipalFromContext(r.Context()).IsAdmin() {
+			s.respondWithError(w, http.StatusForbidden, "FORBIDDEN", "admin role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// actingAs проверяет, что запрос выполняется от имени userID или администратором
+func (s *Server) actingAs(w http.ResponseWriter, r *http.Request, userID string) bool {
+	if !s.auth.enabled() {
+		return true
+	}
+	principal := entities.PrincipalFromContext(r.Context())
+	if principal.IsAdmin() || (principal != nil && principal.UserID == userID) {
+		return true
+	}
+	s.respondWithError(w, http.StatusForbidden, "FORBIDDEN", "access to another user's data is not allowed")
+	return false
+}
+
+type IssueAPITokenRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type APITokenResponse struct {
+	Token *entities.APIToken `json:"token"`
+}
+
+type APITokensResponse struct {
+	Tokens []*entities.APIToken `json:"tokens"`
+}
+
+type RevokeAPITokenRequest struct {
+	TokenID int64 `json:"token_id"`
+}
+
+func (s *Server) handleIssueAPIToken(w http.ResponseWriter, r *http.Request) {
+	var req IssueAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	token, err := s.service.IssueAPIToken(r.Context(), req.UserID, entities.Role(req.Role))
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusCreated, APITokenResponse{Token: token})
+}
+
+func (s *Server) handleListAPITokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := s.service.ListAPITokens(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, APITokensResponse{Tokens: tokens})
+}
+
+func (s *Server) handleRevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	var req RevokeAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := s.service.RevokeAPIToken(r.Context(), req.TokenID); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, req)
+}
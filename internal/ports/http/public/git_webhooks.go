@@ -0,0 +1,238 @@
+package public
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// максимальный размер тела входящего webhook
+const maxGitWebhookBody = 5 << 20
+
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int64  `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+type gitLabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int64  `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+}
+
+// handleGitHubWebhook принимает событие pull_request. Подпись X-Hub-Signature-256 — HMAC-SHA256 тела
+func (s *Server) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	if s.githubSecret == "" {
+		s.respondWithError(w, http.StatusNotFound, "NOT_FOUND", "github webhook is not configured")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxGitWebhookBody))
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if !validGitHubSignature(s.githubSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		s.respondWithError(w, http.StatusUnauthorized, "INVALID_SIGNATURE", "signature mismatch")
+		return
+	}
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		s.respondWithJSON(w, http.StatusOK, entities.GitEventResult{Status: entities.GitEventIgnored})
+		return
+	}
+
+	var payload gitHubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	action := entities.GitPRAction(payload.Action)
+	if action == entities.GitActionClosed && payload.PullRequest.Merged {
+		action = entities.GitActionMerged
+	}
+	s.applyGitEvent(w, r, entities.GitPREvent{
+		Provider:    entities.GitProviderGitHub,
+		Action:      action,
+		Repository:  payload.Repository.FullName,
+		Number:      payload.PullRequest.Number,
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+		SenderLogin: payload.Sender.Login,
+		Draft:       payload.PullRequest.Draft,
+	})
+}
+
+// handleGitLabWebhook принимает событие merge_request. GitLab передает секрет в X-Gitlab-Token
+func (s *Server) handleGitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if s.gitlabSecret == "" {
+		s.respondWithError(w, http.StatusNotFound, "NOT_FOUND", "gitlab webhook is not configured")
+		return
+	}
+	token := r.Header.Get("X-Gitlab-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.gitlabSecret)) != 1 {
+		s.respondWithError(w, http.StatusUnauthorized, "INVALID_SIGNATURE", "token mismatch")
+		return
+	}
+
+	var payload gitLabMergeRequestPayload
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxGitWebhookBody)).Decode(&payload); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if payload.ObjectKind != "merge_request" {
+		s.respondWithJSON(w, http.StatusOK, entities.GitEventResult{Status: entities.GitEventIgnored})
+		return
+	}
+
+	// у GitLab автор открытия MR совпадает с инициатором события
+	s.applyGitEvent(w, r, entities.GitPREvent{
+		Provider:    entities.GitProviderGitLab,
+		Action:      gitLabAction(payload.ObjectAttributes.Action),
+		Repository:  payload.Project.PathWithNamespace,
+		Number:      payload.ObjectAttributes.IID,
+		Title:       payload.ObjectAttributes.Title,
+		AuthorLogin: payload.User.Username,
+		SenderLogin: payload.User.Username,
+		Draft:       payload.ObjectAttributes.Draft,
+	})
+}
+
+func (s *Server) applyGitEvent(w http.ResponseWriter, r *http.Request, event entities.GitPREvent) {
+	result, err := s.service.HandleGitEvent(r.Context(), event)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+	if result.PR != nil {
+		pr := newPullRequestResponse(result.PR)
+		s.respondWithJSON(w, http.StatusOK, GitEventResponse{Status: result.Status, PR: &pr})
+		return
+	}
+	s.respondWithJSON(w, http.StatusOK, GitEventResponse{Status: result.Status})
+}
+
+type GitEventResponse struct {
+	Status string               `json:"status"`
+	PR     *PullRequestResponse `json:"pr,omitempty"`
+}
+
+func gitLabAction(action string) entities.GitPRAction {
+	switch action {
+	case "open":
+		return entities.GitActionOpened
+	case "close":
+		return entities.GitActionClosed
+	case "merge":
+		return entities.GitActionMerged
+	case "reopen":
+		return entities.GitActionReopened
+	default:
+		return entities.GitPRAction(action)
+	}
+}
+
+func validGitHubSignature(secret string, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+type ExternalIdentityRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type ExternalIdentityResponse struct {
+	Identity *entities.ExternalIdentity `json:"identity"`
+}
+
+type ExternalIdentitiesResponse struct {
+	Identities []*entities.ExternalIdentity `json:"identities"`
+}
+
+func (s *Server) handleSetExternalIdentity(w http.ResponseWriter, r *http.Request) {
+	var req ExternalIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	identity, err := s.service.SetExternalIdentity(r.Context(), &entities.ExternalIdentity{
+		Provider: entities.GitProvider(req.Provider),
+		Login:    req.Login,
+		UserID:   req.UserID,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ExternalIdentityResponse{Identity: identity})
+}
+
+func (s *Server) handleListExternalIdentities(w http.ResponseWriter, r *http.Request) {
+	provider := entities.GitProvider(r.URL.Query().Get("provider"))
+
+	identities, err := s.service.ListExternalIdentities(r.Context(), provider)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ExternalIdentitiesResponse{Identities: identities})
+}
+
+func (s *Server) handleDeleteExternalIdentity(w http.ResponseWriter, r *http.Request) {
+	var req ExternalIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := s.service.DeleteExternalIdentity(r.Context(), entities.GitProvider(req.Provider), req.Login); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, req)
+}
@@ -0,0 +1,95 @@
+package public
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// RequestIDHeader заголовок с идентификатором запроса. Входящее значение сохраняется, иначе генерируется новое
+const RequestIDHeader = "X-Request-ID"
+
+// входящий идентификатор попадает в логи, поэтому принимается только короткий и без спецсимволов
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// WithLogger задает логгер запросов (по умолчанию slog.Default())
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// logRequests присваивает запросу идентификатор, кладет в контекст логгер с ним и trace_id
+// и после обработки пишет строку журнала доступа
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID := r.Header.Get(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		logger := s.logger.With("request_id", requestID)
+		// заголовок выставляет traceRequests, если трассировка включена
+		if traceID := w.Header().Get(TraceIDHeader); traceID != "" {
+			logger = logger.With("trace_id", traceID)
+		}
+		ctx := entities.ContextWithLogger(r.Context(), logger)
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "http request",
+			slog.String("method", r.Method),
+			slog.String("route", routePattern(r)),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
+
+// recoverPanics отвечает 500 на панику обработчика и пишет ее в лог запроса вместе со стеком
+func recoverPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+			if rvr == http.ErrAbortHandler {
+				panic(rvr)
+			}
+			entities.LoggerFromContext(r.Context()).ErrorContext(r.Context(), "panic recovered",
+				"panic", fmt.Sprint(rvr), "stack", string(debug.Stack()))
+			w.WriteHeader(http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
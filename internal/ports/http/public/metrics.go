@@ -0,0 +1,47 @@
+package public
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// HTTPMetrics учитывает обработанные запросы и отдает метрики на /metrics
+type HTTPMetrics interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+	Handler() http.Handler
+}
+
+// WithMetrics включает учет запросов и эндпоинт /metrics. Эндпоинт не требует токена,
+// доступ к нему ограничивается на уровне сети
+func WithMetrics(metrics HTTPMetrics) ServerOption {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
+// observeRequests учитывает запрос по шаблону маршрута chi; запросы к несуществующим путям
+// попадают в один маршрут "unmatched", чтобы случайные пути не порождали новые серии
+func (s *Server) observeRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		s.metrics.ObserveHTTPRequest(r.Method, routePattern(r), status, time.Since(start))
+	})
+}
+
+// routePattern возвращает шаблон маршрута chi, по которому обработан запрос, или "unmatched"
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	return "unmatched"
+}
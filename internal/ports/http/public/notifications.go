@@ -0,0 +1,86 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// UpdateNotificationSettingsRequest заменяет настройки целиком, пустые quiet_hours_* отключают тихие часы,
+// пустой digest_time - время отправки сервиса
+type UpdateNotificationSettingsRequest struct {
+	UserID          string `json:"user_id"`
+	Email           string `json:"email"`
+	DigestOptOut    bool   `json:"digest_opt_out"`
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+	DigestTime      string `json:"digest_time"`
+	Timezone        string `json:"timezone"`
+}
+
+type NotificationSettingsResponse struct {
+	Settings *entities.NotificationSettings `json:"settings"`
+}
+
+type ReviewDigestResponse struct {
+	Digest *entities.ReviewDigest `json:"digest"`
+}
+
+func (s *Server) handleGetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if !s.actingAs(w, r, userID) {
+		return
+	}
+
+	settings, err := s.service.GetNotificationSettings(r.Context(), userID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, NotificationSettingsResponse{Settings: settings})
+}
+
+func (s *Server) handleUpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	var req UpdateNotificationSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if !s.actingAs(w, r, req.UserID) {
+		return
+	}
+
+	settings, err := s.service.UpdateNotificationSettings(r.Context(), &entities.NotificationSettings{
+		UserID:          req.UserID,
+		Email:           req.Email,
+		DigestOptOut:    req.DigestOptOut,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		DigestTime:      req.DigestTime,
+		Timezone:        req.Timezone,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, NotificationSettingsResponse{Settings: settings})
+}
+
+// handleGetReviewDigest показывает дайджест, который пользователь получил бы сейчас, без отправки
+func (s *Server) handleGetReviewDigest(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if !s.actingAs(w, r, userID) {
+		return
+	}
+
+	digest, err := s.service.GetReviewDigest(r.Context(), userID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, ReviewDigestResponse{Digest: digest})
+}
@@ -8,17 +8,67 @@ import (
 
 // интерфейс юзкейса имплементируется в хендлерах и используется для вызова бизнес-логики
 type PRReviewService interface {
-	CreateTeam(ctx context.Context, teamName string, members []entities.TeamMember) (*entities.Team, error)
+	CreateTeam(ctx context.Context, teamName string, members []entities.TeamMember, settings *entities.TeamSettings, opts entities.TeamCreateOptions) (*entities.TeamCreateResult, error)
 	GetTeam(ctx context.Context, teamName string) (*entities.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*entities.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, settings *entities.TeamSettingsUpdate) (*entities.TeamSettings, error)
+	AddTeamMembers(ctx context.Context, teamName string, members []entities.TeamMember) (*entities.Team, error)
+	RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*entities.TeamMembershipChange, error)
+	MoveTeamMember(ctx context.Context, userID, fromTeam, toTeam string) (*entities.TeamMembershipChange, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (*entities.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
 
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*entities.User, error)
 	GetUserReviews(ctx context.Context, userID string) ([]*entities.PullRequestShort, error)
+	SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*entities.User, error)
+	GetReviewerLoad(ctx context.Context, userID string) (*entities.ReviewerLoad, error)
+	SetUserSkills(ctx context.Context, userID string, skills []string) (*entities.User, error)
+	CreateUnavailability(ctx context.Context, period *entities.Unavailability) (*entities.Unavailability, error)
+	GetUnavailability(ctx context.Context, periodID int64) (*entities.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string) ([]*entities.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, periodID int64) error
+	GetNotificationSettings(ctx context.Context, userID string) (*entities.NotificationSettings, error)
+	UpdateNotificationSettings(ctx context.Context, settings *entities.NotificationSettings) (*entities.NotificationSettings, error)
+	GetReviewDigest(ctx context.Context, userID string) (*entities.ReviewDigest, error)
 
-	CreatePullRequest(ctx context.Context, prID, prName, authorID string) (*entities.PullRequest, error)
+	CreatePullRequest(ctx context.Context, prID, prName, authorID string, opts entities.PRCreateOptions) (*entities.PullRequest, error)
+	GetPullRequest(ctx context.Context, prID string) (*entities.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter entities.PRListFilter) (*entities.PRPage, error)
+	GetPullRequestHistory(ctx context.Context, prID string) ([]*entities.PREvent, error)
+	ListOverdueReviews(ctx context.Context, teamName string) ([]*entities.OverdueReview, error)
 	MergePullRequest(ctx context.Context, prID string) (*entities.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID string) (*entities.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID string) (*entities.PullRequest, error)
+	MarkPullRequestReady(ctx context.Context, prID string) (*entities.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, verdict entities.ReviewVerdict, comment string) (*entities.Review, error)
 	ReassignReviewer(ctx context.Context, prID, oldUserID string) (pr *entities.PullRequest, newReviewerID string, err error)
+	ReassignReviewerTo(ctx context.Context, prID, oldUserID, newUserID string) (*entities.PullRequest, error)
+	AddReviewer(ctx context.Context, prID, userID string) (*entities.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*entities.PullRequest, error)
 
 	DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) (*entities.DeactivateResult, error)
 
 	GetStats(ctx context.Context) (*entities.Stats, error)
+	GetPRThroughput(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.ThroughputPoint, error)
+	GetReviewTimings(ctx context.Context, filter entities.AnalyticsFilter) (*entities.ReviewTimings, error)
+	GetReassignmentStats(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.TeamReassignments, error)
+	GetWorkloadTrend(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.WorkloadPoint, error)
+
+	CreateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*entities.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status entities.DeliveryStatus) ([]*entities.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, deliveryID int64) error
+
+	HandleGitEvent(ctx context.Context, event entities.GitPREvent) (*entities.GitEventResult, error)
+	SetExternalIdentity(ctx context.Context, identity *entities.ExternalIdentity) (*entities.ExternalIdentity, error)
+	ListExternalIdentities(ctx context.Context, provider entities.GitProvider) ([]*entities.ExternalIdentity, error)
+	DeleteExternalIdentity(ctx context.Context, provider entities.GitProvider, login string) error
+
+	IssueAPIToken(ctx context.Context, userID string, role entities.Role) (*entities.APIToken, error)
+	AuthenticateAPIToken(ctx context.Context, token string) (*entities.Principal, error)
+	ListAPITokens(ctx context.Context, userID string) ([]*entities.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID int64) error
 }
@@ -0,0 +1,22 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+type OverdueReviewsResponse struct {
+	Overdue []*entities.OverdueReview `json:"overdue"`
+}
+
+// handleListOverdueReviews без team_name возвращает просроченные назначения всех команд
+func (s *Server) handleListOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	overdue, err := s.service.ListOverdueReviews(r.Context(), r.URL.Query().Get("team_name"))
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, OverdueReviewsResponse{Overdue: overdue})
+}
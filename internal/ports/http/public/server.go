@@ -1,68 +1,190 @@
 package public
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Server struct {
 	service PRReviewService
 	router  *chi.Mux
+
+	githubSecret string
+	gitlabSecret string
+
+	auth AuthConfig
+
+	metrics HTTPMetrics
+	tracer  trace.Tracer
+	logger  *slog.Logger
+}
+
+// ServerOption настраивает Server при создании
+type ServerOption func(*Server)
+
+// WithGitWebhookSecrets задает секреты входящих webhook GitHub и GitLab. Пустой секрет отключает эндпоинт
+func WithGitWebhookSecrets(githubSecret, gitlabSecret string) ServerOption {
+	return func(s *Server) {
+		s.githubSecret = githubSecret
+		s.gitlabSecret = gitlabSecret
+	}
 }
 
-func NewServer(service PRReviewService) (*Server, error) {
+func NewServer(service PRReviewService, opts ...ServerOption) (*Server, error) {
 	if service == nil {
 		return nil, errors.Wrap(entities.ErrNilDependency, "public server service")
 	}
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
 	s := &Server{
 		service: service,
-		router:  r,
+		router:  chi.NewRouter(),
+		logger:  slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	if s.tracer != nil {
+		s.router.Use(s.traceRequests)
+	}
+	s.router.Use(s.logRequests)
+	if s.metrics != nil {
+		// снаружи recoverPanics, чтобы запросы с паникой тоже учитывались как 500
+		s.router.Use(s.observeRequests)
+	}
+	s.router.Use(recoverPanics)
+	s.router.Use(actorMiddleware)
 	s.setupRoutes()
 	return s, nil
 }
 
+// ActorHeader заголовок с идентификатором инициатора запроса для журнала изменений PR
+const ActorHeader = "X-Actor-ID"
+
+func actorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(entities.ContextWithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (s *Server) GetRouter() *chi.Mux {
 	return s.router
 }
 
 func (s *Server) setupRoutes() {
-	s.router.Post("/team/add", s.handleCreateTeam)
-	s.router.Get("/team/get", s.handleGetTeam)
-
-	s.router.Post("/users/setIsActive", s.handleSetUserActive)
-	s.router.Get("/users/getReview", s.handleGetUserReviews)
-
-	s.router.Post("/pullRequest/create", s.handleCreatePR)
-	s.router.Post("/pullRequest/merge", s.handleMergePR)
-	s.router.Post("/pullRequest/reassign", s.handleReassignReviewer)
-
-	s.router.Post("/team/deactivateMembers", s.handleDeactivateMembers)
-
-	s.router.Get("/stats", s.handleGetStats)
+	// входящие события git-хостингов проверяются подписью, а не bearer-токеном
+	s.router.Post("/webhooks/github", s.handleGitHubWebhook)
+	s.router.Post("/webhooks/gitlab", s.handleGitLabWebhook)
+	if s.metrics != nil {
+		s.router.Method(http.MethodGet, "/metrics", s.metrics.Handler())
+	}
+
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.authenticate)
+
+		r.Get("/team/get", s.handleGetTeam)
+		r.Get("/team/settings", s.handleGetTeamSettings)
+
+		r.Get("/users/getReview", s.handleGetUserReviews)
+		r.Post("/users/unavailability/create", s.handleCreateUnavailability)
+		r.Get("/users/unavailability/list", s.handleListUnavailability)
+		r.Post("/users/unavailability/delete", s.handleDeleteUnavailability)
+		r.Get("/users/notifications", s.handleGetNotificationSettings)
+		r.Post("/users/notifications", s.handleUpdateNotificationSettings)
+		r.Get("/users/digest", s.handleGetReviewDigest)
+
+		r.Post("/pullRequest/create", s.handleCreatePR)
+		r.Get("/pullRequest/get", s.handleGetPR)
+		r.Get("/pullRequest/list", s.handleListPRs)
+		r.Get("/pullRequest/history", s.handleGetPRHistory)
+		r.Get("/pullRequest/overdue", s.handleListOverdueReviews)
+		r.Post("/pullRequest/merge", s.handleMergePR)
+		r.Post("/pullRequest/reassign", s.handleReassignReviewer)
+		r.Post("/pullRequest/addReviewer", s.handleAddReviewer)
+		r.Post("/pullRequest/removeReviewer", s.handleRemoveReviewer)
+		r.Post("/pullRequest/review", s.handleSubmitReview)
+		r.Post("/pullRequest/close", s.handleClosePR)
+		r.Post("/pullRequest/reopen", s.handleReopenPR)
+		r.Post("/pullRequest/markReady", s.handleMarkPRReady)
+
+		r.Get("/stats", s.handleGetStats)
+		r.Get("/stats/throughput", s.handleGetPRThroughput)
+		r.Get("/stats/timings", s.handleGetReviewTimings)
+		r.Get("/stats/reassignments", s.handleGetReassignmentStats)
+		r.Get("/stats/workload", s.handleGetWorkloadTrend)
+
+		r.Group(func(r chi.Router) {
+			r.Use(s.requireAdmin)
+
+			r.Post("/team/add", s.handleCreateTeam)
+			r.Post("/team/settings", s.handleUpdateTeamSettings)
+			r.Post("/team/deactivateMembers", s.handleDeactivateMembers)
+			r.Post("/team/members/add", s.handleAddTeamMembers)
+			r.Post("/team/members/remove", s.handleRemoveTeamMembers)
+			r.Post("/team/members/move", s.handleMoveTeamMember)
+			r.Post("/team/rename", s.handleRenameTeam)
+			r.Post("/team/delete", s.handleDeleteTeam)
+
+			r.Post("/users/setIsActive", s.handleSetUserActive)
+			r.Post("/users/setCapacity", s.handleSetUserCapacity)
+			r.Post("/users/setSkills", s.handleSetUserSkills)
+
+			r.Post("/webhooks/subscriptions/create", s.handleCreateWebhookSubscription)
+			r.Get("/webhooks/subscriptions/get", s.handleGetWebhookSubscription)
+			r.Get("/webhooks/subscriptions/list", s.handleListWebhookSubscriptions)
+			r.Post("/webhooks/subscriptions/update", s.handleUpdateWebhookSubscription)
+			r.Post("/webhooks/subscriptions/delete", s.handleDeleteWebhookSubscription)
+			r.Get("/webhooks/deliveries/list", s.handleListWebhookDeliveries)
+			r.Post("/webhooks/deliveries/redeliver", s.handleRedeliverWebhook)
+
+			r.Post("/identities/set", s.handleSetExternalIdentity)
+			r.Get("/identities/list", s.handleListExternalIdentities)
+			r.Post("/identities/delete", s.handleDeleteExternalIdentity)
+
+			r.Post("/auth/tokens/create", s.handleIssueAPIToken)
+			r.Get("/auth/tokens/list", s.handleListAPITokens)
+			r.Post("/auth/tokens/revoke", s.handleRevokeAPIToken)
+		})
+	})
 }
 
 type CreateTeamRequest struct {
 	TeamName string                `json:"team_name"`
 	Members  []entities.TeamMember `json:"members"`
+	Settings *TeamSettingsRequest  `json:"settings,omitempty"`
+	// ConflictMode move (по умолчанию), join, reject или dry_run
+	ConflictMode string `json:"conflict_mode,omitempty"`
+}
+
+type TeamSettingsRequest struct {
+	MinReviewers int `json:"min_reviewers"`
+	MaxReviewers int `json:"max_reviewers"`
 }
 
 type TeamResponse struct {
-	TeamName string                `json:"team_name"`
-	Members  []entities.TeamMember `json:"members"`
+	TeamName string                 `json:"team_name"`
+	Members  []entities.TeamMember  `json:"members"`
+	Settings *entities.TeamSettings `json:"settings,omitempty"`
 }
 
 type CreateTeamResponse struct {
-	Team TeamResponse `json:"team"`
+	Team          TeamResponse                  `json:"team"`
+	Conflicts     []entities.TeamMemberConflict `json:"conflicts,omitempty"`
+	ReassignedPRs []entities.PRReassignmentInfo `json:"reassigned_prs,omitempty"`
+	DryRun        bool                          `json:"dry_run,omitempty"`
 }
 
 type GetTeamResponse struct {
@@ -76,7 +198,17 @@ func (s *Server) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	team, err := s.service.CreateTeam(r.Context(), req.TeamName, req.Members)
+	var settings *entities.TeamSettings
+	if req.Settings != nil {
+		settings = &entities.TeamSettings{
+			TeamName:     req.TeamName,
+			MinReviewers: req.Settings.MinReviewers,
+			MaxReviewers: req.Settings.MaxReviewers,
+		}
+	}
+
+	opts := entities.TeamCreateOptions{ConflictMode: entities.TeamConflictMode(req.ConflictMode)}
+	result, err := s.service.CreateTeam(r.Context(), req.TeamName, req.Members, settings, opts)
 	if err != nil {
 		s.handleError(w, err)
 		return
@@ -84,11 +216,19 @@ func (s *Server) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	resp := CreateTeamResponse{
 		Team: TeamResponse{
-			TeamName: team.TeamName,
-			Members:  team.TeamMembers,
+			TeamName: result.Team.TeamName,
+			Members:  result.Team.TeamMembers,
+			Settings: result.Team.Settings,
 		},
+		Conflicts:     result.Conflicts,
+		ReassignedPRs: result.Reassignments,
+		DryRun:        result.DryRun,
 	}
-	s.respondWithJSON(w, http.StatusCreated, resp)
+	code := http.StatusCreated
+	if result.DryRun {
+		code = http.StatusOK
+	}
+	s.respondWithJSON(w, code, resp)
 }
 
 func (s *Server) handleGetTeam(w http.ResponseWriter, r *http.Request) {
@@ -108,27 +248,123 @@ func (s *Server) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 		Team: TeamResponse{
 			TeamName: team.TeamName,
 			Members:  team.TeamMembers,
+			Settings: team.Settings,
 		},
 	}
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
 
+type UpdateTeamSettingsRequest struct {
+	TeamName string `json:"team_name"`
+	// MinReviewers и MaxReviewers границы числа ревьюверов; без поля значение не меняется
+	MinReviewers *int `json:"min_reviewers"`
+	MaxReviewers *int `json:"max_reviewers"`
+	// FallbackTeams заменяет список резервных команд; без поля список не меняется, [] очищает его
+	FallbackTeams []string `json:"fallback_teams"`
+	// OwnerRules заменяет правила владения кодом; без поля правила не меняются, [] очищает их
+	OwnerRules []entities.OwnerRule `json:"owner_rules"`
+	// ReviewSLAMinutes время на вердикт ревьювера, 0 отключает SLA; без поля значение не меняется
+	ReviewSLAMinutes *int `json:"review_sla_minutes"`
+	// SLAEscalation reassign или add_reviewer; без поля значение не меняется
+	SLAEscalation entities.SLAEscalation `json:"sla_escalation"`
+}
+
+type TeamSettingsResponse struct {
+	Settings *entities.TeamSettings `json:"settings"`
+}
+
+func (s *Server) handleGetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing team_name query parameter")
+		return
+	}
+
+	settings, err := s.service.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, TeamSettingsResponse{Settings: settings})
+}
+
+func (s *Server) handleUpdateTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	settings, err := s.service.UpdateTeamSettings(r.Context(), &entities.TeamSettingsUpdate{
+		TeamName:      req.TeamName,
+		MinReviewers:  req.MinReviewers,
+		MaxReviewers:  req.MaxReviewers,
+		FallbackTeams: req.FallbackTeams,
+		OwnerRules:    req.OwnerRules,
+
+		ReviewSLAMinutes: req.ReviewSLAMinutes,
+		SLAEscalation:    req.SLAEscalation,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, TeamSettingsResponse{Settings: settings})
+}
+
 type SetUserActiveRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	TeamName string   `json:"team_name"` // основная команда
+	Teams    []string `json:"teams"`
+	IsActive bool     `json:"is_active"`
+
+	UnavailableUntil *time.Time `json:"unavailable_until,omitempty"`
+	MaxOpenReviews   *int       `json:"max_open_reviews,omitempty"`
+	Skills           []string   `json:"skills"`
+}
+
+func newUserResponse(user *entities.User) UserResponse {
+	resp := UserResponse{
+		UserID:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		Teams:    user.Teams,
+		IsActive: user.IsActive,
+
+		UnavailableUntil: user.UnavailableUntil,
+		MaxOpenReviews:   user.MaxOpenReviews,
+		Skills:           user.Skills,
+	}
+	if resp.Skills == nil {
+		resp.Skills = []string{}
+	}
+	return resp
 }
 
 type SetUserActiveResponse struct {
 	User UserResponse `json:"user"`
 }
 
+// SetUserCapacityRequest null в max_open_reviews снимает ограничение
+type SetUserCapacityRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+// SetUserSkillsRequest skills заменяет навыки целиком, [] очищает их
+type SetUserSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
 func (s *Server) handleSetUserActive(w http.ResponseWriter, r *http.Request) {
 	var req SetUserActiveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -142,20 +378,47 @@ func (s *Server) handleSetUserActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := SetUserActiveResponse{
-		User: UserResponse{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
+	s.respondWithJSON(w, http.StatusOK, SetUserActiveResponse{User: newUserResponse(user)})
+}
+
+func (s *Server) handleSetUserCapacity(w http.ResponseWriter, r *http.Request) {
+	var req SetUserCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
 	}
-	s.respondWithJSON(w, http.StatusOK, resp)
+
+	user, err := s.service.SetUserReviewCapacity(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, SetUserActiveResponse{User: newUserResponse(user)})
+}
+
+func (s *Server) handleSetUserSkills(w http.ResponseWriter, r *http.Request) {
+	var req SetUserSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user, err := s.service.SetUserSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, SetUserActiveResponse{User: newUserResponse(user)})
 }
 
 type UserReviewsResponse struct {
 	UserID       string                      `json:"user_id"`
 	PullRequests []entities.PullRequestShort `json:"pull_requests"`
+
+	OpenReviews    int  `json:"open_reviews"`
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"` // отсутствует, если лимит не задан
 }
 
 func (s *Server) handleGetUserReviews(w http.ResponseWriter, r *http.Request) {
@@ -164,7 +427,15 @@ func (s *Server) handleGetUserReviews(w http.ResponseWriter, r *http.Request) {
 		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing user_id query parameter")
 		return
 	}
+	if !s.actingAs(w, r, userID) {
+		return
+	}
 
+	load, err := s.service.GetReviewerLoad(r.Context(), userID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
 	prs, err := s.service.GetUserReviews(r.Context(), userID)
 	if err != nil {
 		s.handleError(w, err)
@@ -172,8 +443,10 @@ func (s *Server) handleGetUserReviews(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := UserReviewsResponse{
-		UserID:       userID,
-		PullRequests: make([]entities.PullRequestShort, 0, len(prs)),
+		UserID:         userID,
+		PullRequests:   make([]entities.PullRequestShort, 0, len(prs)),
+		OpenReviews:    load.OpenReviews,
+		MaxOpenReviews: load.MaxOpenReviews,
 	}
 	for _, pr := range prs {
 		resp.PullRequests = append(resp.PullRequests, *pr)
@@ -185,14 +458,55 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	Draft           bool   `json:"draft"`
+	TeamName        string `json:"team_name,omitempty"` // команда ревьюверов, по умолчанию основная команда автора
+	// ChangedFiles пути измененных файлов, по ним ревьюверами назначаются владельцы кода
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Labels метки PR, ревьюверы с совпадающими навыками выбираются первыми
+	Labels []string `json:"labels,omitempty"`
 }
 
 type PullRequestResponse struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	TeamName          string     `json:"team_name,omitempty"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+	// ReviewerTeams команда, из которой назначен каждый ревьювер (своя или резервная)
+	ReviewerTeams map[string]string `json:"reviewer_teams,omitempty"`
+	ChangedFiles  []string          `json:"changed_files,omitempty"`
+	// ReviewerRules шаблон правила владения, по которому назначен ревьювер; назначенных случайно здесь нет
+	ReviewerRules map[string]string `json:"reviewer_rules,omitempty"`
+	Labels        []string          `json:"labels,omitempty"`
+}
+
+func newPullRequestResponse(pr *entities.PullRequest) PullRequestResponse {
+	resp := PullRequestResponse{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            string(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		TeamName:          pr.TeamName,
+		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		ReviewerTeams:     pr.ReviewerTeams,
+		ChangedFiles:      pr.ChangedFiles,
+		ReviewerRules:     pr.ReviewerRules,
+		Labels:            pr.Labels,
+	}
+	if resp.AssignedReviewers == nil {
+		resp.AssignedReviewers = []string{}
+	}
+	if !pr.CreatedAt.IsZero() {
+		createdAt := pr.CreatedAt
+		resp.CreatedAt = &createdAt
+	}
+	return resp
 }
 
 type CreatePRResponse struct {
@@ -206,24 +520,137 @@ func (s *Server) handleCreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := s.service.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := s.service.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, entities.PRCreateOptions{
+		Draft:        req.Draft,
+		TeamName:     req.TeamName,
+		ChangedFiles: req.ChangedFiles,
+		Labels:       req.Labels,
+	})
 	if err != nil {
 		s.handleError(w, err)
 		return
 	}
 
 	resp := CreatePRResponse{
-		PR: PullRequestResponse{
-			PullRequestID:     pr.PullRequestID,
-			PullRequestName:   pr.PullRequestName,
-			AuthorID:          pr.AuthorID,
-			Status:            string(pr.Status),
-			AssignedReviewers: pr.AssignedReviewers,
-		},
+		PR: newPullRequestResponse(pr),
 	}
 	s.respondWithJSON(w, http.StatusCreated, resp)
 }
 
+type GetPRResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+func (s *Server) handleGetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing pull_request_id query parameter")
+		return
+	}
+
+	pr, err := s.service.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, GetPRResponse{PR: newPullRequestResponse(pr)})
+}
+
+type PRHistoryResponse struct {
+	PullRequestID string              `json:"pull_request_id"`
+	Events        []*entities.PREvent `json:"events"`
+}
+
+func (s *Server) handleGetPRHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing pull_request_id query parameter")
+		return
+	}
+
+	events, err := s.service.GetPullRequestHistory(r.Context(), prID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, PRHistoryResponse{PullRequestID: prID, Events: events})
+}
+
+type ListPRsResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+func (s *Server) handleListPRs(w http.ResponseWriter, r *http.Request) {
+	filter, err := parsePRListFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	page, err := s.service.ListPullRequests(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	resp := ListPRsResponse{
+		PullRequests: make([]PullRequestResponse, 0, len(page.PullRequests)),
+		NextCursor:   page.NextCursor,
+	}
+	for _, pr := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, newPullRequestResponse(pr))
+	}
+	s.respondWithJSON(w, http.StatusOK, resp)
+}
+
+// parsePRListFilter разбирает query-параметры /pullRequest/list. Даты принимаются в RFC3339
+func parsePRListFilter(query url.Values) (entities.PRListFilter, error) {
+	filter := entities.PRListFilter{
+		Status:     entities.PRStatus(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		TeamName:   query.Get("team_name"),
+		SortBy:     query.Get("sort_by"),
+		Order:      query.Get("order"),
+		Cursor:     query.Get("cursor"),
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("invalid limit '%s'", raw)
+		}
+		filter.Limit = limit
+	}
+
+	dates := []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+		{"merged_from", &filter.MergedFrom},
+		{"merged_to", &filter.MergedTo},
+	}
+	for _, d := range dates {
+		raw := query.Get(d.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s '%s': expected RFC3339", d.name, raw)
+		}
+		t = t.UTC()
+		*d.dst = &t
+	}
+
+	return filter, nil
+}
+
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 }
@@ -246,25 +673,23 @@ func (s *Server) handleMergePR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := MergePRResponse{
-		PR: PullRequestResponse{
-			PullRequestID:     pr.PullRequestID,
-			PullRequestName:   pr.PullRequestName,
-			AuthorID:          pr.AuthorID,
-			Status:            string(pr.Status),
-			AssignedReviewers: pr.AssignedReviewers,
-		},
+		PR: newPullRequestResponse(pr),
 	}
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
 
+// ReassignReviewerRequest без new_user_id замена выбирается стратегией команды
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id,omitempty"`
 }
 
 type ReassignReviewerResponse struct {
 	PR         PullRequestResponse `json:"pr"`
 	ReplacedBy string              `json:"replaced_by"`
+	// ReplacedByTeam команда, из которой выбран новый ревьювер
+	ReplacedByTeam string `json:"replaced_by_team,omitempty"`
 }
 
 func (s *Server) handleReassignReviewer(w http.ResponseWriter, r *http.Request) {
@@ -274,25 +699,135 @@ func (s *Server) handleReassignReviewer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	pr, newReviewerID, err := s.service.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	var pr *entities.PullRequest
+	var err error
+	newReviewerID := req.NewUserID
+	if newReviewerID != "" {
+		pr, err = s.service.ReassignReviewerTo(r.Context(), req.PullRequestID, req.OldUserID, newReviewerID)
+	} else {
+		pr, newReviewerID, err = s.service.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	}
 	if err != nil {
 		s.handleError(w, err)
 		return
 	}
 
 	resp := ReassignReviewerResponse{
-		PR: PullRequestResponse{
-			PullRequestID:     pr.PullRequestID,
-			PullRequestName:   pr.PullRequestName,
-			AuthorID:          pr.AuthorID,
-			Status:            string(pr.Status),
-			AssignedReviewers: pr.AssignedReviewers,
-		},
-		ReplacedBy: newReviewerID,
+		PR:             newPullRequestResponse(pr),
+		ReplacedBy:     newReviewerID,
+		ReplacedByTeam: pr.ReviewerTeams[newReviewerID],
 	}
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
 
+type ReviewerChangeRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+func (s *Server) handleAddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ReviewerChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := s.service.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, PRStatusResponse{PR: newPullRequestResponse(pr)})
+}
+
+func (s *Server) handleRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ReviewerChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := s.service.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, PRStatusResponse{PR: newPullRequestResponse(pr)})
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Verdict       string `json:"verdict"`
+	Comment       string `json:"comment"`
+}
+
+type SubmitReviewResponse struct {
+	Review *entities.Review `json:"review"`
+}
+
+func (s *Server) handleSubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if !s.actingAs(w, r, req.ReviewerID) {
+		return
+	}
+
+	review, err := s.service.SubmitReview(r.Context(), req.PullRequestID, req.ReviewerID, entities.ReviewVerdict(req.Verdict), req.Comment)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusCreated, SubmitReviewResponse{Review: review})
+}
+
+type PRStatusRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type PRStatusResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+func (s *Server) handleClosePR(w http.ResponseWriter, r *http.Request) {
+	s.handlePRTransition(w, r, s.service.ClosePullRequest)
+}
+
+func (s *Server) handleReopenPR(w http.ResponseWriter, r *http.Request) {
+	s.handlePRTransition(w, r, s.service.ReopenPullRequest)
+}
+
+func (s *Server) handleMarkPRReady(w http.ResponseWriter, r *http.Request) {
+	s.handlePRTransition(w, r, s.service.MarkPullRequestReady)
+}
+
+// handlePRTransition общий обработчик эндпоинтов смены статуса PR
+func (s *Server) handlePRTransition(
+	w http.ResponseWriter,
+	r *http.Request,
+	transition func(ctx context.Context, prID string) (*entities.PullRequest, error),
+) {
+	var req PRStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := transition(r.Context(), req.PullRequestID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, PRStatusResponse{PR: newPullRequestResponse(pr)})
+}
+
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
@@ -300,6 +835,8 @@ type ErrorResponse struct {
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// TraceID идентификатор трассировки запроса, если трассировка включена
+	TraceID string `json:"trace_id,omitempty"`
 }
 
 func (s *Server) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -321,6 +858,8 @@ func (s *Server) respondWithError(w http.ResponseWriter, code int, errCode strin
 		Error: ErrorDetail{
 			Code:    errCode,
 			Message: message,
+			// заголовок выставляет traceRequests до вызова обработчика
+			TraceID: w.Header().Get(TraceIDHeader),
 		},
 	}
 	s.respondWithJSON(w, code, resp)
@@ -386,7 +925,8 @@ func (s *Server) handleDeactivateMembers(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleError(w http.ResponseWriter, err error) {
-	if appErr, ok := err.(*entities.AppError); ok {
+	var appErr *entities.AppError
+	if errors.As(err, &appErr) {
 		statusCode := s.getHTTPStatusForError(appErr)
 		s.respondWithError(w, statusCode, string(appErr.Code), appErr.Message)
 		return
@@ -414,23 +954,44 @@ func (s *Server) handleError(w http.ResponseWriter, err error) {
 		s.respondWithError(w, http.StatusConflict, "INVALID_TEAM_USER", msg)
 		return
 	default:
+		s.logUnexpectedError(w, err)
 		s.respondWithError(w, http.StatusBadRequest, "INTERNAL_ERROR", msg)
 		return
 	}
 }
 
+// logUnexpectedError пишет ошибку, не являющуюся AppError. Контекста запроса здесь нет, поэтому идентификаторы
+// берутся из заголовков ответа, выставленных logRequests и traceRequests
+func (s *Server) logUnexpectedError(w http.ResponseWriter, err error) {
+	attrs := []any{"request_id", w.Header().Get(RequestIDHeader), "error", err}
+	if traceID := w.Header().Get(TraceIDHeader); traceID != "" {
+		attrs = append(attrs, "trace_id", traceID)
+	}
+	s.logger.Error("request failed", attrs...)
+}
+
 func (s *Server) getHTTPStatusForError(appErr *entities.AppError) int {
 	switch appErr.Code {
 	case entities.ErrCodeTeamExists:
 		return http.StatusBadRequest
 	case entities.ErrCodePRExists:
 		return http.StatusConflict
-	case entities.ErrCodePRMerged, entities.ErrCodeNotAssigned, entities.ErrCodeNoCandidate:
+	case entities.ErrCodePRMerged, entities.ErrCodeNotAssigned, entities.ErrCodeNoCandidate, entities.ErrCodeNoCapacity:
+		return http.StatusConflict
+	case entities.ErrCodeAlreadyAssigned, entities.ErrCodeInvalidReviewer:
+		return http.StatusConflict
+	case entities.ErrCodeNotEnoughReviewers, entities.ErrCodeInvalidTransition, entities.ErrCodePRNotOpen:
+		return http.StatusConflict
+	case entities.ErrCodeMergeBlocked, entities.ErrCodeTeamNotEmpty, entities.ErrCodeTeamMemberConflict:
 		return http.StatusConflict
 	case entities.ErrCodeInvalidTeamUser:
 		return http.StatusConflict
 	case entities.ErrCodeNotFound:
 		return http.StatusNotFound
+	case entities.ErrCodeUnauthorized:
+		return http.StatusUnauthorized
+	case entities.ErrCodeForbidden:
+		return http.StatusForbidden
 	default:
 		// Дефолт больше не 500 — приводим к 400 по OpenAPI ожиданиям.
 		return http.StatusBadRequest
@@ -0,0 +1,120 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+type TeamMembersRequest struct {
+	TeamName string                `json:"team_name"`
+	Members  []entities.TeamMember `json:"members"`
+}
+
+type RemoveTeamMembersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type MoveTeamMemberRequest struct {
+	UserID   string `json:"user_id"`
+	FromTeam string `json:"from_team,omitempty"` // по умолчанию основная команда пользователя
+	TeamName string `json:"team_name"`
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+type DeleteTeamRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+func newTeamResponse(team *entities.Team) GetTeamResponse {
+	members := team.TeamMembers
+	if members == nil {
+		members = []entities.TeamMember{}
+	}
+	return GetTeamResponse{Team: TeamResponse{TeamName: team.TeamName, Members: members, Settings: team.Settings}}
+}
+
+func (s *Server) handleAddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req TeamMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	team, err := s.service.AddTeamMembers(r.Context(), req.TeamName, req.Members)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, newTeamResponse(team))
+}
+
+func (s *Server) handleRemoveTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req RemoveTeamMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	result, err := s.service.RemoveTeamMembers(r.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleMoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req MoveTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	result, err := s.service.MoveTeamMember(r.Context(), req.UserID, req.FromTeam, req.TeamName)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleRenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req RenameTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	team, err := s.service.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, newTeamResponse(team))
+}
+
+func (s *Server) handleDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req DeleteTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := s.service.DeleteTeam(r.Context(), req.TeamName); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, req)
+}
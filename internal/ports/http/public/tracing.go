@@ -0,0 +1,54 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+
+// TraceIDHeader заголовок ответа с идентификатором трассировки запроса
+const TraceIDHeader = "X-Trace-ID"
+
+// WithTracing включает спаны на каждый запрос. Входящий заголовок traceparent продолжает трассировку вызывающей
+// стороны, идентификатор трассировки возвращается в заголовке X-Trace-ID и в теле ошибок
+func WithTracing(provider trace.TracerProvider) ServerOption {
+	return func(s *Server) {
+		s.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// traceRequests открывает спан до маршрутизации и после обработки называет его по шаблону маршрута chi
+func (s *Server) traceRequests(next http.Handler) http.Handler {
+	propagator := propagation.TraceContext{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := s.tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)),
+		)
+		defer span.End()
+		if traceID := span.SpanContext().TraceID(); traceID.IsValid() {
+			w.Header().Set(TraceIDHeader, traceID.String())
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		route := routePattern(r)
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
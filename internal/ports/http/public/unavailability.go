@@ -0,0 +1,92 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+type CreateUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type UnavailabilityResponse struct {
+	Period *entities.Unavailability `json:"period"`
+}
+
+type UnavailabilityListResponse struct {
+	Periods []*entities.Unavailability `json:"periods"`
+}
+
+type UnavailabilityIDRequest struct {
+	PeriodID int64 `json:"period_id"`
+}
+
+func (s *Server) handleCreateUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req CreateUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if !s.actingAs(w, r, req.UserID) {
+		return
+	}
+
+	period, err := s.service.CreateUnavailability(r.Context(), &entities.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusCreated, UnavailabilityResponse{Period: period})
+}
+
+// handleListUnavailability без user_id возвращает периоды всех пользователей, это доступно только администратору
+func (s *Server) handleListUnavailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if !s.actingAs(w, r, userID) {
+		return
+	}
+
+	periods, err := s.service.ListUnavailability(r.Context(), userID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, UnavailabilityListResponse{Periods: periods})
+}
+
+func (s *Server) handleDeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req UnavailabilityIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	period, err := s.service.GetUnavailability(r.Context(), req.PeriodID)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+	if !s.actingAs(w, r, period.UserID) {
+		return
+	}
+
+	if err := s.service.DeleteUnavailability(r.Context(), req.PeriodID); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, req)
+}
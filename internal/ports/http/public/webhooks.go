@@ -0,0 +1,156 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+type WebhookSubscriptionRequest struct {
+	SubscriptionID int64                  `json:"subscription_id"`
+	URL            string                 `json:"url"`
+	Secret         string                 `json:"secret"`
+	EventTypes     []entities.PREventType `json:"event_types"`
+	IsActive       *bool                  `json:"is_active"`
+}
+
+func (req WebhookSubscriptionRequest) toEntity() *entities.WebhookSubscription {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	return &entities.WebhookSubscription{
+		SubscriptionID: req.SubscriptionID,
+		URL:            req.URL,
+		Secret:         req.Secret,
+		EventTypes:     req.EventTypes,
+		IsActive:       isActive,
+	}
+}
+
+type WebhookSubscriptionResponse struct {
+	Subscription *entities.WebhookSubscription `json:"subscription"`
+}
+
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []*entities.WebhookSubscription `json:"subscriptions"`
+}
+
+type WebhookSubscriptionIDRequest struct {
+	SubscriptionID int64 `json:"subscription_id"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []*entities.WebhookDelivery `json:"deliveries"`
+}
+
+type WebhookDeliveryIDRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (s *Server) handleCreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var req WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	sub, err := s.service.CreateWebhookSubscription(r.Context(), req.toEntity())
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusCreated, WebhookSubscriptionResponse{Subscription: sub})
+}
+
+func (s *Server) handleGetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("subscription_id"), 10, 64)
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing or invalid subscription_id query parameter")
+		return
+	}
+
+	sub, err := s.service.GetWebhookSubscription(r.Context(), id)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, WebhookSubscriptionResponse{Subscription: sub})
+}
+
+func (s *Server) handleListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := s.service.ListWebhookSubscriptions(r.Context())
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, WebhookSubscriptionsResponse{Subscriptions: subs})
+}
+
+func (s *Server) handleUpdateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var req WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	sub, err := s.service.UpdateWebhookSubscription(r.Context(), req.toEntity())
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, WebhookSubscriptionResponse{Subscription: sub})
+}
+
+func (s *Server) handleDeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var req WebhookSubscriptionIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := s.service.DeleteWebhookSubscription(r.Context(), req.SubscriptionID); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, req)
+}
+
+func (s *Server) handleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("subscription_id"), 10, 64)
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing or invalid subscription_id query parameter")
+		return
+	}
+	status := entities.DeliveryStatus(r.URL.Query().Get("status"))
+
+	deliveries, err := s.service.ListWebhookDeliveries(r.Context(), id, status)
+	if err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, WebhookDeliveriesResponse{Deliveries: deliveries})
+}
+
+func (s *Server) handleRedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookDeliveryIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := s.service.RedeliverWebhook(r.Context(), req.DeliveryID); err != nil {
+		s.handleError(w, err)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusAccepted, req)
+}
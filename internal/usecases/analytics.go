@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// defaultAnalyticsPeriod период аналитики, если начало не задано
+const defaultAnalyticsPeriod = 30 * 24 * time.Hour
+
+// GetPRThroughput возвращает число созданных и смерженных PR по командам и интервалам
+func (s *ServiceStorage) GetPRThroughput(ctx context.Context, filter en.AnalyticsFilter) ([]en.ThroughputPoint, error) {
+	if err := s.normalizeAnalyticsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	points, err := s.storage.GetPRThroughput(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get PR throughput")
+	}
+	return points, nil
+}
+
+// GetReviewTimings возвращает медиану и p90 времени до мержа и до первого вердикта
+func (s *ServiceStorage) GetReviewTimings(ctx context.Context, filter en.AnalyticsFilter) (*en.ReviewTimings, error) {
+	if err := s.normalizeAnalyticsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	timings, err := s.storage.GetReviewTimings(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get review timings")
+	}
+	return timings, nil
+}
+
+// GetReassignmentStats возвращает долю замен ревьюверов по командам
+func (s *ServiceStorage) GetReassignmentStats(ctx context.Context, filter en.AnalyticsFilter) ([]en.TeamReassignments, error) {
+	if err := s.normalizeAnalyticsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	stats, err := s.storage.GetReassignmentStats(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get reassignment stats")
+	}
+	return stats, nil
+}
+
+// GetWorkloadTrend возвращает назначенные ревью и оставленные вердикты пользователей по интервалам
+func (s *ServiceStorage) GetWorkloadTrend(ctx context.Context, filter en.AnalyticsFilter) ([]en.WorkloadPoint, error) {
+	if err := s.normalizeAnalyticsFilter(ctx, &filter); err != nil {
+		return nil, err
+	}
+	if filter.UserID != "" {
+		user, err := s.storage.GetUser(ctx, filter.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get user")
+		}
+		if user == nil {
+			return nil, en.NewNotFoundError("user", filter.UserID)
+		}
+	}
+	points, err := s.storage.GetWorkloadTrend(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workload trend")
+	}
+	return points, nil
+}
+
+// normalizeAnalyticsFilter подставляет значения по умолчанию: интервал - день, конец периода - текущий момент,
+// начало - за 30 дней до конца. Время переводится в UTC, в котором хранятся метки времени
+func (s *ServiceStorage) normalizeAnalyticsFilter(ctx context.Context, filter *en.AnalyticsFilter) error {
+	switch filter.Bucket {
+	case "":
+		filter.Bucket = en.BucketDay
+	case en.BucketDay, en.BucketWeek:
+	default:
+		return errors.Errorf("invalid bucket '%s': expected day or week", filter.Bucket)
+	}
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultAnalyticsPeriod)
+	}
+	filter.From, filter.To = filter.From.UTC(), filter.To.UTC()
+	if !filter.From.Before(filter.To) {
+		return errors.New("from must be before to")
+	}
+
+	if filter.TeamName != "" {
+		exists, err := s.storage.TeamExists(ctx, filter.TeamName)
+		if err != nil {
+			return errors.Wrap(err, "failed to check team")
+		}
+		if !exists {
+			return en.NewNotFoundError("team", filter.TeamName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// apiTokenPrefix отличает персональные токены от JWT и упрощает поиск утекших токенов в логах
+const apiTokenPrefix = "prs_"
+
+// IssueAPIToken выпускает персональный токен пользователя. Открытое значение возвращается только здесь
+func (s *ServiceStorage) IssueAPIToken(ctx context.Context, userID string, role en.Role) (*en.APIToken, error) {
+	if userID == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	if role == "" {
+		role = en.RoleUser
+	}
+	if err := validateRole(role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to generate token")
+	}
+	plain := apiTokenPrefix + hex.EncodeToString(raw)
+
+	token, err := s.storage.CreateAPIToken(ctx, &en.APIToken{UserID: userID, Role: role}, hashAPIToken(plain))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create api token")
+	}
+	token.Token = plain
+	return token, nil
+}
+
+// AuthenticateAPIToken возвращает владельца персонального токена. Токены отозванные
+// и принадлежащие неактивным пользователям не принимаются
+func (s *ServiceStorage) AuthenticateAPIToken(ctx context.Context, plain string) (*en.Principal, error) {
+	token, err := s.storage.GetAPITokenByHash(ctx, hashAPIToken(plain))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api token")
+	}
+	if token == nil || token.RevokedAt != nil {
+		return nil, en.NewUnauthorizedError("invalid token")
+	}
+
+	user, err := s.storage.GetUser(ctx, token.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil || !user.IsActive {
+		return nil, en.NewUnauthorizedError("token owner is inactive")
+	}
+
+	return &en.Principal{UserID: token.UserID, Role: token.Role}, nil
+}
+
+// ListAPITokens возвращает токены пользователя без открытых значений, userID может быть пустым
+func (s *ServiceStorage) ListAPITokens(ctx context.Context, userID string) ([]*en.APIToken, error) {
+	tokens, err := s.storage.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api tokens")
+	}
+	if tokens == nil {
+		tokens = []*en.APIToken{}
+	}
+	return tokens, nil
+}
+
+func (s *ServiceStorage) RevokeAPIToken(ctx context.Context, tokenID int64) error {
+	revoked, err := s.storage.RevokeAPIToken(ctx, tokenID, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke api token")
+	}
+	if !revoked {
+		return en.NewNotFoundError("api token", formatID(tokenID))
+	}
+	return nil
+}
+
+func hashAPIToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func validateRole(role en.Role) error {
+	switch role {
+	case en.RoleAdmin, en.RoleUser:
+		return nil
+	default:
+		return errors.Errorf("unknown role '%s'", role)
+	}
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// AvailabilitySchedulerConfig параметры обработки периодов недоступности. Нулевые значения
+// заменяются значениями по умолчанию
+type AvailabilitySchedulerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Metrics учитывает переназначения, nil - метрики не собираются
+	Metrics Metrics
+}
+
+func (c AvailabilitySchedulerConfig) withDefaults() AvailabilitySchedulerConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Minute
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	return c
+}
+
+// AvailabilityScheduler переназначает открытые ревью пользователей, у которых начался период недоступности
+type AvailabilityScheduler struct {
+	storage   Storage
+	selectors *ReviewerSelectors
+	cfg       AvailabilitySchedulerConfig
+	now       func() time.Time
+}
+
+// NewAvailabilityScheduler создает планировщик. selectors может быть nil, тогда замена выбирается случайно
+func NewAvailabilityScheduler(storage Storage, selectors *ReviewerSelectors, cfg AvailabilitySchedulerConfig) (*AvailabilityScheduler, error) {
+	if storage == nil {
+		return nil, errors.Wrap(en.ErrNilDependency, "availability scheduler")
+	}
+	return &AvailabilityScheduler{storage: storage, selectors: selectors, cfg: cfg.withDefaults(), now: time.Now}, nil
+}
+
+// Run обрабатывает начавшиеся периоды до отмены контекста
+func (a *AvailabilityScheduler) Run(ctx context.Context) {
+	runPeriodically(ctx, "availability_scheduler", a.cfg.PollInterval, func(ctx context.Context) error {
+		_, err := a.RunOnce(ctx)
+		return err
+	})
+}
+
+// RunOnce обрабатывает одну пачку начавшихся периодов и возвращает список переназначений
+func (a *AvailabilityScheduler) RunOnce(ctx context.Context) ([]en.PRReassignmentInfo, error) {
+	reassigned, err := a.storage.StartUnavailabilityPeriods(ctx, a.now().UTC(), a.cfg.BatchSize, loggedPicker(ctx, a.selectors))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start unavailability periods")
+	}
+	observeReassignments(a.cfg.Metrics, en.ReasonReviewerUnavailable, reassigned)
+	return reassigned, nil
+}
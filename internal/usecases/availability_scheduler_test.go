@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityScheduler_RunOnce(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	selectors, err := NewReviewerSelectors(StrategyRandom, map[string]string{"backend": StrategyLeastLoaded}, nil)
+	require.NoError(t, err)
+	scheduler, err := NewAvailabilityScheduler(mockStorage, selectors, AvailabilitySchedulerConfig{BatchSize: 10})
+	require.NoError(t, err)
+	local := time.Date(2025, 11, 16, 13, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	scheduler.now = func() time.Time { return local }
+
+	ctx := context.Background()
+	reassigned := []en.PRReassignmentInfo{{PullRequestID: "pr-1", OldReviewer: "u2", NewReviewer: "u4"}}
+	// хранилище получает время в UTC: колонки периодов хранят UTC
+	mockStorage.EXPECT().StartUnavailabilityPeriods(ctx, time.Date(2025, 11, 16, 10, 0, 0, 0, time.UTC), 10, mock.Anything).
+		RunAndReturn(func(_ context.Context, now time.Time, _ int, picker func(string) en.ReviewerPicker) ([]en.PRReassignmentInfo, error) {
+			assert.Equal(t, time.UTC, now.Location())
+			// замена выбирается стратегией команды PR
+			candidates := []*en.User{{UserID: "u3"}, {UserID: "u4"}}
+			assert.Equal(t, []string{"u4"}, picker("backend")(candidates, map[string]int{"u3": 5, "u4": 1}, 1))
+			return reassigned, nil
+		}).Once()
+
+	result, err := scheduler.RunOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, reassigned, result)
+}
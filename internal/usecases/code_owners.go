@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// validateOwnerRules проверяет шаблоны правил владения и существование владельцев: пользователей и команд
+func (s *ServiceStorage) validateOwnerRules(ctx context.Context, settings *en.TeamSettingsUpdate) error {
+	for _, rule := range settings.OwnerRules {
+		if err := validateOwnerPattern(rule.Pattern); err != nil {
+			return err
+		}
+		if len(rule.Owners) == 0 {
+			return errors.Errorf("owner rule '%s' has no owners", rule.Pattern)
+		}
+		for _, owner := range rule.Owners {
+			if err := s.validateOwner(ctx, owner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateOwner проверяет, что владелец - существующий пользователь или существующая команда вида @team
+func (s *ServiceStorage) validateOwner(ctx context.Context, owner string) error {
+	if teamName, ok := strings.CutPrefix(owner, en.OwnerTeamPrefix); ok {
+		if teamName == "" {
+			return errors.New("owner team name cannot be empty")
+		}
+		exists, err := s.storage.TeamExists(ctx, teamName)
+		if err != nil {
+			return errors.Wrap(err, "failed to check owner team existence")
+		}
+		if !exists {
+			return en.NewNotFoundError("team", teamName)
+		}
+		return nil
+	}
+
+	if owner == "" {
+		return errors.New("owner cannot be empty")
+	}
+	user, err := s.storage.GetUser(ctx, owner)
+	if err != nil {
+		return errors.Wrap(err, "failed to get owner")
+	}
+	if user == nil {
+		return en.NewNotFoundError("user", owner)
+	}
+	return nil
+}
+
+// validateOwnerPattern проверяет синтаксис шаблона пути
+func validateOwnerPattern(pattern string) error {
+	trimmed := strings.Trim(strings.TrimSpace(pattern), "/")
+	if trimmed == "" {
+		return errors.New("owner rule pattern cannot be empty")
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.Errorf("invalid owner rule pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// matchOwnerPattern проверяет, подходит ли путь под шаблон в стиле CODEOWNERS.
+// Шаблон без "/" внутри ищется на любой глубине, "/" в начале или в середине привязывает его к корню,
+// "/" в конце означает каталог, "**" - любое количество сегментов. Шаблон каталога подходит всем файлам внутри него
+func matchOwnerPattern(pattern, filePath string) bool {
+	pattern = strings.TrimSpace(pattern)
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return false
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+
+	patternSegments := strings.Split(trimmed, "/")
+	if !anchored {
+		patternSegments = append([]string{"**"}, patternSegments...)
+	}
+
+	pathSegments := strings.Split(strings.Trim(filePath, "/"), "/")
+	last := len(pathSegments)
+	if dirOnly {
+		// сам файл каталогом не является, проверяются только родительские каталоги
+		last--
+	}
+	for n := last; n > 0; n-- {
+		if matchSegments(patternSegments, pathSegments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segments[0])
+	return err == nil && ok && matchSegments(pattern[1:], segments[1:])
+}
+
+// matchOwnerRules возвращает правила, которые владеют измененными файлами. Для каждого файла, как в CODEOWNERS,
+// действует последнее подходящее правило. Правила возвращаются без повторов в порядке первых файлов
+func matchOwnerRules(rules []en.OwnerRule, files []string) []en.OwnerRule {
+	var matched []en.OwnerRule
+	seen := make(map[int]bool)
+	for _, file := range files {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !matchOwnerPattern(rules[i].Pattern, file) {
+				continue
+			}
+			if !seen[i] {
+				seen[i] = true
+				matched = append(matched, rules[i])
+			}
+			break
+		}
+	}
+	return matched
+}
+
+// ownerCandidate владелец, которого можно назначить ревьювером, и команда, от имени которой он назначается
+type ownerCandidate struct {
+	user *en.User
+	team string
+}
+
+// selectOwners назначает ревьюверов из владельцев измененных файлов команды teamName, дополняя selection.
+// Сначала каждое сработавшее правило получает по одному ревьюверу, затем оставшиеся места до max_reviewers
+// заполняются остальными владельцами. Возвращает, сколько владельцев пропущено из-за лимита нагрузки
+func (s *ServiceStorage) selectOwners(ctx context.Context, selection *reviewerSelection, settings *en.TeamSettings,
+	pr *en.PullRequest, teamName string,
+) (int, error) {
+	rules := matchOwnerRules(settings.OwnerRules, pr.ChangedFiles)
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	atCapacity := 0
+	candidates := make([][]ownerCandidate, len(rules))
+	for i, rule := range rules {
+		ruleCandidates, full, err := s.ownerCandidates(ctx, teamName, rule, []string{pr.AuthorID})
+		if err != nil {
+			return 0, err
+		}
+		candidates[i] = ruleCandidates
+		atCapacity += full
+	}
+
+	for _, perRule := range []int{1, settings.MaxReviewers} {
+		for i, rule := range rules {
+			need := min(perRule, settings.MaxReviewers-len(selection.ids))
+			if need <= 0 {
+				return atCapacity, nil
+			}
+
+			var users []*en.User
+			teams := make(map[string]string)
+			for _, c := range candidates[i] {
+				if !contains(selection.ids, c.user.UserID) {
+					users = append(users, c.user)
+					teams[c.user.UserID] = c.team
+				}
+			}
+			picked, expert, err := s.pickBySkills(ctx, teamName, users, pr.Labels, need)
+			if err != nil {
+				return 0, err
+			}
+			for _, id := range picked {
+				selection.add(id, teams[id], rule.Pattern)
+			}
+			selection.expert = selection.expert || expert
+		}
+	}
+	return atCapacity, nil
+}
+
+// ownerCandidates раскрывает владельцев правила в доступных пользователей. Команда @team дает своих активных
+// участников; пользователь назначается от команды PR, если состоит в ней, иначе от своей основной команды
+func (s *ServiceStorage) ownerCandidates(ctx context.Context, teamName string, rule en.OwnerRule, exclude []string) ([]ownerCandidate, int, error) {
+	var users []*en.User
+	teams := make(map[string]string)
+	for _, owner := range rule.Owners {
+		if ownerTeam, ok := strings.CutPrefix(owner, en.OwnerTeamPrefix); ok {
+			members, err := s.storage.GetUsersByTeam(ctx, ownerTeam, true)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "failed to get owner team members")
+			}
+			for _, member := range members {
+				if _, dup := teams[member.UserID]; !dup {
+					users = append(users, member)
+					teams[member.UserID] = ownerTeam
+				}
+			}
+			continue
+		}
+
+		if _, dup := teams[owner]; dup {
+			continue
+		}
+		user, err := s.storage.GetUser(ctx, owner)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get owner")
+		}
+		// владелец мог быть удален или деактивирован после сохранения правила
+		if user == nil || !user.IsActive {
+			continue
+		}
+		users = append(users, user)
+		teams[user.UserID] = user.TeamName
+		if user.InTeam(teamName) {
+			teams[user.UserID] = teamName
+		}
+	}
+
+	available, full, err := s.availableCandidates(ctx, users, exclude)
+	if err != nil {
+		return nil, 0, err
+	}
+	candidates := make([]ownerCandidate, len(available))
+	for i, user := range available {
+		candidates[i] = ownerCandidate{user: user, team: teams[user.UserID]}
+	}
+	return candidates, full, nil
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// Notifier доставляет дайджест открытых ревью пользователю
+type Notifier interface {
+	Notify(ctx context.Context, digest *en.ReviewDigest) error
+}
+
+// DigestSchedulerConfig параметры рассылки дайджестов. Нулевые значения заменяются значениями по умолчанию
+type DigestSchedulerConfig struct {
+	PollInterval time.Duration
+	// SendTime "HH:MM" местного времени отправки для пользователей, не задавших свое digest_time
+	SendTime string
+}
+
+func (c DigestSchedulerConfig) withDefaults() DigestSchedulerConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = 15 * time.Minute
+	}
+	if c.SendTime == "" {
+		c.SendTime = en.DefaultDigestTime
+	}
+	return c
+}
+
+// DigestScheduler раз в местные сутки пользователя отправляет ему дайджест открытых ревью после наступления
+// времени отправки. Отказавшихся от рассылки и тех, у кого тихие часы, отсеивает хранилище
+type DigestScheduler struct {
+	storage  Storage
+	notifier Notifier
+	cfg      DigestSchedulerConfig
+	now      func() time.Time
+}
+
+func NewDigestScheduler(storage Storage, notifier Notifier, cfg DigestSchedulerConfig) (*DigestScheduler, error) {
+	if storage == nil || notifier == nil {
+		return nil, errors.Wrap(en.ErrNilDependency, "digest scheduler")
+	}
+	cfg = cfg.withDefaults()
+	if _, err := en.ParseTimeOfDay(cfg.SendTime); err != nil {
+		return nil, errors.Wrap(err, "digest send time")
+	}
+	return &DigestScheduler{storage: storage, notifier: notifier, cfg: cfg, now: time.Now}, nil
+}
+
+// Run рассылает дайджесты до отмены контекста
+func (d *DigestScheduler) Run(ctx context.Context) {
+	runPeriodically(ctx, "digest_scheduler", d.cfg.PollInterval, func(ctx context.Context) error {
+		sent, err := d.RunOnce(ctx)
+		for _, digest := range sent {
+			en.LoggerFromContext(ctx).InfoContext(ctx, "digest sent", "user_id", digest.UserID, "reviews", len(digest.Reviews))
+		}
+		return err
+	})
+}
+
+// RunOnce отправляет дайджесты всем, кому они положены сейчас, и возвращает отправленные.
+// Перед отправкой дайджест забирается в хранилище, поэтому несколько экземпляров сервиса не дублируют
+// рассылку; при ошибке доставки отметка снимается и пользователь получит дайджест на следующем проходе
+func (d *DigestScheduler) RunOnce(ctx context.Context) ([]*en.ReviewDigest, error) {
+	// время округляется до точности хранилища, чтобы снять отметку по точному совпадению
+	now := d.now().UTC().Truncate(time.Microsecond)
+	digests, err := d.storage.ListDueReviewDigests(ctx, now, d.cfg.SendTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list due review digests")
+	}
+
+	sent := []*en.ReviewDigest{}
+	var failed []string
+	for _, digest := range digests {
+		var prev *time.Time
+		if digest.Settings != nil {
+			prev = digest.Settings.LastDigestAt
+		}
+		claimed, err := d.storage.SetDigestSentAt(ctx, digest.UserID, prev, &now)
+		if err != nil {
+			return sent, errors.Wrap(err, "failed to claim digest")
+		}
+		if !claimed {
+			continue
+		}
+
+		withReviewAges(digest, now)
+		if err := d.notifier.Notify(ctx, digest); err != nil {
+			failed = append(failed, digest.UserID)
+			en.LoggerFromContext(ctx).ErrorContext(ctx, "digest notify failed", "user_id", digest.UserID, "error", err)
+			if _, err := d.storage.SetDigestSentAt(ctx, digest.UserID, &now, prev); err != nil {
+				return sent, errors.Wrap(err, "failed to release digest")
+			}
+			continue
+		}
+		sent = append(sent, digest)
+	}
+	if len(failed) > 0 {
+		return sent, errors.Errorf("failed to deliver digests to %v", failed)
+	}
+	return sent, nil
+}
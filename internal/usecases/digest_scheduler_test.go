@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	delivered []*en.ReviewDigest
+	err       error
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, digest *en.ReviewDigest) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.delivered = append(f.delivered, digest)
+	return nil
+}
+
+func newTestDigestScheduler(t *testing.T, now time.Time, notifier Notifier) (*DigestScheduler, *MockStorage) {
+	mockStorage := NewMockStorage(t)
+	scheduler, err := NewDigestScheduler(mockStorage, notifier, DigestSchedulerConfig{SendTime: "08:30"})
+	require.NoError(t, err)
+	scheduler.now = func() time.Time { return now }
+	return scheduler, mockStorage
+}
+
+func pendingDigest(userID string, lastDigestAt *time.Time, assignedAt time.Time) *en.ReviewDigest {
+	settings := en.NewDefaultNotificationSettings(userID)
+	settings.LastDigestAt = lastDigestAt
+	return &en.ReviewDigest{
+		UserID:   userID,
+		Username: userID,
+		Reviews:  []en.DigestReview{{PullRequestID: "pr-" + userID, AuthorID: "author", AssignedAt: assignedAt}},
+		Settings: settings,
+	}
+}
+
+func TestDigestScheduler_RunOnce(t *testing.T) {
+	now := time.Date(2025, 11, 16, 10, 0, 0, 0, time.UTC)
+	notifier := &fakeNotifier{}
+	scheduler, mockStorage := newTestDigestScheduler(t, now, notifier)
+
+	ctx := context.Background()
+	yesterday := now.Add(-24 * time.Hour)
+	digests := []*en.ReviewDigest{
+		pendingDigest("u1", nil, now.Add(-time.Hour)),
+		pendingDigest("u2", &yesterday, now.Add(-time.Hour)),
+	}
+	// отбор по времени отправки, тихим часам и отказу от рассылки делает хранилище
+	mockStorage.EXPECT().ListDueReviewDigests(ctx, now, "08:30").Return(digests, nil).Once()
+	mockStorage.EXPECT().SetDigestSentAt(ctx, "u1", (*time.Time)(nil), &now).Return(true, nil).Once()
+	mockStorage.EXPECT().SetDigestSentAt(ctx, "u2", &yesterday, &now).Return(true, nil).Once()
+
+	sent, err := scheduler.RunOnce(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	assert.Equal(t, sent, notifier.delivered)
+	assert.Equal(t, now, sent[0].GeneratedAt)
+	assert.Equal(t, int64(3600), sent[0].Reviews[0].AgeSeconds)
+}
+
+func TestDigestScheduler_ClaimedByAnotherInstance(t *testing.T) {
+	now := time.Date(2025, 11, 16, 10, 0, 0, 0, time.UTC)
+	notifier := &fakeNotifier{}
+	scheduler, mockStorage := newTestDigestScheduler(t, now, notifier)
+
+	ctx := context.Background()
+	mockStorage.EXPECT().ListDueReviewDigests(ctx, now, "08:30").
+		Return([]*en.ReviewDigest{pendingDigest("u1", nil, now.Add(-time.Hour))}, nil).Once()
+	mockStorage.EXPECT().SetDigestSentAt(ctx, "u1", (*time.Time)(nil), &now).Return(false, nil).Once()
+
+	sent, err := scheduler.RunOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Empty(t, sent)
+	assert.Empty(t, notifier.delivered)
+}
+
+func TestDigestScheduler_NotifyFailureReleasesClaim(t *testing.T) {
+	now := time.Date(2025, 11, 16, 10, 0, 0, 0, time.UTC)
+	scheduler, mockStorage := newTestDigestScheduler(t, now, &fakeNotifier{err: errors.New("smtp: connection refused")})
+
+	ctx := context.Background()
+	yesterday := now.Add(-24 * time.Hour)
+	mockStorage.EXPECT().ListDueReviewDigests(ctx, now, "08:30").
+		Return([]*en.ReviewDigest{pendingDigest("u1", &yesterday, now.Add(-time.Hour))}, nil).Once()
+	mockStorage.EXPECT().SetDigestSentAt(ctx, "u1", &yesterday, &now).Return(true, nil).Once()
+	mockStorage.EXPECT().SetDigestSentAt(ctx, "u1", &now, &yesterday).Return(true, nil).Once()
+
+	sent, err := scheduler.RunOnce(ctx)
+
+	require.Error(t, err)
+	assert.Empty(t, sent)
+}
+
+func TestNewDigestScheduler_InvalidSendTime(t *testing.T) {
+	_, err := NewDigestScheduler(NewMockStorage(t), &fakeNotifier{}, DigestSchedulerConfig{SendTime: "9am"})
+
+	require.Error(t, err)
+}
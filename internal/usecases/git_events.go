@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// handleGitEvent применяет событие git-хостинга к PR: opened создает PR, closed/merged закрывает или мержит,
+// reopened и ready_for_review переводят в OPEN. Повторная доставка того же события не меняет результат
+func (s *ServiceStorage) HandleGitEvent(ctx context.Context, event en.GitPREvent) (*en.GitEventResult, error) {
+	if event.Repository == "" || event.Number <= 0 {
+		return nil, errors.New("repository and PR number cannot be empty")
+	}
+	prID := event.PullRequestID()
+	if event.SenderLogin != "" {
+		ctx = en.ContextWithActor(ctx, fmt.Sprintf("%s:%s", event.Provider, event.SenderLogin))
+	}
+
+	var (
+		pr  *en.PullRequest
+		err error
+	)
+	switch event.Action {
+	case en.GitActionOpened:
+		pr, err = s.openFromGitEvent(ctx, prID, event)
+		if err == nil && pr == nil {
+			return &en.GitEventResult{Status: en.GitEventIgnored}, nil
+		}
+	case en.GitActionMerged:
+		// PR уже смержен во внешней системе, политика мержа здесь не применяется
+		pr, err = s.mergePullRequest(ctx, prID, false)
+	case en.GitActionClosed:
+		pr, err = s.ClosePullRequest(ctx, prID)
+	case en.GitActionReopened:
+		pr, err = s.ReopenPullRequest(ctx, prID)
+	case en.GitActionReadyForReview:
+		pr, err = s.MarkPullRequestReady(ctx, prID)
+	default:
+		return &en.GitEventResult{Status: en.GitEventIgnored}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &en.GitEventResult{Status: en.GitEventProcessed, PR: pr}, nil
+}
+
+// openFromGitEvent создает PR по событию opened. nil без ошибки — PR уже создан предыдущей доставкой
+func (s *ServiceStorage) openFromGitEvent(ctx context.Context, prID string, event en.GitPREvent) (*en.PullRequest, error) {
+	exists, err := s.storage.PRExists(ctx, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check PR existence")
+	}
+	if exists {
+		return nil, nil
+	}
+	authorID, err := s.resolveExternalUser(ctx, event.Provider, event.AuthorLogin)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreatePullRequest(ctx, prID, event.Title, authorID, en.PRCreateOptions{Draft: event.Draft})
+}
+
+func (s *ServiceStorage) resolveExternalUser(ctx context.Context, provider en.GitProvider, login string) (string, error) {
+	if login == "" {
+		return "", errors.New("author login cannot be empty")
+	}
+	userID, err := s.storage.GetUserIDByExternalLogin(ctx, provider, login)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve external identity")
+	}
+	if userID == "" {
+		return "", en.NewNotFoundError(fmt.Sprintf("%s identity", provider), login)
+	}
+	return userID, nil
+}
+
+// setExternalIdentity задает соответствие логина во внешней системе пользователю сервиса
+func (s *ServiceStorage) SetExternalIdentity(ctx context.Context, identity *en.ExternalIdentity) (*en.ExternalIdentity, error) {
+	if identity == nil || identity.Login == "" || identity.UserID == "" {
+		return nil, errors.New("login and user_id cannot be empty")
+	}
+	if err := validateGitProvider(identity.Provider); err != nil {
+		return nil, err
+	}
+
+	user, err := s.storage.GetUser(ctx, identity.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", identity.UserID)
+	}
+
+	if err := s.storage.SetExternalIdentity(ctx, identity); err != nil {
+		return nil, errors.Wrap(err, "failed to set external identity")
+	}
+	return identity, nil
+}
+
+// ListExternalIdentities возвращает соответствия логинов, provider может быть пустым
+func (s *ServiceStorage) ListExternalIdentities(ctx context.Context, provider en.GitProvider) ([]*en.ExternalIdentity, error) {
+	if provider != "" {
+		if err := validateGitProvider(provider); err != nil {
+			return nil, err
+		}
+	}
+	identities, err := s.storage.ListExternalIdentities(ctx, provider)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list external identities")
+	}
+	if identities == nil {
+		identities = []*en.ExternalIdentity{}
+	}
+	return identities, nil
+}
+
+// DeleteExternalIdentity удаляет соответствие логина
+func (s *ServiceStorage) DeleteExternalIdentity(ctx context.Context, provider en.GitProvider, login string) error {
+	if err := validateGitProvider(provider); err != nil {
+		return err
+	}
+	deleted, err := s.storage.DeleteExternalIdentity(ctx, provider, login)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete external identity")
+	}
+	if !deleted {
+		return en.NewNotFoundError(fmt.Sprintf("%s identity", provider), login)
+	}
+	return nil
+}
+
+func validateGitProvider(provider en.GitProvider) error {
+	switch provider {
+	case en.GitProviderGitHub, en.GitProviderGitLab:
+		return nil
+	default:
+		return errors.Errorf("unknown git provider '%s'", provider)
+	}
+}
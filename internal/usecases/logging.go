@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// logSelection пишет решение стратегии выбора: кого рассматривали, с какой нагрузкой и кого выбрали.
+// Идентификатор запроса из логгера контекста связывает строку с итоговым назначением
+func logSelection(ctx context.Context, teamName string, candidates []*en.User, load map[string]int, count int, picked []string) {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.UserID
+	}
+	attrs := []slog.Attr{
+		slog.String("team", teamName),
+		slog.Any("candidates", ids),
+		slog.Int("requested", count),
+		slog.Any("chosen", picked),
+	}
+	if load != nil {
+		attrs = append(attrs, slog.Any("load", load))
+	}
+	en.LoggerFromContext(ctx).LogAttrs(ctx, slog.LevelInfo, "reviewers selected", attrs...)
+}
+
+// loggedPicker функция выбора ревьюверов стратегией команды для хранилища, которая пишет каждое решение
+func loggedPicker(ctx context.Context, selectors *ReviewerSelectors) func(teamName string) en.ReviewerPicker {
+	return func(teamName string) en.ReviewerPicker {
+		selector := selectors.ForTeam(teamName)
+		return func(candidates []*en.User, load map[string]int, count int) []string {
+			picked := selector.Select(candidates, load, count)
+			logSelection(ctx, teamName, candidates, load, count, picked)
+			return picked
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// AddReviewer вручную назначает пользователя ревьювером OPEN PR. Пользователь должен быть активен,
+// не быть автором и состоять в команде PR или одной из ее резервных команд. max_reviewers ограничивает
+// только автоматическое назначение
+func (s *ServiceStorage) AddReviewer(ctx context.Context, prID, userID string) (*en.PullRequest, error) {
+	if prID == "" || userID == "" {
+		return nil, errors.New("pull_request_id and user_id cannot be empty")
+	}
+	pr, err := s.openPRForReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	sourceTeam, err := s.validateManualReviewer(ctx, pr, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReassignReviewer(ctx, prID, "", userID, sourceTeam, 0); err != nil {
+		return nil, errors.Wrap(err, "failed to add reviewer")
+	}
+	if s.metrics != nil {
+		s.metrics.ReviewersAssigned(en.ReasonManualAdd, 1)
+	}
+	en.LoggerFromContext(ctx).InfoContext(ctx, "reviewer added manually",
+		"pull_request_id", prID, "new_reviewer", userID, "team", sourceTeam)
+	return s.updatedPR(ctx, prID)
+}
+
+// RemoveReviewer снимает ревьювера с OPEN PR без замены, если после этого останется не меньше min_reviewers
+func (s *ServiceStorage) RemoveReviewer(ctx context.Context, prID, userID string) (*en.PullRequest, error) {
+	if prID == "" || userID == "" {
+		return nil, errors.New("pull_request_id and user_id cannot be empty")
+	}
+	pr, err := s.openPRForReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(pr.AssignedReviewers, userID) {
+		return nil, en.NewNotAssignedError(userID, prID)
+	}
+
+	teamName, err := s.prTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// минимум проверяется хранилищем под блокировкой PR: параллельное снятие могло уже уменьшить число ревьюверов
+	if err := s.storage.ReassignReviewer(ctx, prID, userID, "", "", settings.MinReviewers); err != nil {
+		return nil, errors.Wrap(err, "failed to remove reviewer")
+	}
+	if s.metrics != nil {
+		s.metrics.ReviewersRemoved(en.ReasonManualRemove, 1)
+	}
+	en.LoggerFromContext(ctx).InfoContext(ctx, "reviewer removed manually", "pull_request_id", prID, "old_reviewer", userID)
+	return s.updatedPR(ctx, prID)
+}
+
+// ReassignReviewerTo заменяет ревьювера на явно указанного пользователя с теми же проверками, что и AddReviewer
+func (s *ServiceStorage) ReassignReviewerTo(ctx context.Context, prID, oldUserID, newUserID string) (*en.PullRequest, error) {
+	if prID == "" || oldUserID == "" || newUserID == "" {
+		return nil, errors.New("pull_request_id, old_user_id and new_user_id cannot be empty")
+	}
+	pr, err := s.openPRForReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(pr.AssignedReviewers, oldUserID) {
+		return nil, en.NewNotAssignedError(oldUserID, prID)
+	}
+	sourceTeam, err := s.validateManualReviewer(ctx, pr, newUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReassignReviewer(ctx, prID, oldUserID, newUserID, sourceTeam, 0); err != nil {
+		return nil, errors.Wrap(err, "failed to reassign reviewer")
+	}
+	if s.metrics != nil {
+		s.metrics.ReviewersReassigned(en.ReasonManualReassign, 1)
+	}
+	en.LoggerFromContext(ctx).InfoContext(ctx, "reviewer reassigned manually",
+		"pull_request_id", prID, "old_reviewer", oldUserID, "new_reviewer", newUserID, "team", sourceTeam)
+	return s.updatedPR(ctx, prID)
+}
+
+// openPRForReviewers возвращает PR, ревьюверов которого можно менять: существующий и OPEN
+func (s *ServiceStorage) openPRForReviewers(ctx context.Context, prID string) (*en.PullRequest, error) {
+	pr, err := s.getExistingPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == en.StatusMerged {
+		return nil, en.NewPRMergedError(prID)
+	}
+	if pr.Status != en.StatusOpen {
+		return nil, en.NewPRNotOpenError(prID, pr.Status)
+	}
+	return pr, nil
+}
+
+// validateManualReviewer проверяет, что userID может быть ревьювером PR, и возвращает команду,
+// от которой он назначается: команду PR или первую резервную, в которой он состоит
+func (s *ServiceStorage) validateManualReviewer(ctx context.Context, pr *en.PullRequest, userID string) (string, error) {
+	if userID == pr.AuthorID {
+		return "", en.NewInvalidReviewerError(userID, pr.PullRequestID, "user is the author")
+	}
+	if contains(pr.AssignedReviewers, userID) {
+		return "", en.NewAlreadyAssignedError(userID, pr.PullRequestID)
+	}
+
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return "", en.NewNotFoundError("user", userID)
+	}
+	if !user.IsActive {
+		return "", en.NewInvalidReviewerError(userID, pr.PullRequestID, "user is inactive")
+	}
+
+	teamName, err := s.prTeam(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	if user.InTeam(teamName) {
+		return teamName, nil
+	}
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+	for _, fallback := range settings.FallbackTeams {
+		if user.InTeam(fallback) {
+			return fallback, nil
+		}
+	}
+	return "", en.NewInvalidTeamUserError(userID, teamName, "does not belong to")
+}
+
+// prTeam возвращает команду ревьюверов PR; для PR без команды - основную команду автора
+func (s *ServiceStorage) prTeam(ctx context.Context, pr *en.PullRequest) (string, error) {
+	if pr.TeamName != "" {
+		return pr.TeamName, nil
+	}
+	author, err := s.storage.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get author")
+	}
+	if author == nil {
+		return "", en.NewNotFoundError("author", pr.AuthorID)
+	}
+	return author.TeamName, nil
+}
+
+func (s *ServiceStorage) updatedPR(ctx context.Context, prID string) (*en.PullRequest, error) {
+	pr, err := s.storage.GetPR(ctx, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get updated PR")
+	}
+	return pr, nil
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// MergePolicy условия мержа PR по вердиктам ревьюверов. Нулевое значение отключает проверку
+type MergePolicy struct {
+	RequiredApprovals       int  // минимальное количество APPROVED от назначенных ревьюверов, не больше их числа на PR
+	BlockOnChangesRequested bool // запрещать мерж, пока есть неснятый CHANGES_REQUESTED
+}
+
+func (p MergePolicy) enabled() bool {
+	return p.RequiredApprovals > 0 || p.BlockOnChangesRequested
+}
+
+// reviewSummary итоговое состояние ревью PR по последним вердиктам назначенных ревьюверов
+type reviewSummary struct {
+	assigned         int
+	approvals        int
+	changesRequested []string
+}
+
+// summarizeReviews учитывает только текущих ревьюверов PR и только последний APPROVED / CHANGES_REQUESTED
+// каждого из них: COMMENTED не снимает ранее поставленный вердикт. reviews упорядочены по времени
+func summarizeReviews(reviews []*en.Review, assigned []string) reviewSummary {
+	latest := make(map[string]en.ReviewVerdict, len(assigned))
+	for _, r := range reviews {
+		if r.Verdict == en.VerdictCommented || !contains(assigned, r.ReviewerID) {
+			continue
+		}
+		latest[r.ReviewerID] = r.Verdict
+	}
+
+	summary := reviewSummary{assigned: len(assigned)}
+	for reviewerID, verdict := range latest {
+		switch verdict {
+		case en.VerdictApproved:
+			summary.approvals++
+		case en.VerdictChangesRequested:
+			summary.changesRequested = append(summary.changesRequested, reviewerID)
+		}
+	}
+	sort.Strings(summary.changesRequested)
+	return summary
+}
+
+// check возвращает MERGE_BLOCKED, если состояние ревью не удовлетворяет политике
+func (p MergePolicy) check(prID string, summary reviewSummary) error {
+	if p.BlockOnChangesRequested && len(summary.changesRequested) > 0 {
+		return en.NewMergeBlockedError(prID,
+			fmt.Sprintf("changes requested by %s", strings.Join(summary.changesRequested, ", ")))
+	}
+	// на PR может быть назначено меньше ревьюверов, чем требует политика: min_reviewers команды
+	// бывает меньше RequiredApprovals, иначе такой PR нельзя было бы смержить никогда
+	required := min(p.RequiredApprovals, summary.assigned)
+	if summary.approvals < required {
+		return en.NewMergeBlockedError(prID,
+			fmt.Sprintf("%d of %d required approvals", summary.approvals, required))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"testing"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeReviews(t *testing.T) {
+	reviews := []*en.Review{
+		{ReviewerID: "u2", Verdict: en.VerdictChangesRequested},
+		{ReviewerID: "u3", Verdict: en.VerdictApproved},
+		{ReviewerID: "u2", Verdict: en.VerdictApproved},
+		{ReviewerID: "u3", Verdict: en.VerdictCommented},
+		{ReviewerID: "u4", Verdict: en.VerdictChangesRequested},
+		{ReviewerID: "u5", Verdict: en.VerdictApproved},
+	}
+
+	// u5 больше не назначен, COMMENTED у u3 не снимает одобрение
+	summary := summarizeReviews(reviews, []string{"u2", "u3", "u4"})
+
+	assert.Equal(t, 3, summary.assigned)
+	assert.Equal(t, 2, summary.approvals)
+	assert.Equal(t, []string{"u4"}, summary.changesRequested)
+}
+
+func TestMergePolicy_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  MergePolicy
+		summary reviewSummary
+		blocked bool
+	}{
+		{"disabled", MergePolicy{}, reviewSummary{}, false},
+		{"enough approvals", MergePolicy{RequiredApprovals: 2}, reviewSummary{assigned: 2, approvals: 2}, false},
+		{"not enough approvals", MergePolicy{RequiredApprovals: 2}, reviewSummary{assigned: 3, approvals: 1}, true},
+		{"capped by assigned reviewers", MergePolicy{RequiredApprovals: 2}, reviewSummary{assigned: 1, approvals: 1}, false},
+		{"capped but not approved", MergePolicy{RequiredApprovals: 2}, reviewSummary{assigned: 1}, true},
+		{"no reviewers", MergePolicy{RequiredApprovals: 2}, reviewSummary{}, false},
+		{"changes requested", MergePolicy{BlockOnChangesRequested: true}, reviewSummary{assigned: 2, approvals: 1, changesRequested: []string{"u2"}}, true},
+		{"changes requested not blocking", MergePolicy{RequiredApprovals: 1}, reviewSummary{assigned: 2, approvals: 1, changesRequested: []string{"u2"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check("pr-1", tt.summary)
+			if !tt.blocked {
+				assert.NoError(t, err)
+				return
+			}
+			var appErr *en.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, en.ErrCodeMergeBlocked, appErr.Code)
+		})
+	}
+}
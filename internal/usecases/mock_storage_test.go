@@ -22,6 +22,175 @@ func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
     return &MockStorage_Expecter{mock: &_m.Mock}
 }
 
+// AddTeamMembers provides a mock function with given fields: ctx, teamName, users
+func (_m *MockStorage) AddTeamMembers(ctx context.Context, teamName string, users []*entities.User) error {
+    ret := _m.Called(ctx, teamName, users)
+
+    if len(ret) == 0 {
+        panic("no return value specified for AddTeamMembers")
+    }
+
+    var r0 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, []*entities.User) error); ok {
+        r0 = rf(ctx, teamName, users)
+    } else {
+        r0 = ret.Error(0)
+    }
+
+    return r0
+}
+
+// Storage_AddTeamMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTeamMembers'
+type Storage_AddTeamMembers_Call struct {
+    *mock.Call
+}
+
+// AddTeamMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - users []*entities.User
+func (_e *MockStorage_Expecter) AddTeamMembers(ctx interface{}, teamName interface{}, users interface{}) *Storage_AddTeamMembers_Call {
+    return &Storage_AddTeamMembers_Call{Call: _e.mock.On("AddTeamMembers", ctx, teamName, users)}
+}
+
+func (_c *Storage_AddTeamMembers_Call) Run(run func(ctx context.Context, teamName string, users []*entities.User)) *Storage_AddTeamMembers_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].([]*entities.User))
+    })
+    return _c
+}
+
+func (_c *Storage_AddTeamMembers_Call) Return(_a0 error) *Storage_AddTeamMembers_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_AddTeamMembers_Call) RunAndReturn(run func(context.Context, string, []*entities.User) error) *Storage_AddTeamMembers_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ClaimWebhookDeliveries provides a mock function with given fields: ctx, limit, now, lease
+func (_m *MockStorage) ClaimWebhookDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+    ret := _m.Called(ctx, limit, now, lease)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ClaimWebhookDeliveries")
+    }
+
+    var r0 []*entities.WebhookDelivery
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Duration) ([]*entities.WebhookDelivery, error)); ok {
+        return rf(ctx, limit, now, lease)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Duration) []*entities.WebhookDelivery); ok {
+        r0 = rf(ctx, limit, now, lease)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.WebhookDelivery)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Duration) error); ok {
+        r1 = rf(ctx, limit, now, lease)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ClaimWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWebhookDeliveries'
+type Storage_ClaimWebhookDeliveries_Call struct {
+    *mock.Call
+}
+
+// ClaimWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - now time.Time
+//   - lease time.Duration
+func (_e *MockStorage_Expecter) ClaimWebhookDeliveries(ctx interface{}, limit interface{}, now interface{}, lease interface{}) *Storage_ClaimWebhookDeliveries_Call {
+    return &Storage_ClaimWebhookDeliveries_Call{Call: _e.mock.On("ClaimWebhookDeliveries", ctx, limit, now, lease)}
+}
+
+func (_c *Storage_ClaimWebhookDeliveries_Call) Run(run func(ctx context.Context, limit int, now time.Time, lease time.Duration)) *Storage_ClaimWebhookDeliveries_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int), args[2].(time.Time), args[3].(time.Duration))
+    })
+    return _c
+}
+
+func (_c *Storage_ClaimWebhookDeliveries_Call) Return(_a0 []*entities.WebhookDelivery, _a1 error) *Storage_ClaimWebhookDeliveries_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ClaimWebhookDeliveries_Call) RunAndReturn(run func(context.Context, int, time.Time, time.Duration) ([]*entities.WebhookDelivery, error)) *Storage_ClaimWebhookDeliveries_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// CreateAPIToken provides a mock function with given fields: ctx, token, tokenHash
+func (_m *MockStorage) CreateAPIToken(ctx context.Context, token *entities.APIToken, tokenHash string) (*entities.APIToken, error) {
+    ret := _m.Called(ctx, token, tokenHash)
+
+    if len(ret) == 0 {
+        panic("no return value specified for CreateAPIToken")
+    }
+
+    var r0 *entities.APIToken
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.APIToken, string) (*entities.APIToken, error)); ok {
+        return rf(ctx, token, tokenHash)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.APIToken, string) *entities.APIToken); ok {
+        r0 = rf(ctx, token, tokenHash)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.APIToken)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.APIToken, string) error); ok {
+        r1 = rf(ctx, token, tokenHash)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type Storage_CreateAPIToken_Call struct {
+    *mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entities.APIToken
+//   - tokenHash string
+func (_e *MockStorage_Expecter) CreateAPIToken(ctx interface{}, token interface{}, tokenHash interface{}) *Storage_CreateAPIToken_Call {
+    return &Storage_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", ctx, token, tokenHash)}
+}
+
+func (_c *Storage_CreateAPIToken_Call) Run(run func(ctx context.Context, token *entities.APIToken, tokenHash string)) *Storage_CreateAPIToken_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(*entities.APIToken), args[2].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_CreateAPIToken_Call) Return(_a0 *entities.APIToken, _a1 error) *Storage_CreateAPIToken_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_CreateAPIToken_Call) RunAndReturn(run func(context.Context, *entities.APIToken, string) (*entities.APIToken, error)) *Storage_CreateAPIToken_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
 // CreatePRWithReviewers provides a mock function with given fields: ctx, pr, reviewerIDs
 func (_m *MockStorage) CreatePRWithReviewers(ctx context.Context, pr *entities.PullRequest, reviewerIDs []string) error {
     ret := _m.Called(ctx, pr, reviewerIDs)
@@ -70,17 +239,17 @@ func (_c *Storage_CreatePRWithReviewers_Call) RunAndReturn(run func(context.Cont
     return _c
 }
 
-// CreateTeamWithUsers provides a mock function with given fields: ctx, teamName, users
-func (_m *MockStorage) CreateTeamWithUsers(ctx context.Context, teamName string, users []*entities.User) error {
-    ret := _m.Called(ctx, teamName, users)
+// CreateReview provides a mock function with given fields: ctx, review
+func (_m *MockStorage) CreateReview(ctx context.Context, review *entities.Review) error {
+    ret := _m.Called(ctx, review)
 
     if len(ret) == 0 {
-        panic("no return value specified for CreateTeamWithUsers")
+        panic("no return value specified for CreateReview")
     }
 
     var r0 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, []*entities.User) error); ok {
-        r0 = rf(ctx, teamName, users)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.Review) error); ok {
+        r0 = rf(ctx, review)
     } else {
         r0 = ret.Error(0)
     }
@@ -88,6 +257,65 @@ func (_m *MockStorage) CreateTeamWithUsers(ctx context.Context, teamName string,
     return r0
 }
 
+// Storage_CreateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReview'
+type Storage_CreateReview_Call struct {
+    *mock.Call
+}
+
+// CreateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review *entities.Review
+func (_e *MockStorage_Expecter) CreateReview(ctx interface{}, review interface{}) *Storage_CreateReview_Call {
+    return &Storage_CreateReview_Call{Call: _e.mock.On("CreateReview", ctx, review)}
+}
+
+func (_c *Storage_CreateReview_Call) Run(run func(ctx context.Context, review *entities.Review)) *Storage_CreateReview_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(*entities.Review))
+    })
+    return _c
+}
+
+func (_c *Storage_CreateReview_Call) Return(_a0 error) *Storage_CreateReview_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_CreateReview_Call) RunAndReturn(run func(context.Context, *entities.Review) error) *Storage_CreateReview_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// CreateTeamWithUsers provides a mock function with given fields: ctx, teamName, settings, users, opts, picker
+func (_m *MockStorage) CreateTeamWithUsers(ctx context.Context, teamName string, settings *entities.TeamSettings, users []*entities.User, opts entities.TeamCreateOptions, picker func(teamName string) entities.ReviewerPicker) (*entities.TeamCreateResult, error) {
+    ret := _m.Called(ctx, teamName, settings, users, opts, picker)
+
+    if len(ret) == 0 {
+        panic("no return value specified for CreateTeamWithUsers")
+    }
+
+    var r0 *entities.TeamCreateResult
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, *entities.TeamSettings, []*entities.User, entities.TeamCreateOptions, func(teamName string) entities.ReviewerPicker) (*entities.TeamCreateResult, error)); ok {
+        return rf(ctx, teamName, settings, users, opts, picker)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, *entities.TeamSettings, []*entities.User, entities.TeamCreateOptions, func(teamName string) entities.ReviewerPicker) *entities.TeamCreateResult); ok {
+        r0 = rf(ctx, teamName, settings, users, opts, picker)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.TeamCreateResult)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, *entities.TeamSettings, []*entities.User, entities.TeamCreateOptions, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, teamName, settings, users, opts, picker)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
 // Storage_CreateTeamWithUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTeamWithUsers'
 type Storage_CreateTeamWithUsers_Call struct {
     *mock.Call
@@ -96,51 +324,54 @@ type Storage_CreateTeamWithUsers_Call struct {
 // CreateTeamWithUsers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
+//   - settings *entities.TeamSettings
 //   - users []*entities.User
-func (_e *MockStorage_Expecter) CreateTeamWithUsers(ctx interface{}, teamName interface{}, users interface{}) *Storage_CreateTeamWithUsers_Call {
-    return &Storage_CreateTeamWithUsers_Call{Call: _e.mock.On("CreateTeamWithUsers", ctx, teamName, users)}
+//   - opts entities.TeamCreateOptions
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) CreateTeamWithUsers(ctx interface{}, teamName interface{}, settings interface{}, users interface{}, opts interface{}, picker interface{}) *Storage_CreateTeamWithUsers_Call {
+    return &Storage_CreateTeamWithUsers_Call{Call: _e.mock.On("CreateTeamWithUsers", ctx, teamName, settings, users, opts, picker)}
 }
 
-func (_c *Storage_CreateTeamWithUsers_Call) Run(run func(ctx context.Context, teamName string, users []*entities.User)) *Storage_CreateTeamWithUsers_Call {
+func (_c *Storage_CreateTeamWithUsers_Call) Run(run func(ctx context.Context, teamName string, settings *entities.TeamSettings, users []*entities.User, opts entities.TeamCreateOptions, picker func(teamName string) entities.ReviewerPicker)) *Storage_CreateTeamWithUsers_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].([]*entities.User))
+        run(args[0].(context.Context), args[1].(string), args[2].(*entities.TeamSettings), args[3].([]*entities.User), args[4].(entities.TeamCreateOptions), args[5].(func(teamName string) entities.ReviewerPicker))
     })
     return _c
 }
 
-func (_c *Storage_CreateTeamWithUsers_Call) Return(_a0 error) *Storage_CreateTeamWithUsers_Call {
-    _c.Call.Return(_a0)
+func (_c *Storage_CreateTeamWithUsers_Call) Return(_a0 *entities.TeamCreateResult, _a1 error) *Storage_CreateTeamWithUsers_Call {
+    _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_CreateTeamWithUsers_Call) RunAndReturn(run func(context.Context, string, []*entities.User) error) *Storage_CreateTeamWithUsers_Call {
+func (_c *Storage_CreateTeamWithUsers_Call) RunAndReturn(run func(context.Context, string, *entities.TeamSettings, []*entities.User, entities.TeamCreateOptions, func(teamName string) entities.ReviewerPicker) (*entities.TeamCreateResult, error)) *Storage_CreateTeamWithUsers_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// DeactivateTeamMembersWithReassignment provides a mock function with given fields: ctx, teamName, userIDs
-func (_m *MockStorage) DeactivateTeamMembersWithReassignment(ctx context.Context, teamName string, userIDs []string) (*entities.DeactivateResult, error) {
-    ret := _m.Called(ctx, teamName, userIDs)
+// CreateUnavailability provides a mock function with given fields: ctx, period
+func (_m *MockStorage) CreateUnavailability(ctx context.Context, period *entities.Unavailability) (*entities.Unavailability, error) {
+    ret := _m.Called(ctx, period)
 
     if len(ret) == 0 {
-        panic("no return value specified for DeactivateTeamMembersWithReassignment")
+        panic("no return value specified for CreateUnavailability")
     }
 
-    var r0 *entities.DeactivateResult
+    var r0 *entities.Unavailability
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*entities.DeactivateResult, error)); ok {
-        return rf(ctx, teamName, userIDs)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.Unavailability) (*entities.Unavailability, error)); ok {
+        return rf(ctx, period)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string, []string) *entities.DeactivateResult); ok {
-        r0 = rf(ctx, teamName, userIDs)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.Unavailability) *entities.Unavailability); ok {
+        r0 = rf(ctx, period)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.DeactivateResult)
+            r0 = ret.Get(0).(*entities.Unavailability)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
-        r1 = rf(ctx, teamName, userIDs)
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.Unavailability) error); ok {
+        r1 = rf(ctx, period)
     } else {
         r1 = ret.Error(1)
     }
@@ -148,59 +379,58 @@ func (_m *MockStorage) DeactivateTeamMembersWithReassignment(ctx context.Context
     return r0, r1
 }
 
-// Storage_DeactivateTeamMembersWithReassignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateTeamMembersWithReassignment'
-type Storage_DeactivateTeamMembersWithReassignment_Call struct {
+// Storage_CreateUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUnavailability'
+type Storage_CreateUnavailability_Call struct {
     *mock.Call
 }
 
-// DeactivateTeamMembersWithReassignment is a helper method to define mock.On call
+// CreateUnavailability is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-//   - userIDs []string
-func (_e *MockStorage_Expecter) DeactivateTeamMembersWithReassignment(ctx interface{}, teamName interface{}, userIDs interface{}) *Storage_DeactivateTeamMembersWithReassignment_Call {
-    return &Storage_DeactivateTeamMembersWithReassignment_Call{Call: _e.mock.On("DeactivateTeamMembersWithReassignment", ctx, teamName, userIDs)}
+//   - period *entities.Unavailability
+func (_e *MockStorage_Expecter) CreateUnavailability(ctx interface{}, period interface{}) *Storage_CreateUnavailability_Call {
+    return &Storage_CreateUnavailability_Call{Call: _e.mock.On("CreateUnavailability", ctx, period)}
 }
 
-func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) Run(run func(ctx context.Context, teamName string, userIDs []string)) *Storage_DeactivateTeamMembersWithReassignment_Call {
+func (_c *Storage_CreateUnavailability_Call) Run(run func(ctx context.Context, period *entities.Unavailability)) *Storage_CreateUnavailability_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].([]string))
+        run(args[0].(context.Context), args[1].(*entities.Unavailability))
     })
     return _c
 }
 
-func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) Return(_a0 *entities.DeactivateResult, _a1 error) *Storage_DeactivateTeamMembersWithReassignment_Call {
+func (_c *Storage_CreateUnavailability_Call) Return(_a0 *entities.Unavailability, _a1 error) *Storage_CreateUnavailability_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) RunAndReturn(run func(context.Context, string, []string) (*entities.DeactivateResult, error)) *Storage_DeactivateTeamMembersWithReassignment_Call {
+func (_c *Storage_CreateUnavailability_Call) RunAndReturn(run func(context.Context, *entities.Unavailability) (*entities.Unavailability, error)) *Storage_CreateUnavailability_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetPR provides a mock function with given fields: ctx, prID
-func (_m *MockStorage) GetPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
-    ret := _m.Called(ctx, prID)
+// CreateWebhookSubscription provides a mock function with given fields: ctx, sub
+func (_m *MockStorage) CreateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error) {
+    ret := _m.Called(ctx, sub)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetPR")
+        panic("no return value specified for CreateWebhookSubscription")
     }
 
-    var r0 *entities.PullRequest
+    var r0 *entities.WebhookSubscription
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.PullRequest, error)); ok {
-        return rf(ctx, prID)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookSubscription) (*entities.WebhookSubscription, error)); ok {
+        return rf(ctx, sub)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.PullRequest); ok {
-        r0 = rf(ctx, prID)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookSubscription) *entities.WebhookSubscription); ok {
+        r0 = rf(ctx, sub)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.PullRequest)
+            r0 = ret.Get(0).(*entities.WebhookSubscription)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, prID)
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.WebhookSubscription) error); ok {
+        r1 = rf(ctx, sub)
     } else {
         r1 = ret.Error(1)
     }
@@ -208,58 +438,58 @@ func (_m *MockStorage) GetPR(ctx context.Context, prID string) (*entities.PullRe
     return r0, r1
 }
 
-// Storage_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
-type Storage_GetPR_Call struct {
+// Storage_CreateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookSubscription'
+type Storage_CreateWebhookSubscription_Call struct {
     *mock.Call
 }
 
-// GetPR is a helper method to define mock.On call
+// CreateWebhookSubscription is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *MockStorage_Expecter) GetPR(ctx interface{}, prID interface{}) *Storage_GetPR_Call {
-    return &Storage_GetPR_Call{Call: _e.mock.On("GetPR", ctx, prID)}
+//   - sub *entities.WebhookSubscription
+func (_e *MockStorage_Expecter) CreateWebhookSubscription(ctx interface{}, sub interface{}) *Storage_CreateWebhookSubscription_Call {
+    return &Storage_CreateWebhookSubscription_Call{Call: _e.mock.On("CreateWebhookSubscription", ctx, sub)}
 }
 
-func (_c *Storage_GetPR_Call) Run(run func(ctx context.Context, prID string)) *Storage_GetPR_Call {
+func (_c *Storage_CreateWebhookSubscription_Call) Run(run func(ctx context.Context, sub *entities.WebhookSubscription)) *Storage_CreateWebhookSubscription_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(*entities.WebhookSubscription))
     })
     return _c
 }
 
-func (_c *Storage_GetPR_Call) Return(_a0 *entities.PullRequest, _a1 error) *Storage_GetPR_Call {
+func (_c *Storage_CreateWebhookSubscription_Call) Return(_a0 *entities.WebhookSubscription, _a1 error) *Storage_CreateWebhookSubscription_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetPR_Call) RunAndReturn(run func(context.Context, string) (*entities.PullRequest, error)) *Storage_GetPR_Call {
+func (_c *Storage_CreateWebhookSubscription_Call) RunAndReturn(run func(context.Context, *entities.WebhookSubscription) (*entities.WebhookSubscription, error)) *Storage_CreateWebhookSubscription_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetPRsByReviewer provides a mock function with given fields: ctx, userID
-func (_m *MockStorage) GetPRsByReviewer(ctx context.Context, userID string) ([]*entities.PullRequestShort, error) {
-    ret := _m.Called(ctx, userID)
+// DeactivateTeamMembersWithReassignment provides a mock function with given fields: ctx, teamName, userIDs, picker
+func (_m *MockStorage) DeactivateTeamMembersWithReassignment(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker) (*entities.DeactivateResult, error) {
+    ret := _m.Called(ctx, teamName, userIDs, picker)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetPRsByReviewer")
+        panic("no return value specified for DeactivateTeamMembersWithReassignment")
     }
 
-    var r0 []*entities.PullRequestShort
+    var r0 *entities.DeactivateResult
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.PullRequestShort, error)); ok {
-        return rf(ctx, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) (*entities.DeactivateResult, error)); ok {
+        return rf(ctx, teamName, userIDs, picker)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.PullRequestShort); ok {
-        r0 = rf(ctx, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) *entities.DeactivateResult); ok {
+        r0 = rf(ctx, teamName, userIDs, picker)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).([]*entities.PullRequestShort)
+            r0 = ret.Get(0).(*entities.DeactivateResult)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, userID)
+    if rf, ok := ret.Get(1).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, teamName, userIDs, picker)
     } else {
         r1 = ret.Error(1)
     }
@@ -267,58 +497,58 @@ func (_m *MockStorage) GetPRsByReviewer(ctx context.Context, userID string) ([]*
     return r0, r1
 }
 
-// Storage_GetPRsByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRsByReviewer'
-type Storage_GetPRsByReviewer_Call struct {
+// Storage_DeactivateTeamMembersWithReassignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateTeamMembersWithReassignment'
+type Storage_DeactivateTeamMembersWithReassignment_Call struct {
     *mock.Call
 }
 
-// GetPRsByReviewer is a helper method to define mock.On call
+// DeactivateTeamMembersWithReassignment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockStorage_Expecter) GetPRsByReviewer(ctx interface{}, userID interface{}) *Storage_GetPRsByReviewer_Call {
-    return &Storage_GetPRsByReviewer_Call{Call: _e.mock.On("GetPRsByReviewer", ctx, userID)}
+//   - teamName string
+//   - userIDs []string
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) DeactivateTeamMembersWithReassignment(ctx interface{}, teamName interface{}, userIDs interface{}, picker interface{}) *Storage_DeactivateTeamMembersWithReassignment_Call {
+    return &Storage_DeactivateTeamMembersWithReassignment_Call{Call: _e.mock.On("DeactivateTeamMembersWithReassignment", ctx, teamName, userIDs, picker)}
 }
 
-func (_c *Storage_GetPRsByReviewer_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetPRsByReviewer_Call {
+func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) Run(run func(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker)) *Storage_DeactivateTeamMembersWithReassignment_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(func(teamName string) entities.ReviewerPicker))
     })
     return _c
 }
 
-func (_c *Storage_GetPRsByReviewer_Call) Return(_a0 []*entities.PullRequestShort, _a1 error) *Storage_GetPRsByReviewer_Call {
+func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) Return(_a0 *entities.DeactivateResult, _a1 error) *Storage_DeactivateTeamMembersWithReassignment_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetPRsByReviewer_Call) RunAndReturn(run func(context.Context, string) ([]*entities.PullRequestShort, error)) *Storage_GetPRsByReviewer_Call {
+func (_c *Storage_DeactivateTeamMembersWithReassignment_Call) RunAndReturn(run func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) (*entities.DeactivateResult, error)) *Storage_DeactivateTeamMembersWithReassignment_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetStats provides a mock function with given fields: ctx
-func (_m *MockStorage) GetStats(ctx context.Context) (*entities.Stats, error) {
-    ret := _m.Called(ctx)
+// DeleteExternalIdentity provides a mock function with given fields: ctx, provider, login
+func (_m *MockStorage) DeleteExternalIdentity(ctx context.Context, provider entities.GitProvider, login string) (bool, error) {
+    ret := _m.Called(ctx, provider, login)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetStats")
+        panic("no return value specified for DeleteExternalIdentity")
     }
 
-    var r0 *entities.Stats
+    var r0 bool
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context) (*entities.Stats, error)); ok {
-        return rf(ctx)
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider, string) (bool, error)); ok {
+        return rf(ctx, provider, login)
     }
-    if rf, ok := ret.Get(0).(func(context.Context) *entities.Stats); ok {
-        r0 = rf(ctx)
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider, string) bool); ok {
+        r0 = rf(ctx, provider, login)
     } else {
-        if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.Stats)
-        }
+        r0 = ret.Get(0).(bool)
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-        r1 = rf(ctx)
+    if rf, ok := ret.Get(1).(func(context.Context, entities.GitProvider, string) error); ok {
+        r1 = rf(ctx, provider, login)
     } else {
         r1 = ret.Error(1)
     }
@@ -326,57 +556,2671 @@ func (_m *MockStorage) GetStats(ctx context.Context) (*entities.Stats, error) {
     return r0, r1
 }
 
-// Storage_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
-type Storage_GetStats_Call struct {
+// Storage_DeleteExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExternalIdentity'
+type Storage_DeleteExternalIdentity_Call struct {
     *mock.Call
 }
 
-// GetStats is a helper method to define mock.On call
+// DeleteExternalIdentity is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStorage_Expecter) GetStats(ctx interface{}) *Storage_GetStats_Call {
-    return &Storage_GetStats_Call{Call: _e.mock.On("GetStats", ctx)}
+//   - provider entities.GitProvider
+//   - login string
+func (_e *MockStorage_Expecter) DeleteExternalIdentity(ctx interface{}, provider interface{}, login interface{}) *Storage_DeleteExternalIdentity_Call {
+    return &Storage_DeleteExternalIdentity_Call{Call: _e.mock.On("DeleteExternalIdentity", ctx, provider, login)}
 }
 
-func (_c *Storage_GetStats_Call) Run(run func(ctx context.Context)) *Storage_GetStats_Call {
+func (_c *Storage_DeleteExternalIdentity_Call) Run(run func(ctx context.Context, provider entities.GitProvider, login string)) *Storage_DeleteExternalIdentity_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context))
+        run(args[0].(context.Context), args[1].(entities.GitProvider), args[2].(string))
     })
     return _c
 }
 
-func (_c *Storage_GetStats_Call) Return(_a0 *entities.Stats, _a1 error) *Storage_GetStats_Call {
+func (_c *Storage_DeleteExternalIdentity_Call) Return(_a0 bool, _a1 error) *Storage_DeleteExternalIdentity_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetStats_Call) RunAndReturn(run func(context.Context) (*entities.Stats, error)) *Storage_GetStats_Call {
+func (_c *Storage_DeleteExternalIdentity_Call) RunAndReturn(run func(context.Context, entities.GitProvider, string) (bool, error)) *Storage_DeleteExternalIdentity_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetTeamByName provides a mock function with given fields: ctx, teamName
-func (_m *MockStorage) GetTeamByName(ctx context.Context, teamName string) (*entities.Team, error) {
+// DeleteTeam provides a mock function with given fields: ctx, teamName
+func (_m *MockStorage) DeleteTeam(ctx context.Context, teamName string) (bool, error) {
     ret := _m.Called(ctx, teamName)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetTeamByName")
+        panic("no return value specified for DeleteTeam")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+        return rf(ctx, teamName)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+        r0 = rf(ctx, teamName)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, teamName)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_DeleteTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTeam'
+type Storage_DeleteTeam_Call struct {
+    *mock.Call
+}
+
+// DeleteTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockStorage_Expecter) DeleteTeam(ctx interface{}, teamName interface{}) *Storage_DeleteTeam_Call {
+    return &Storage_DeleteTeam_Call{Call: _e.mock.On("DeleteTeam", ctx, teamName)}
+}
+
+func (_c *Storage_DeleteTeam_Call) Run(run func(ctx context.Context, teamName string)) *Storage_DeleteTeam_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_DeleteTeam_Call) Return(_a0 bool, _a1 error) *Storage_DeleteTeam_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_DeleteTeam_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Storage_DeleteTeam_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// DeleteUnavailability provides a mock function with given fields: ctx, periodID
+func (_m *MockStorage) DeleteUnavailability(ctx context.Context, periodID int64) (bool, error) {
+    ret := _m.Called(ctx, periodID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for DeleteUnavailability")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+        return rf(ctx, periodID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+        r0 = rf(ctx, periodID)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+        r1 = rf(ctx, periodID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_DeleteUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnavailability'
+type Storage_DeleteUnavailability_Call struct {
+    *mock.Call
+}
+
+// DeleteUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - periodID int64
+func (_e *MockStorage_Expecter) DeleteUnavailability(ctx interface{}, periodID interface{}) *Storage_DeleteUnavailability_Call {
+    return &Storage_DeleteUnavailability_Call{Call: _e.mock.On("DeleteUnavailability", ctx, periodID)}
+}
+
+func (_c *Storage_DeleteUnavailability_Call) Run(run func(ctx context.Context, periodID int64)) *Storage_DeleteUnavailability_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64))
+    })
+    return _c
+}
+
+func (_c *Storage_DeleteUnavailability_Call) Return(_a0 bool, _a1 error) *Storage_DeleteUnavailability_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_DeleteUnavailability_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *Storage_DeleteUnavailability_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockStorage) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) (bool, error) {
+    ret := _m.Called(ctx, subscriptionID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for DeleteWebhookSubscription")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+        return rf(ctx, subscriptionID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+        r0 = rf(ctx, subscriptionID)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+        r1 = rf(ctx, subscriptionID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_DeleteWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookSubscription'
+type Storage_DeleteWebhookSubscription_Call struct {
+    *mock.Call
+}
+
+// DeleteWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+func (_e *MockStorage_Expecter) DeleteWebhookSubscription(ctx interface{}, subscriptionID interface{}) *Storage_DeleteWebhookSubscription_Call {
+    return &Storage_DeleteWebhookSubscription_Call{Call: _e.mock.On("DeleteWebhookSubscription", ctx, subscriptionID)}
+}
+
+func (_c *Storage_DeleteWebhookSubscription_Call) Run(run func(ctx context.Context, subscriptionID int64)) *Storage_DeleteWebhookSubscription_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64))
+    })
+    return _c
+}
+
+func (_c *Storage_DeleteWebhookSubscription_Call) Return(_a0 bool, _a1 error) *Storage_DeleteWebhookSubscription_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_DeleteWebhookSubscription_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *Storage_DeleteWebhookSubscription_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// EscalateOverdueReviews provides a mock function with given fields: ctx, now, limit, picker
+func (_m *MockStorage) EscalateOverdueReviews(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker) ([]entities.ReviewEscalation, error) {
+    ret := _m.Called(ctx, now, limit, picker)
+
+    if len(ret) == 0 {
+        panic("no return value specified for EscalateOverdueReviews")
+    }
+
+    var r0 []entities.ReviewEscalation
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) ([]entities.ReviewEscalation, error)); ok {
+        return rf(ctx, now, limit, picker)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) []entities.ReviewEscalation); ok {
+        r0 = rf(ctx, now, limit, picker)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.ReviewEscalation)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, now, limit, picker)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_EscalateOverdueReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EscalateOverdueReviews'
+type Storage_EscalateOverdueReviews_Call struct {
+    *mock.Call
+}
+
+// EscalateOverdueReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) EscalateOverdueReviews(ctx interface{}, now interface{}, limit interface{}, picker interface{}) *Storage_EscalateOverdueReviews_Call {
+    return &Storage_EscalateOverdueReviews_Call{Call: _e.mock.On("EscalateOverdueReviews", ctx, now, limit, picker)}
+}
+
+func (_c *Storage_EscalateOverdueReviews_Call) Run(run func(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker)) *Storage_EscalateOverdueReviews_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(func(teamName string) entities.ReviewerPicker))
+    })
+    return _c
+}
+
+func (_c *Storage_EscalateOverdueReviews_Call) Return(_a0 []entities.ReviewEscalation, _a1 error) *Storage_EscalateOverdueReviews_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_EscalateOverdueReviews_Call) RunAndReturn(run func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) ([]entities.ReviewEscalation, error)) *Storage_EscalateOverdueReviews_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetAPITokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *MockStorage) GetAPITokenByHash(ctx context.Context, tokenHash string) (*entities.APIToken, error) {
+    ret := _m.Called(ctx, tokenHash)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetAPITokenByHash")
+    }
+
+    var r0 *entities.APIToken
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.APIToken, error)); ok {
+        return rf(ctx, tokenHash)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.APIToken); ok {
+        r0 = rf(ctx, tokenHash)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.APIToken)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, tokenHash)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetAPITokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPITokenByHash'
+type Storage_GetAPITokenByHash_Call struct {
+    *mock.Call
+}
+
+// GetAPITokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockStorage_Expecter) GetAPITokenByHash(ctx interface{}, tokenHash interface{}) *Storage_GetAPITokenByHash_Call {
+    return &Storage_GetAPITokenByHash_Call{Call: _e.mock.On("GetAPITokenByHash", ctx, tokenHash)}
+}
+
+func (_c *Storage_GetAPITokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *Storage_GetAPITokenByHash_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetAPITokenByHash_Call) Return(_a0 *entities.APIToken, _a1 error) *Storage_GetAPITokenByHash_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetAPITokenByHash_Call) RunAndReturn(run func(context.Context, string) (*entities.APIToken, error)) *Storage_GetAPITokenByHash_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetNotificationSettings provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) GetNotificationSettings(ctx context.Context, userID string) (*entities.NotificationSettings, error) {
+    ret := _m.Called(ctx, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetNotificationSettings")
+    }
+
+    var r0 *entities.NotificationSettings
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.NotificationSettings, error)); ok {
+        return rf(ctx, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.NotificationSettings); ok {
+        r0 = rf(ctx, userID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.NotificationSettings)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetNotificationSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationSettings'
+type Storage_GetNotificationSettings_Call struct {
+    *mock.Call
+}
+
+// GetNotificationSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStorage_Expecter) GetNotificationSettings(ctx interface{}, userID interface{}) *Storage_GetNotificationSettings_Call {
+    return &Storage_GetNotificationSettings_Call{Call: _e.mock.On("GetNotificationSettings", ctx, userID)}
+}
+
+func (_c *Storage_GetNotificationSettings_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetNotificationSettings_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetNotificationSettings_Call) Return(_a0 *entities.NotificationSettings, _a1 error) *Storage_GetNotificationSettings_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetNotificationSettings_Call) RunAndReturn(run func(context.Context, string) (*entities.NotificationSettings, error)) *Storage_GetNotificationSettings_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetOpenReviewLoad provides a mock function with given fields: ctx, userIDs
+func (_m *MockStorage) GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+    ret := _m.Called(ctx, userIDs)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetOpenReviewLoad")
+    }
+
+    var r0 map[string]int
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+        return rf(ctx, userIDs)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+        r0 = rf(ctx, userIDs)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(map[string]int)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+        r1 = rf(ctx, userIDs)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetOpenReviewLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenReviewLoad'
+type Storage_GetOpenReviewLoad_Call struct {
+    *mock.Call
+}
+
+// GetOpenReviewLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockStorage_Expecter) GetOpenReviewLoad(ctx interface{}, userIDs interface{}) *Storage_GetOpenReviewLoad_Call {
+    return &Storage_GetOpenReviewLoad_Call{Call: _e.mock.On("GetOpenReviewLoad", ctx, userIDs)}
+}
+
+func (_c *Storage_GetOpenReviewLoad_Call) Run(run func(ctx context.Context, userIDs []string)) *Storage_GetOpenReviewLoad_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].([]string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetOpenReviewLoad_Call) Return(_a0 map[string]int, _a1 error) *Storage_GetOpenReviewLoad_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetOpenReviewLoad_Call) RunAndReturn(run func(context.Context, []string) (map[string]int, error)) *Storage_GetOpenReviewLoad_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetPR provides a mock function with given fields: ctx, prID
+func (_m *MockStorage) GetPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+    ret := _m.Called(ctx, prID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetPR")
+    }
+
+    var r0 *entities.PullRequest
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.PullRequest, error)); ok {
+        return rf(ctx, prID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.PullRequest); ok {
+        r0 = rf(ctx, prID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.PullRequest)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, prID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
+type Storage_GetPR_Call struct {
+    *mock.Call
+}
+
+// GetPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockStorage_Expecter) GetPR(ctx interface{}, prID interface{}) *Storage_GetPR_Call {
+    return &Storage_GetPR_Call{Call: _e.mock.On("GetPR", ctx, prID)}
+}
+
+func (_c *Storage_GetPR_Call) Run(run func(ctx context.Context, prID string)) *Storage_GetPR_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetPR_Call) Return(_a0 *entities.PullRequest, _a1 error) *Storage_GetPR_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetPR_Call) RunAndReturn(run func(context.Context, string) (*entities.PullRequest, error)) *Storage_GetPR_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetPREvents provides a mock function with given fields: ctx, prID
+func (_m *MockStorage) GetPREvents(ctx context.Context, prID string) ([]*entities.PREvent, error) {
+    ret := _m.Called(ctx, prID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetPREvents")
+    }
+
+    var r0 []*entities.PREvent
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.PREvent, error)); ok {
+        return rf(ctx, prID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.PREvent); ok {
+        r0 = rf(ctx, prID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.PREvent)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, prID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetPREvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPREvents'
+type Storage_GetPREvents_Call struct {
+    *mock.Call
+}
+
+// GetPREvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockStorage_Expecter) GetPREvents(ctx interface{}, prID interface{}) *Storage_GetPREvents_Call {
+    return &Storage_GetPREvents_Call{Call: _e.mock.On("GetPREvents", ctx, prID)}
+}
+
+func (_c *Storage_GetPREvents_Call) Run(run func(ctx context.Context, prID string)) *Storage_GetPREvents_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetPREvents_Call) Return(_a0 []*entities.PREvent, _a1 error) *Storage_GetPREvents_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetPREvents_Call) RunAndReturn(run func(context.Context, string) ([]*entities.PREvent, error)) *Storage_GetPREvents_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetPRReviews provides a mock function with given fields: ctx, prID
+func (_m *MockStorage) GetPRReviews(ctx context.Context, prID string) ([]*entities.Review, error) {
+    ret := _m.Called(ctx, prID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetPRReviews")
+    }
+
+    var r0 []*entities.Review
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.Review, error)); ok {
+        return rf(ctx, prID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.Review); ok {
+        r0 = rf(ctx, prID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.Review)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, prID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetPRReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRReviews'
+type Storage_GetPRReviews_Call struct {
+    *mock.Call
+}
+
+// GetPRReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockStorage_Expecter) GetPRReviews(ctx interface{}, prID interface{}) *Storage_GetPRReviews_Call {
+    return &Storage_GetPRReviews_Call{Call: _e.mock.On("GetPRReviews", ctx, prID)}
+}
+
+func (_c *Storage_GetPRReviews_Call) Run(run func(ctx context.Context, prID string)) *Storage_GetPRReviews_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetPRReviews_Call) Return(_a0 []*entities.Review, _a1 error) *Storage_GetPRReviews_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetPRReviews_Call) RunAndReturn(run func(context.Context, string) ([]*entities.Review, error)) *Storage_GetPRReviews_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetPRThroughput provides a mock function with given fields: ctx, filter
+func (_m *MockStorage) GetPRThroughput(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.ThroughputPoint, error) {
+    ret := _m.Called(ctx, filter)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetPRThroughput")
+    }
+
+    var r0 []entities.ThroughputPoint
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) ([]entities.ThroughputPoint, error)); ok {
+        return rf(ctx, filter)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) []entities.ThroughputPoint); ok {
+        r0 = rf(ctx, filter)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.ThroughputPoint)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.AnalyticsFilter) error); ok {
+        r1 = rf(ctx, filter)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetPRThroughput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRThroughput'
+type Storage_GetPRThroughput_Call struct {
+    *mock.Call
+}
+
+// GetPRThroughput is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.AnalyticsFilter
+func (_e *MockStorage_Expecter) GetPRThroughput(ctx interface{}, filter interface{}) *Storage_GetPRThroughput_Call {
+    return &Storage_GetPRThroughput_Call{Call: _e.mock.On("GetPRThroughput", ctx, filter)}
+}
+
+func (_c *Storage_GetPRThroughput_Call) Run(run func(ctx context.Context, filter entities.AnalyticsFilter)) *Storage_GetPRThroughput_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.AnalyticsFilter))
+    })
+    return _c
+}
+
+func (_c *Storage_GetPRThroughput_Call) Return(_a0 []entities.ThroughputPoint, _a1 error) *Storage_GetPRThroughput_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetPRThroughput_Call) RunAndReturn(run func(context.Context, entities.AnalyticsFilter) ([]entities.ThroughputPoint, error)) *Storage_GetPRThroughput_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetPRsByReviewer provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) GetPRsByReviewer(ctx context.Context, userID string) ([]*entities.PullRequestShort, error) {
+    ret := _m.Called(ctx, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetPRsByReviewer")
+    }
+
+    var r0 []*entities.PullRequestShort
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.PullRequestShort, error)); ok {
+        return rf(ctx, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.PullRequestShort); ok {
+        r0 = rf(ctx, userID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.PullRequestShort)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetPRsByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRsByReviewer'
+type Storage_GetPRsByReviewer_Call struct {
+    *mock.Call
+}
+
+// GetPRsByReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStorage_Expecter) GetPRsByReviewer(ctx interface{}, userID interface{}) *Storage_GetPRsByReviewer_Call {
+    return &Storage_GetPRsByReviewer_Call{Call: _e.mock.On("GetPRsByReviewer", ctx, userID)}
+}
+
+func (_c *Storage_GetPRsByReviewer_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetPRsByReviewer_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetPRsByReviewer_Call) Return(_a0 []*entities.PullRequestShort, _a1 error) *Storage_GetPRsByReviewer_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetPRsByReviewer_Call) RunAndReturn(run func(context.Context, string) ([]*entities.PullRequestShort, error)) *Storage_GetPRsByReviewer_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetReassignmentStats provides a mock function with given fields: ctx, filter
+func (_m *MockStorage) GetReassignmentStats(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.TeamReassignments, error) {
+    ret := _m.Called(ctx, filter)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetReassignmentStats")
+    }
+
+    var r0 []entities.TeamReassignments
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) ([]entities.TeamReassignments, error)); ok {
+        return rf(ctx, filter)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) []entities.TeamReassignments); ok {
+        r0 = rf(ctx, filter)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.TeamReassignments)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.AnalyticsFilter) error); ok {
+        r1 = rf(ctx, filter)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetReassignmentStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReassignmentStats'
+type Storage_GetReassignmentStats_Call struct {
+    *mock.Call
+}
+
+// GetReassignmentStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.AnalyticsFilter
+func (_e *MockStorage_Expecter) GetReassignmentStats(ctx interface{}, filter interface{}) *Storage_GetReassignmentStats_Call {
+    return &Storage_GetReassignmentStats_Call{Call: _e.mock.On("GetReassignmentStats", ctx, filter)}
+}
+
+func (_c *Storage_GetReassignmentStats_Call) Run(run func(ctx context.Context, filter entities.AnalyticsFilter)) *Storage_GetReassignmentStats_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.AnalyticsFilter))
+    })
+    return _c
+}
+
+func (_c *Storage_GetReassignmentStats_Call) Return(_a0 []entities.TeamReassignments, _a1 error) *Storage_GetReassignmentStats_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetReassignmentStats_Call) RunAndReturn(run func(context.Context, entities.AnalyticsFilter) ([]entities.TeamReassignments, error)) *Storage_GetReassignmentStats_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetReviewTimings provides a mock function with given fields: ctx, filter
+func (_m *MockStorage) GetReviewTimings(ctx context.Context, filter entities.AnalyticsFilter) (*entities.ReviewTimings, error) {
+    ret := _m.Called(ctx, filter)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetReviewTimings")
+    }
+
+    var r0 *entities.ReviewTimings
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) (*entities.ReviewTimings, error)); ok {
+        return rf(ctx, filter)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) *entities.ReviewTimings); ok {
+        r0 = rf(ctx, filter)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.ReviewTimings)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.AnalyticsFilter) error); ok {
+        r1 = rf(ctx, filter)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetReviewTimings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewTimings'
+type Storage_GetReviewTimings_Call struct {
+    *mock.Call
+}
+
+// GetReviewTimings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.AnalyticsFilter
+func (_e *MockStorage_Expecter) GetReviewTimings(ctx interface{}, filter interface{}) *Storage_GetReviewTimings_Call {
+    return &Storage_GetReviewTimings_Call{Call: _e.mock.On("GetReviewTimings", ctx, filter)}
+}
+
+func (_c *Storage_GetReviewTimings_Call) Run(run func(ctx context.Context, filter entities.AnalyticsFilter)) *Storage_GetReviewTimings_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.AnalyticsFilter))
+    })
+    return _c
+}
+
+func (_c *Storage_GetReviewTimings_Call) Return(_a0 *entities.ReviewTimings, _a1 error) *Storage_GetReviewTimings_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetReviewTimings_Call) RunAndReturn(run func(context.Context, entities.AnalyticsFilter) (*entities.ReviewTimings, error)) *Storage_GetReviewTimings_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *MockStorage) GetStats(ctx context.Context) (*entities.Stats, error) {
+    ret := _m.Called(ctx)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetStats")
+    }
+
+    var r0 *entities.Stats
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context) (*entities.Stats, error)); ok {
+        return rf(ctx)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context) *entities.Stats); ok {
+        r0 = rf(ctx)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.Stats)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+        r1 = rf(ctx)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type Storage_GetStats_Call struct {
+    *mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) GetStats(ctx interface{}) *Storage_GetStats_Call {
+    return &Storage_GetStats_Call{Call: _e.mock.On("GetStats", ctx)}
+}
+
+func (_c *Storage_GetStats_Call) Run(run func(ctx context.Context)) *Storage_GetStats_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context))
+    })
+    return _c
+}
+
+func (_c *Storage_GetStats_Call) Return(_a0 *entities.Stats, _a1 error) *Storage_GetStats_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetStats_Call) RunAndReturn(run func(context.Context) (*entities.Stats, error)) *Storage_GetStats_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetTeamByName provides a mock function with given fields: ctx, teamName
+func (_m *MockStorage) GetTeamByName(ctx context.Context, teamName string) (*entities.Team, error) {
+    ret := _m.Called(ctx, teamName)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetTeamByName")
+    }
+
+    var r0 *entities.Team
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.Team, error)); ok {
+        return rf(ctx, teamName)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.Team); ok {
+        r0 = rf(ctx, teamName)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.Team)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, teamName)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamByName'
+type Storage_GetTeamByName_Call struct {
+    *mock.Call
+}
+
+// GetTeamByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockStorage_Expecter) GetTeamByName(ctx interface{}, teamName interface{}) *Storage_GetTeamByName_Call {
+    return &Storage_GetTeamByName_Call{Call: _e.mock.On("GetTeamByName", ctx, teamName)}
+}
+
+func (_c *Storage_GetTeamByName_Call) Run(run func(ctx context.Context, teamName string)) *Storage_GetTeamByName_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetTeamByName_Call) Return(_a0 *entities.Team, _a1 error) *Storage_GetTeamByName_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetTeamByName_Call) RunAndReturn(run func(context.Context, string) (*entities.Team, error)) *Storage_GetTeamByName_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetTeamSettings provides a mock function with given fields: ctx, teamName
+func (_m *MockStorage) GetTeamSettings(ctx context.Context, teamName string) (*entities.TeamSettings, error) {
+    ret := _m.Called(ctx, teamName)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetTeamSettings")
+    }
+
+    var r0 *entities.TeamSettings
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.TeamSettings, error)); ok {
+        return rf(ctx, teamName)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.TeamSettings); ok {
+        r0 = rf(ctx, teamName)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.TeamSettings)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, teamName)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetTeamSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamSettings'
+type Storage_GetTeamSettings_Call struct {
+    *mock.Call
+}
+
+// GetTeamSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockStorage_Expecter) GetTeamSettings(ctx interface{}, teamName interface{}) *Storage_GetTeamSettings_Call {
+    return &Storage_GetTeamSettings_Call{Call: _e.mock.On("GetTeamSettings", ctx, teamName)}
+}
+
+func (_c *Storage_GetTeamSettings_Call) Run(run func(ctx context.Context, teamName string)) *Storage_GetTeamSettings_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetTeamSettings_Call) Return(_a0 *entities.TeamSettings, _a1 error) *Storage_GetTeamSettings_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetTeamSettings_Call) RunAndReturn(run func(context.Context, string) (*entities.TeamSettings, error)) *Storage_GetTeamSettings_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetUnavailability provides a mock function with given fields: ctx, periodID
+func (_m *MockStorage) GetUnavailability(ctx context.Context, periodID int64) (*entities.Unavailability, error) {
+    ret := _m.Called(ctx, periodID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetUnavailability")
+    }
+
+    var r0 *entities.Unavailability
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64) (*entities.Unavailability, error)); ok {
+        return rf(ctx, periodID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64) *entities.Unavailability); ok {
+        r0 = rf(ctx, periodID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.Unavailability)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+        r1 = rf(ctx, periodID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnavailability'
+type Storage_GetUnavailability_Call struct {
+    *mock.Call
+}
+
+// GetUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - periodID int64
+func (_e *MockStorage_Expecter) GetUnavailability(ctx interface{}, periodID interface{}) *Storage_GetUnavailability_Call {
+    return &Storage_GetUnavailability_Call{Call: _e.mock.On("GetUnavailability", ctx, periodID)}
+}
+
+func (_c *Storage_GetUnavailability_Call) Run(run func(ctx context.Context, periodID int64)) *Storage_GetUnavailability_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64))
+    })
+    return _c
+}
+
+func (_c *Storage_GetUnavailability_Call) Return(_a0 *entities.Unavailability, _a1 error) *Storage_GetUnavailability_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetUnavailability_Call) RunAndReturn(run func(context.Context, int64) (*entities.Unavailability, error)) *Storage_GetUnavailability_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) GetUser(ctx context.Context, userID string) (*entities.User, error) {
+    ret := _m.Called(ctx, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetUser")
+    }
+
+    var r0 *entities.User
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.User, error)); ok {
+        return rf(ctx, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.User); ok {
+        r0 = rf(ctx, userID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.User)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type Storage_GetUser_Call struct {
+    *mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStorage_Expecter) GetUser(ctx interface{}, userID interface{}) *Storage_GetUser_Call {
+    return &Storage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *Storage_GetUser_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetUser_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetUser_Call) Return(_a0 *entities.User, _a1 error) *Storage_GetUser_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetUser_Call) RunAndReturn(run func(context.Context, string) (*entities.User, error)) *Storage_GetUser_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetUserIDByExternalLogin provides a mock function with given fields: ctx, provider, login
+func (_m *MockStorage) GetUserIDByExternalLogin(ctx context.Context, provider entities.GitProvider, login string) (string, error) {
+    ret := _m.Called(ctx, provider, login)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetUserIDByExternalLogin")
+    }
+
+    var r0 string
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider, string) (string, error)); ok {
+        return rf(ctx, provider, login)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider, string) string); ok {
+        r0 = rf(ctx, provider, login)
+    } else {
+        r0 = ret.Get(0).(string)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.GitProvider, string) error); ok {
+        r1 = rf(ctx, provider, login)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetUserIDByExternalLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserIDByExternalLogin'
+type Storage_GetUserIDByExternalLogin_Call struct {
+    *mock.Call
+}
+
+// GetUserIDByExternalLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider entities.GitProvider
+//   - login string
+func (_e *MockStorage_Expecter) GetUserIDByExternalLogin(ctx interface{}, provider interface{}, login interface{}) *Storage_GetUserIDByExternalLogin_Call {
+    return &Storage_GetUserIDByExternalLogin_Call{Call: _e.mock.On("GetUserIDByExternalLogin", ctx, provider, login)}
+}
+
+func (_c *Storage_GetUserIDByExternalLogin_Call) Run(run func(ctx context.Context, provider entities.GitProvider, login string)) *Storage_GetUserIDByExternalLogin_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.GitProvider), args[2].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_GetUserIDByExternalLogin_Call) Return(_a0 string, _a1 error) *Storage_GetUserIDByExternalLogin_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetUserIDByExternalLogin_Call) RunAndReturn(run func(context.Context, entities.GitProvider, string) (string, error)) *Storage_GetUserIDByExternalLogin_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetUsersByTeam provides a mock function with given fields: ctx, teamName, activeOnly
+func (_m *MockStorage) GetUsersByTeam(ctx context.Context, teamName string, activeOnly bool) ([]*entities.User, error) {
+    ret := _m.Called(ctx, teamName, activeOnly)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetUsersByTeam")
+    }
+
+    var r0 []*entities.User
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, bool) ([]*entities.User, error)); ok {
+        return rf(ctx, teamName, activeOnly)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, bool) []*entities.User); ok {
+        r0 = rf(ctx, teamName, activeOnly)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.User)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+        r1 = rf(ctx, teamName, activeOnly)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetUsersByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByTeam'
+type Storage_GetUsersByTeam_Call struct {
+    *mock.Call
+}
+
+// GetUsersByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - activeOnly bool
+func (_e *MockStorage_Expecter) GetUsersByTeam(ctx interface{}, teamName interface{}, activeOnly interface{}) *Storage_GetUsersByTeam_Call {
+    return &Storage_GetUsersByTeam_Call{Call: _e.mock.On("GetUsersByTeam", ctx, teamName, activeOnly)}
+}
+
+func (_c *Storage_GetUsersByTeam_Call) Run(run func(ctx context.Context, teamName string, activeOnly bool)) *Storage_GetUsersByTeam_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(bool))
+    })
+    return _c
+}
+
+func (_c *Storage_GetUsersByTeam_Call) Return(_a0 []*entities.User, _a1 error) *Storage_GetUsersByTeam_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetUsersByTeam_Call) RunAndReturn(run func(context.Context, string, bool) ([]*entities.User, error)) *Storage_GetUsersByTeam_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockStorage) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*entities.WebhookSubscription, error) {
+    ret := _m.Called(ctx, subscriptionID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetWebhookSubscription")
+    }
+
+    var r0 *entities.WebhookSubscription
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64) (*entities.WebhookSubscription, error)); ok {
+        return rf(ctx, subscriptionID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64) *entities.WebhookSubscription); ok {
+        r0 = rf(ctx, subscriptionID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.WebhookSubscription)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+        r1 = rf(ctx, subscriptionID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type Storage_GetWebhookSubscription_Call struct {
+    *mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+func (_e *MockStorage_Expecter) GetWebhookSubscription(ctx interface{}, subscriptionID interface{}) *Storage_GetWebhookSubscription_Call {
+    return &Storage_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription", ctx, subscriptionID)}
+}
+
+func (_c *Storage_GetWebhookSubscription_Call) Run(run func(ctx context.Context, subscriptionID int64)) *Storage_GetWebhookSubscription_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64))
+    })
+    return _c
+}
+
+func (_c *Storage_GetWebhookSubscription_Call) Return(_a0 *entities.WebhookSubscription, _a1 error) *Storage_GetWebhookSubscription_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, int64) (*entities.WebhookSubscription, error)) *Storage_GetWebhookSubscription_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// GetWorkloadTrend provides a mock function with given fields: ctx, filter
+func (_m *MockStorage) GetWorkloadTrend(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.WorkloadPoint, error) {
+    ret := _m.Called(ctx, filter)
+
+    if len(ret) == 0 {
+        panic("no return value specified for GetWorkloadTrend")
+    }
+
+    var r0 []entities.WorkloadPoint
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) ([]entities.WorkloadPoint, error)); ok {
+        return rf(ctx, filter)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.AnalyticsFilter) []entities.WorkloadPoint); ok {
+        r0 = rf(ctx, filter)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.WorkloadPoint)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.AnalyticsFilter) error); ok {
+        r1 = rf(ctx, filter)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_GetWorkloadTrend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkloadTrend'
+type Storage_GetWorkloadTrend_Call struct {
+    *mock.Call
+}
+
+// GetWorkloadTrend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.AnalyticsFilter
+func (_e *MockStorage_Expecter) GetWorkloadTrend(ctx interface{}, filter interface{}) *Storage_GetWorkloadTrend_Call {
+    return &Storage_GetWorkloadTrend_Call{Call: _e.mock.On("GetWorkloadTrend", ctx, filter)}
+}
+
+func (_c *Storage_GetWorkloadTrend_Call) Run(run func(ctx context.Context, filter entities.AnalyticsFilter)) *Storage_GetWorkloadTrend_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.AnalyticsFilter))
+    })
+    return _c
+}
+
+func (_c *Storage_GetWorkloadTrend_Call) Return(_a0 []entities.WorkloadPoint, _a1 error) *Storage_GetWorkloadTrend_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_GetWorkloadTrend_Call) RunAndReturn(run func(context.Context, entities.AnalyticsFilter) ([]entities.WorkloadPoint, error)) *Storage_GetWorkloadTrend_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// IsUserAssignedToReviewer provides a mock function with given fields: ctx, prID, userID
+func (_m *MockStorage) IsUserAssignedToReviewer(ctx context.Context, prID string, userID string) (bool, error) {
+    ret := _m.Called(ctx, prID, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for IsUserAssignedToReviewer")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+        return rf(ctx, prID, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+        r0 = rf(ctx, prID, userID)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+        r1 = rf(ctx, prID, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_IsUserAssignedToReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsUserAssignedToReviewer'
+type Storage_IsUserAssignedToReviewer_Call struct {
+    *mock.Call
+}
+
+// IsUserAssignedToReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - userID string
+func (_e *MockStorage_Expecter) IsUserAssignedToReviewer(ctx interface{}, prID interface{}, userID interface{}) *Storage_IsUserAssignedToReviewer_Call {
+    return &Storage_IsUserAssignedToReviewer_Call{Call: _e.mock.On("IsUserAssignedToReviewer", ctx, prID, userID)}
+}
+
+func (_c *Storage_IsUserAssignedToReviewer_Call) Run(run func(ctx context.Context, prID string, userID string)) *Storage_IsUserAssignedToReviewer_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_IsUserAssignedToReviewer_Call) Return(_a0 bool, _a1 error) *Storage_IsUserAssignedToReviewer_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_IsUserAssignedToReviewer_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Storage_IsUserAssignedToReviewer_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListAPITokens provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) ListAPITokens(ctx context.Context, userID string) ([]*entities.APIToken, error) {
+    ret := _m.Called(ctx, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListAPITokens")
+    }
+
+    var r0 []*entities.APIToken
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.APIToken, error)); ok {
+        return rf(ctx, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.APIToken); ok {
+        r0 = rf(ctx, userID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.APIToken)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListAPITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPITokens'
+type Storage_ListAPITokens_Call struct {
+    *mock.Call
+}
+
+// ListAPITokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStorage_Expecter) ListAPITokens(ctx interface{}, userID interface{}) *Storage_ListAPITokens_Call {
+    return &Storage_ListAPITokens_Call{Call: _e.mock.On("ListAPITokens", ctx, userID)}
+}
+
+func (_c *Storage_ListAPITokens_Call) Run(run func(ctx context.Context, userID string)) *Storage_ListAPITokens_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_ListAPITokens_Call) Return(_a0 []*entities.APIToken, _a1 error) *Storage_ListAPITokens_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListAPITokens_Call) RunAndReturn(run func(context.Context, string) ([]*entities.APIToken, error)) *Storage_ListAPITokens_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListDueReviewDigests provides a mock function with given fields: ctx, now, defaultTime
+func (_m *MockStorage) ListDueReviewDigests(ctx context.Context, now time.Time, defaultTime string) ([]*entities.ReviewDigest, error) {
+    ret := _m.Called(ctx, now, defaultTime)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListDueReviewDigests")
+    }
+
+    var r0 []*entities.ReviewDigest
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) ([]*entities.ReviewDigest, error)); ok {
+        return rf(ctx, now, defaultTime)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) []*entities.ReviewDigest); ok {
+        r0 = rf(ctx, now, defaultTime)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.ReviewDigest)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+        r1 = rf(ctx, now, defaultTime)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListDueReviewDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueReviewDigests'
+type Storage_ListDueReviewDigests_Call struct {
+    *mock.Call
+}
+
+// ListDueReviewDigests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - defaultTime string
+func (_e *MockStorage_Expecter) ListDueReviewDigests(ctx interface{}, now interface{}, defaultTime interface{}) *Storage_ListDueReviewDigests_Call {
+    return &Storage_ListDueReviewDigests_Call{Call: _e.mock.On("ListDueReviewDigests", ctx, now, defaultTime)}
+}
+
+func (_c *Storage_ListDueReviewDigests_Call) Run(run func(ctx context.Context, now time.Time, defaultTime string)) *Storage_ListDueReviewDigests_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(time.Time), args[2].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_ListDueReviewDigests_Call) Return(_a0 []*entities.ReviewDigest, _a1 error) *Storage_ListDueReviewDigests_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListDueReviewDigests_Call) RunAndReturn(run func(context.Context, time.Time, string) ([]*entities.ReviewDigest, error)) *Storage_ListDueReviewDigests_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListExternalIdentities provides a mock function with given fields: ctx, provider
+func (_m *MockStorage) ListExternalIdentities(ctx context.Context, provider entities.GitProvider) ([]*entities.ExternalIdentity, error) {
+    ret := _m.Called(ctx, provider)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListExternalIdentities")
+    }
+
+    var r0 []*entities.ExternalIdentity
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider) ([]*entities.ExternalIdentity, error)); ok {
+        return rf(ctx, provider)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.GitProvider) []*entities.ExternalIdentity); ok {
+        r0 = rf(ctx, provider)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.ExternalIdentity)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.GitProvider) error); ok {
+        r1 = rf(ctx, provider)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListExternalIdentities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExternalIdentities'
+type Storage_ListExternalIdentities_Call struct {
+    *mock.Call
+}
+
+// ListExternalIdentities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider entities.GitProvider
+func (_e *MockStorage_Expecter) ListExternalIdentities(ctx interface{}, provider interface{}) *Storage_ListExternalIdentities_Call {
+    return &Storage_ListExternalIdentities_Call{Call: _e.mock.On("ListExternalIdentities", ctx, provider)}
+}
+
+func (_c *Storage_ListExternalIdentities_Call) Run(run func(ctx context.Context, provider entities.GitProvider)) *Storage_ListExternalIdentities_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.GitProvider))
+    })
+    return _c
+}
+
+func (_c *Storage_ListExternalIdentities_Call) Return(_a0 []*entities.ExternalIdentity, _a1 error) *Storage_ListExternalIdentities_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListExternalIdentities_Call) RunAndReturn(run func(context.Context, entities.GitProvider) ([]*entities.ExternalIdentity, error)) *Storage_ListExternalIdentities_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListOverdueReviews provides a mock function with given fields: ctx, teamName, now
+func (_m *MockStorage) ListOverdueReviews(ctx context.Context, teamName string, now time.Time) ([]*entities.OverdueReview, error) {
+    ret := _m.Called(ctx, teamName, now)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListOverdueReviews")
+    }
+
+    var r0 []*entities.OverdueReview
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*entities.OverdueReview, error)); ok {
+        return rf(ctx, teamName, now)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*entities.OverdueReview); ok {
+        r0 = rf(ctx, teamName, now)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.OverdueReview)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+        r1 = rf(ctx, teamName, now)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListOverdueReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverdueReviews'
+type Storage_ListOverdueReviews_Call struct {
+    *mock.Call
+}
+
+// ListOverdueReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - now time.Time
+func (_e *MockStorage_Expecter) ListOverdueReviews(ctx interface{}, teamName interface{}, now interface{}) *Storage_ListOverdueReviews_Call {
+    return &Storage_ListOverdueReviews_Call{Call: _e.mock.On("ListOverdueReviews", ctx, teamName, now)}
+}
+
+func (_c *Storage_ListOverdueReviews_Call) Run(run func(ctx context.Context, teamName string, now time.Time)) *Storage_ListOverdueReviews_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_ListOverdueReviews_Call) Return(_a0 []*entities.OverdueReview, _a1 error) *Storage_ListOverdueReviews_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListOverdueReviews_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]*entities.OverdueReview, error)) *Storage_ListOverdueReviews_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListPRs provides a mock function with given fields: ctx, filter
+func (_m *MockStorage) ListPRs(ctx context.Context, filter entities.PRListFilter) ([]*entities.PullRequest, error) {
+    ret := _m.Called(ctx, filter)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListPRs")
+    }
+
+    var r0 []*entities.PullRequest
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, entities.PRListFilter) ([]*entities.PullRequest, error)); ok {
+        return rf(ctx, filter)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, entities.PRListFilter) []*entities.PullRequest); ok {
+        r0 = rf(ctx, filter)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.PullRequest)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, entities.PRListFilter) error); ok {
+        r1 = rf(ctx, filter)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListPRs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPRs'
+type Storage_ListPRs_Call struct {
+    *mock.Call
+}
+
+// ListPRs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.PRListFilter
+func (_e *MockStorage_Expecter) ListPRs(ctx interface{}, filter interface{}) *Storage_ListPRs_Call {
+    return &Storage_ListPRs_Call{Call: _e.mock.On("ListPRs", ctx, filter)}
+}
+
+func (_c *Storage_ListPRs_Call) Run(run func(ctx context.Context, filter entities.PRListFilter)) *Storage_ListPRs_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(entities.PRListFilter))
+    })
+    return _c
+}
+
+func (_c *Storage_ListPRs_Call) Return(_a0 []*entities.PullRequest, _a1 error) *Storage_ListPRs_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListPRs_Call) RunAndReturn(run func(context.Context, entities.PRListFilter) ([]*entities.PullRequest, error)) *Storage_ListPRs_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListPendingReviewDigests provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) ListPendingReviewDigests(ctx context.Context, userID string) ([]*entities.ReviewDigest, error) {
+    ret := _m.Called(ctx, userID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListPendingReviewDigests")
+    }
+
+    var r0 []*entities.ReviewDigest
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entities.ReviewDigest, error)); ok {
+        return rf(ctx, userID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) []*entities.ReviewDigest); ok {
+        r0 = rf(ctx, userID)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.ReviewDigest)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, userID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListPendingReviewDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingReviewDigests'
+type Storage_ListPendingReviewDigests_Call struct {
+    *mock.Call
+}
+
+// ListPendingReviewDigests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStorage_Expecter) ListPendingReviewDigests(ctx interface{}, userID interface{}) *Storage_ListPendingReviewDigests_Call {
+    return &Storage_ListPendingReviewDigests_Call{Call: _e.mock.On("ListPendingReviewDigests", ctx, userID)}
+}
+
+func (_c *Storage_ListPendingReviewDigests_Call) Run(run func(ctx context.Context, userID string)) *Storage_ListPendingReviewDigests_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_ListPendingReviewDigests_Call) Return(_a0 []*entities.ReviewDigest, _a1 error) *Storage_ListPendingReviewDigests_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListPendingReviewDigests_Call) RunAndReturn(run func(context.Context, string) ([]*entities.ReviewDigest, error)) *Storage_ListPendingReviewDigests_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListUnavailability provides a mock function with given fields: ctx, userID, from
+func (_m *MockStorage) ListUnavailability(ctx context.Context, userID string, from time.Time) ([]*entities.Unavailability, error) {
+    ret := _m.Called(ctx, userID, from)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListUnavailability")
+    }
+
+    var r0 []*entities.Unavailability
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*entities.Unavailability, error)); ok {
+        return rf(ctx, userID, from)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*entities.Unavailability); ok {
+        r0 = rf(ctx, userID, from)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.Unavailability)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+        r1 = rf(ctx, userID, from)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnavailability'
+type Storage_ListUnavailability_Call struct {
+    *mock.Call
+}
+
+// ListUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - from time.Time
+func (_e *MockStorage_Expecter) ListUnavailability(ctx interface{}, userID interface{}, from interface{}) *Storage_ListUnavailability_Call {
+    return &Storage_ListUnavailability_Call{Call: _e.mock.On("ListUnavailability", ctx, userID, from)}
+}
+
+func (_c *Storage_ListUnavailability_Call) Run(run func(ctx context.Context, userID string, from time.Time)) *Storage_ListUnavailability_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_ListUnavailability_Call) Return(_a0 []*entities.Unavailability, _a1 error) *Storage_ListUnavailability_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListUnavailability_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]*entities.Unavailability, error)) *Storage_ListUnavailability_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, subscriptionID, status
+func (_m *MockStorage) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status entities.DeliveryStatus) ([]*entities.WebhookDelivery, error) {
+    ret := _m.Called(ctx, subscriptionID, status)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListWebhookDeliveries")
+    }
+
+    var r0 []*entities.WebhookDelivery
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64, entities.DeliveryStatus) ([]*entities.WebhookDelivery, error)); ok {
+        return rf(ctx, subscriptionID, status)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64, entities.DeliveryStatus) []*entities.WebhookDelivery); ok {
+        r0 = rf(ctx, subscriptionID, status)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.WebhookDelivery)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64, entities.DeliveryStatus) error); ok {
+        r1 = rf(ctx, subscriptionID, status)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type Storage_ListWebhookDeliveries_Call struct {
+    *mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+//   - status entities.DeliveryStatus
+func (_e *MockStorage_Expecter) ListWebhookDeliveries(ctx interface{}, subscriptionID interface{}, status interface{}) *Storage_ListWebhookDeliveries_Call {
+    return &Storage_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, subscriptionID, status)}
+}
+
+func (_c *Storage_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, subscriptionID int64, status entities.DeliveryStatus)) *Storage_ListWebhookDeliveries_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64), args[2].(entities.DeliveryStatus))
+    })
+    return _c
+}
+
+func (_c *Storage_ListWebhookDeliveries_Call) Return(_a0 []*entities.WebhookDelivery, _a1 error) *Storage_ListWebhookDeliveries_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, int64, entities.DeliveryStatus) ([]*entities.WebhookDelivery, error)) *Storage_ListWebhookDeliveries_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ListWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *MockStorage) ListWebhookSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+    ret := _m.Called(ctx)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ListWebhookSubscriptions")
+    }
+
+    var r0 []*entities.WebhookSubscription
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context) ([]*entities.WebhookSubscription, error)); ok {
+        return rf(ctx)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context) []*entities.WebhookSubscription); ok {
+        r0 = rf(ctx)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]*entities.WebhookSubscription)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+        r1 = rf(ctx)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_ListWebhookSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookSubscriptions'
+type Storage_ListWebhookSubscriptions_Call struct {
+    *mock.Call
+}
+
+// ListWebhookSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) ListWebhookSubscriptions(ctx interface{}) *Storage_ListWebhookSubscriptions_Call {
+    return &Storage_ListWebhookSubscriptions_Call{Call: _e.mock.On("ListWebhookSubscriptions", ctx)}
+}
+
+func (_c *Storage_ListWebhookSubscriptions_Call) Run(run func(ctx context.Context)) *Storage_ListWebhookSubscriptions_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context))
+    })
+    return _c
+}
+
+func (_c *Storage_ListWebhookSubscriptions_Call) Return(_a0 []*entities.WebhookSubscription, _a1 error) *Storage_ListWebhookSubscriptions_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_ListWebhookSubscriptions_Call) RunAndReturn(run func(context.Context) ([]*entities.WebhookSubscription, error)) *Storage_ListWebhookSubscriptions_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// MarkWebhookDelivered provides a mock function with given fields: ctx, deliveryID, at
+func (_m *MockStorage) MarkWebhookDelivered(ctx context.Context, deliveryID int64, at time.Time) error {
+    ret := _m.Called(ctx, deliveryID, at)
+
+    if len(ret) == 0 {
+        panic("no return value specified for MarkWebhookDelivered")
+    }
+
+    var r0 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+        r0 = rf(ctx, deliveryID, at)
+    } else {
+        r0 = ret.Error(0)
+    }
+
+    return r0
+}
+
+// Storage_MarkWebhookDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookDelivered'
+type Storage_MarkWebhookDelivered_Call struct {
+    *mock.Call
+}
+
+// MarkWebhookDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveryID int64
+//   - at time.Time
+func (_e *MockStorage_Expecter) MarkWebhookDelivered(ctx interface{}, deliveryID interface{}, at interface{}) *Storage_MarkWebhookDelivered_Call {
+    return &Storage_MarkWebhookDelivered_Call{Call: _e.mock.On("MarkWebhookDelivered", ctx, deliveryID, at)}
+}
+
+func (_c *Storage_MarkWebhookDelivered_Call) Run(run func(ctx context.Context, deliveryID int64, at time.Time)) *Storage_MarkWebhookDelivered_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_MarkWebhookDelivered_Call) Return(_a0 error) *Storage_MarkWebhookDelivered_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_MarkWebhookDelivered_Call) RunAndReturn(run func(context.Context, int64, time.Time) error) *Storage_MarkWebhookDelivered_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// MarkWebhookFailed provides a mock function with given fields: ctx, deliveryID, lastError, nextAttemptAt
+func (_m *MockStorage) MarkWebhookFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt *time.Time) error {
+    ret := _m.Called(ctx, deliveryID, lastError, nextAttemptAt)
+
+    if len(ret) == 0 {
+        panic("no return value specified for MarkWebhookFailed")
+    }
+
+    var r0 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64, string, *time.Time) error); ok {
+        r0 = rf(ctx, deliveryID, lastError, nextAttemptAt)
+    } else {
+        r0 = ret.Error(0)
+    }
+
+    return r0
+}
+
+// Storage_MarkWebhookFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookFailed'
+type Storage_MarkWebhookFailed_Call struct {
+    *mock.Call
+}
+
+// MarkWebhookFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveryID int64
+//   - lastError string
+//   - nextAttemptAt *time.Time
+func (_e *MockStorage_Expecter) MarkWebhookFailed(ctx interface{}, deliveryID interface{}, lastError interface{}, nextAttemptAt interface{}) *Storage_MarkWebhookFailed_Call {
+    return &Storage_MarkWebhookFailed_Call{Call: _e.mock.On("MarkWebhookFailed", ctx, deliveryID, lastError, nextAttemptAt)}
+}
+
+func (_c *Storage_MarkWebhookFailed_Call) Run(run func(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt *time.Time)) *Storage_MarkWebhookFailed_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(*time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_MarkWebhookFailed_Call) Return(_a0 error) *Storage_MarkWebhookFailed_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_MarkWebhookFailed_Call) RunAndReturn(run func(context.Context, int64, string, *time.Time) error) *Storage_MarkWebhookFailed_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// MergePR provides a mock function with given fields: ctx, prID, mergedAt
+func (_m *MockStorage) MergePR(ctx context.Context, prID string, mergedAt time.Time) (*entities.PullRequest, error) {
+    ret := _m.Called(ctx, prID, mergedAt)
+
+    if len(ret) == 0 {
+        panic("no return value specified for MergePR")
+    }
+
+    var r0 *entities.PullRequest
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entities.PullRequest, error)); ok {
+        return rf(ctx, prID, mergedAt)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *entities.PullRequest); ok {
+        r0 = rf(ctx, prID, mergedAt)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.PullRequest)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+        r1 = rf(ctx, prID, mergedAt)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
+type Storage_MergePR_Call struct {
+    *mock.Call
+}
+
+// MergePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - mergedAt time.Time
+func (_e *MockStorage_Expecter) MergePR(ctx interface{}, prID interface{}, mergedAt interface{}) *Storage_MergePR_Call {
+    return &Storage_MergePR_Call{Call: _e.mock.On("MergePR", ctx, prID, mergedAt)}
+}
+
+func (_c *Storage_MergePR_Call) Run(run func(ctx context.Context, prID string, mergedAt time.Time)) *Storage_MergePR_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_MergePR_Call) Return(_a0 *entities.PullRequest, _a1 error) *Storage_MergePR_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_MergePR_Call) RunAndReturn(run func(context.Context, string, time.Time) (*entities.PullRequest, error)) *Storage_MergePR_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// MoveTeamMember provides a mock function with given fields: ctx, userID, fromTeam, toTeam, picker
+func (_m *MockStorage) MoveTeamMember(ctx context.Context, userID string, fromTeam string, toTeam string, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error) {
+    ret := _m.Called(ctx, userID, fromTeam, toTeam, picker)
+
+    if len(ret) == 0 {
+        panic("no return value specified for MoveTeamMember")
+    }
+
+    var r0 []entities.PRReassignmentInfo
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, string, string, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)); ok {
+        return rf(ctx, userID, fromTeam, toTeam, picker)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, string, string, func(teamName string) entities.ReviewerPicker) []entities.PRReassignmentInfo); ok {
+        r0 = rf(ctx, userID, fromTeam, toTeam, picker)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.PRReassignmentInfo)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, string, string, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, userID, fromTeam, toTeam, picker)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_MoveTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTeamMember'
+type Storage_MoveTeamMember_Call struct {
+    *mock.Call
+}
+
+// MoveTeamMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - fromTeam string
+//   - toTeam string
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) MoveTeamMember(ctx interface{}, userID interface{}, fromTeam interface{}, toTeam interface{}, picker interface{}) *Storage_MoveTeamMember_Call {
+    return &Storage_MoveTeamMember_Call{Call: _e.mock.On("MoveTeamMember", ctx, userID, fromTeam, toTeam, picker)}
+}
+
+func (_c *Storage_MoveTeamMember_Call) Run(run func(ctx context.Context, userID string, fromTeam string, toTeam string, picker func(teamName string) entities.ReviewerPicker)) *Storage_MoveTeamMember_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(func(teamName string) entities.ReviewerPicker))
+    })
+    return _c
+}
+
+func (_c *Storage_MoveTeamMember_Call) Return(_a0 []entities.PRReassignmentInfo, _a1 error) *Storage_MoveTeamMember_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_MoveTeamMember_Call) RunAndReturn(run func(context.Context, string, string, string, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)) *Storage_MoveTeamMember_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// PRExists provides a mock function with given fields: ctx, prID
+func (_m *MockStorage) PRExists(ctx context.Context, prID string) (bool, error) {
+    ret := _m.Called(ctx, prID)
+
+    if len(ret) == 0 {
+        panic("no return value specified for PRExists")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+        return rf(ctx, prID)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+        r0 = rf(ctx, prID)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, prID)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_PRExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PRExists'
+type Storage_PRExists_Call struct {
+    *mock.Call
+}
+
+// PRExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockStorage_Expecter) PRExists(ctx interface{}, prID interface{}) *Storage_PRExists_Call {
+    return &Storage_PRExists_Call{Call: _e.mock.On("PRExists", ctx, prID)}
+}
+
+func (_c *Storage_PRExists_Call) Run(run func(ctx context.Context, prID string)) *Storage_PRExists_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_PRExists_Call) Return(_a0 bool, _a1 error) *Storage_PRExists_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_PRExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Storage_PRExists_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// ReassignReviewer provides a mock function with given fields: ctx, prID, oldUserID, newUserID, sourceTeam, minReviewers
+func (_m *MockStorage) ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string, sourceTeam string, minReviewers int) error {
+    ret := _m.Called(ctx, prID, oldUserID, newUserID, sourceTeam, minReviewers)
+
+    if len(ret) == 0 {
+        panic("no return value specified for ReassignReviewer")
+    }
+
+    var r0 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) error); ok {
+        r0 = rf(ctx, prID, oldUserID, newUserID, sourceTeam, minReviewers)
+    } else {
+        r0 = ret.Error(0)
+    }
+
+    return r0
+}
+
+// Storage_ReassignReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignReviewer'
+type Storage_ReassignReviewer_Call struct {
+    *mock.Call
+}
+
+// ReassignReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - oldUserID string
+//   - newUserID string
+//   - sourceTeam string
+//   - minReviewers int
+func (_e *MockStorage_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldUserID interface{}, newUserID interface{}, sourceTeam interface{}, minReviewers interface{}) *Storage_ReassignReviewer_Call {
+    return &Storage_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldUserID, newUserID, sourceTeam, minReviewers)}
+}
+
+func (_c *Storage_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldUserID string, newUserID string, sourceTeam string, minReviewers int)) *Storage_ReassignReviewer_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(int))
+    })
+    return _c
+}
+
+func (_c *Storage_ReassignReviewer_Call) Return(_a0 error) *Storage_ReassignReviewer_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_ReassignReviewer_Call) RunAndReturn(run func(context.Context, string, string, string, string, int) error) *Storage_ReassignReviewer_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// RemoveTeamMembers provides a mock function with given fields: ctx, teamName, userIDs, picker
+func (_m *MockStorage) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error) {
+    ret := _m.Called(ctx, teamName, userIDs, picker)
+
+    if len(ret) == 0 {
+        panic("no return value specified for RemoveTeamMembers")
+    }
+
+    var r0 []entities.PRReassignmentInfo
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)); ok {
+        return rf(ctx, teamName, userIDs, picker)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) []entities.PRReassignmentInfo); ok {
+        r0 = rf(ctx, teamName, userIDs, picker)
+    } else {
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.PRReassignmentInfo)
+        }
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, teamName, userIDs, picker)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_RemoveTeamMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTeamMembers'
+type Storage_RemoveTeamMembers_Call struct {
+    *mock.Call
+}
+
+// RemoveTeamMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - userIDs []string
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) RemoveTeamMembers(ctx interface{}, teamName interface{}, userIDs interface{}, picker interface{}) *Storage_RemoveTeamMembers_Call {
+    return &Storage_RemoveTeamMembers_Call{Call: _e.mock.On("RemoveTeamMembers", ctx, teamName, userIDs, picker)}
+}
+
+func (_c *Storage_RemoveTeamMembers_Call) Run(run func(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker)) *Storage_RemoveTeamMembers_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(func(teamName string) entities.ReviewerPicker))
+    })
+    return _c
+}
+
+func (_c *Storage_RemoveTeamMembers_Call) Return(_a0 []entities.PRReassignmentInfo, _a1 error) *Storage_RemoveTeamMembers_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_RemoveTeamMembers_Call) RunAndReturn(run func(context.Context, string, []string, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)) *Storage_RemoveTeamMembers_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// RenameTeam provides a mock function with given fields: ctx, oldName, newName
+func (_m *MockStorage) RenameTeam(ctx context.Context, oldName string, newName string) (bool, error) {
+    ret := _m.Called(ctx, oldName, newName)
+
+    if len(ret) == 0 {
+        panic("no return value specified for RenameTeam")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+        return rf(ctx, oldName, newName)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+        r0 = rf(ctx, oldName, newName)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+        r1 = rf(ctx, oldName, newName)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_RenameTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameTeam'
+type Storage_RenameTeam_Call struct {
+    *mock.Call
+}
+
+// RenameTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldName string
+//   - newName string
+func (_e *MockStorage_Expecter) RenameTeam(ctx interface{}, oldName interface{}, newName interface{}) *Storage_RenameTeam_Call {
+    return &Storage_RenameTeam_Call{Call: _e.mock.On("RenameTeam", ctx, oldName, newName)}
+}
+
+func (_c *Storage_RenameTeam_Call) Run(run func(ctx context.Context, oldName string, newName string)) *Storage_RenameTeam_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(string))
+    })
+    return _c
+}
+
+func (_c *Storage_RenameTeam_Call) Return(_a0 bool, _a1 error) *Storage_RenameTeam_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_RenameTeam_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Storage_RenameTeam_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// RequeueWebhookDelivery provides a mock function with given fields: ctx, deliveryID, at
+func (_m *MockStorage) RequeueWebhookDelivery(ctx context.Context, deliveryID int64, at time.Time) (bool, error) {
+    ret := _m.Called(ctx, deliveryID, at)
+
+    if len(ret) == 0 {
+        panic("no return value specified for RequeueWebhookDelivery")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (bool, error)); ok {
+        return rf(ctx, deliveryID, at)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) bool); ok {
+        r0 = rf(ctx, deliveryID, at)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+        r1 = rf(ctx, deliveryID, at)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_RequeueWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueWebhookDelivery'
+type Storage_RequeueWebhookDelivery_Call struct {
+    *mock.Call
+}
+
+// RequeueWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveryID int64
+//   - at time.Time
+func (_e *MockStorage_Expecter) RequeueWebhookDelivery(ctx interface{}, deliveryID interface{}, at interface{}) *Storage_RequeueWebhookDelivery_Call {
+    return &Storage_RequeueWebhookDelivery_Call{Call: _e.mock.On("RequeueWebhookDelivery", ctx, deliveryID, at)}
+}
+
+func (_c *Storage_RequeueWebhookDelivery_Call) Run(run func(ctx context.Context, deliveryID int64, at time.Time)) *Storage_RequeueWebhookDelivery_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_RequeueWebhookDelivery_Call) Return(_a0 bool, _a1 error) *Storage_RequeueWebhookDelivery_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_RequeueWebhookDelivery_Call) RunAndReturn(run func(context.Context, int64, time.Time) (bool, error)) *Storage_RequeueWebhookDelivery_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// RevokeAPIToken provides a mock function with given fields: ctx, tokenID, at
+func (_m *MockStorage) RevokeAPIToken(ctx context.Context, tokenID int64, at time.Time) (bool, error) {
+    ret := _m.Called(ctx, tokenID, at)
+
+    if len(ret) == 0 {
+        panic("no return value specified for RevokeAPIToken")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (bool, error)); ok {
+        return rf(ctx, tokenID, at)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) bool); ok {
+        r0 = rf(ctx, tokenID, at)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+        r1 = rf(ctx, tokenID, at)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_RevokeAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIToken'
+type Storage_RevokeAPIToken_Call struct {
+    *mock.Call
+}
+
+// RevokeAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID int64
+//   - at time.Time
+func (_e *MockStorage_Expecter) RevokeAPIToken(ctx interface{}, tokenID interface{}, at interface{}) *Storage_RevokeAPIToken_Call {
+    return &Storage_RevokeAPIToken_Call{Call: _e.mock.On("RevokeAPIToken", ctx, tokenID, at)}
+}
+
+func (_c *Storage_RevokeAPIToken_Call) Run(run func(ctx context.Context, tokenID int64, at time.Time)) *Storage_RevokeAPIToken_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_RevokeAPIToken_Call) Return(_a0 bool, _a1 error) *Storage_RevokeAPIToken_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_RevokeAPIToken_Call) RunAndReturn(run func(context.Context, int64, time.Time) (bool, error)) *Storage_RevokeAPIToken_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// SetDigestSentAt provides a mock function with given fields: ctx, userID, prev, sentAt
+func (_m *MockStorage) SetDigestSentAt(ctx context.Context, userID string, prev *time.Time, sentAt *time.Time) (bool, error) {
+    ret := _m.Called(ctx, userID, prev, sentAt)
+
+    if len(ret) == 0 {
+        panic("no return value specified for SetDigestSentAt")
+    }
+
+    var r0 bool
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) (bool, error)); ok {
+        return rf(ctx, userID, prev, sentAt)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) bool); ok {
+        r0 = rf(ctx, userID, prev, sentAt)
+    } else {
+        r0 = ret.Get(0).(bool)
+    }
+
+    if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+        r1 = rf(ctx, userID, prev, sentAt)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
+}
+
+// Storage_SetDigestSentAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestSentAt'
+type Storage_SetDigestSentAt_Call struct {
+    *mock.Call
+}
+
+// SetDigestSentAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - prev *time.Time
+//   - sentAt *time.Time
+func (_e *MockStorage_Expecter) SetDigestSentAt(ctx interface{}, userID interface{}, prev interface{}, sentAt interface{}) *Storage_SetDigestSentAt_Call {
+    return &Storage_SetDigestSentAt_Call{Call: _e.mock.On("SetDigestSentAt", ctx, userID, prev, sentAt)}
+}
+
+func (_c *Storage_SetDigestSentAt_Call) Run(run func(ctx context.Context, userID string, prev *time.Time, sentAt *time.Time)) *Storage_SetDigestSentAt_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(string), args[2].(*time.Time), args[3].(*time.Time))
+    })
+    return _c
+}
+
+func (_c *Storage_SetDigestSentAt_Call) Return(_a0 bool, _a1 error) *Storage_SetDigestSentAt_Call {
+    _c.Call.Return(_a0, _a1)
+    return _c
+}
+
+func (_c *Storage_SetDigestSentAt_Call) RunAndReturn(run func(context.Context, string, *time.Time, *time.Time) (bool, error)) *Storage_SetDigestSentAt_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// SetExternalIdentity provides a mock function with given fields: ctx, identity
+func (_m *MockStorage) SetExternalIdentity(ctx context.Context, identity *entities.ExternalIdentity) error {
+    ret := _m.Called(ctx, identity)
+
+    if len(ret) == 0 {
+        panic("no return value specified for SetExternalIdentity")
+    }
+
+    var r0 error
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.ExternalIdentity) error); ok {
+        r0 = rf(ctx, identity)
+    } else {
+        r0 = ret.Error(0)
+    }
+
+    return r0
+}
+
+// Storage_SetExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetExternalIdentity'
+type Storage_SetExternalIdentity_Call struct {
+    *mock.Call
+}
+
+// SetExternalIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *entities.ExternalIdentity
+func (_e *MockStorage_Expecter) SetExternalIdentity(ctx interface{}, identity interface{}) *Storage_SetExternalIdentity_Call {
+    return &Storage_SetExternalIdentity_Call{Call: _e.mock.On("SetExternalIdentity", ctx, identity)}
+}
+
+func (_c *Storage_SetExternalIdentity_Call) Run(run func(ctx context.Context, identity *entities.ExternalIdentity)) *Storage_SetExternalIdentity_Call {
+    _c.Call.Run(func(args mock.Arguments) {
+        run(args[0].(context.Context), args[1].(*entities.ExternalIdentity))
+    })
+    return _c
+}
+
+func (_c *Storage_SetExternalIdentity_Call) Return(_a0 error) *Storage_SetExternalIdentity_Call {
+    _c.Call.Return(_a0)
+    return _c
+}
+
+func (_c *Storage_SetExternalIdentity_Call) RunAndReturn(run func(context.Context, *entities.ExternalIdentity) error) *Storage_SetExternalIdentity_Call {
+    _c.Call.Return(run)
+    return _c
+}
+
+// SetUserActiveStatus provides a mock function with given fields: ctx, userID, isActive
+func (_m *MockStorage) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*entities.User, error) {
+    ret := _m.Called(ctx, userID, isActive)
+
+    if len(ret) == 0 {
+        panic("no return value specified for SetUserActiveStatus")
     }
 
-    var r0 *entities.Team
+    var r0 *entities.User
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.Team, error)); ok {
-        return rf(ctx, teamName)
+    if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*entities.User, error)); ok {
+        return rf(ctx, userID, isActive)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.Team); ok {
-        r0 = rf(ctx, teamName)
+    if rf, ok := ret.Get(0).(func(context.Context, string, bool) *entities.User); ok {
+        r0 = rf(ctx, userID, isActive)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.Team)
+            r0 = ret.Get(0).(*entities.User)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, teamName)
+    if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+        r1 = rf(ctx, userID, isActive)
     } else {
         r1 = ret.Error(1)
     }
@@ -384,58 +3228,59 @@ func (_m *MockStorage) GetTeamByName(ctx context.Context, teamName string) (*ent
     return r0, r1
 }
 
-// Storage_GetTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamByName'
-type Storage_GetTeamByName_Call struct {
+// Storage_SetUserActiveStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserActiveStatus'
+type Storage_SetUserActiveStatus_Call struct {
     *mock.Call
 }
 
-// GetTeamByName is a helper method to define mock.On call
+// SetUserActiveStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *MockStorage_Expecter) GetTeamByName(ctx interface{}, teamName interface{}) *Storage_GetTeamByName_Call {
-    return &Storage_GetTeamByName_Call{Call: _e.mock.On("GetTeamByName", ctx, teamName)}
+//   - userID string
+//   - isActive bool
+func (_e *MockStorage_Expecter) SetUserActiveStatus(ctx interface{}, userID interface{}, isActive interface{}) *Storage_SetUserActiveStatus_Call {
+    return &Storage_SetUserActiveStatus_Call{Call: _e.mock.On("SetUserActiveStatus", ctx, userID, isActive)}
 }
 
-func (_c *Storage_GetTeamByName_Call) Run(run func(ctx context.Context, teamName string)) *Storage_GetTeamByName_Call {
+func (_c *Storage_SetUserActiveStatus_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *Storage_SetUserActiveStatus_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(string), args[2].(bool))
     })
     return _c
 }
 
-func (_c *Storage_GetTeamByName_Call) Return(_a0 *entities.Team, _a1 error) *Storage_GetTeamByName_Call {
+func (_c *Storage_SetUserActiveStatus_Call) Return(_a0 *entities.User, _a1 error) *Storage_SetUserActiveStatus_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetTeamByName_Call) RunAndReturn(run func(context.Context, string) (*entities.Team, error)) *Storage_GetTeamByName_Call {
+func (_c *Storage_SetUserActiveStatus_Call) RunAndReturn(run func(context.Context, string, bool) (*entities.User, error)) *Storage_SetUserActiveStatus_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetUser provides a mock function with given fields: ctx, userID
-func (_m *MockStorage) GetUser(ctx context.Context, userID string) (*entities.User, error) {
-    ret := _m.Called(ctx, userID)
+// SetUserReviewCapacity provides a mock function with given fields: ctx, userID, maxOpenReviews
+func (_m *MockStorage) SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*entities.User, error) {
+    ret := _m.Called(ctx, userID, maxOpenReviews)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetUser")
+        panic("no return value specified for SetUserReviewCapacity")
     }
 
     var r0 *entities.User
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.User, error)); ok {
-        return rf(ctx, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, *int) (*entities.User, error)); ok {
+        return rf(ctx, userID, maxOpenReviews)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) *entities.User); ok {
-        r0 = rf(ctx, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, *int) *entities.User); ok {
+        r0 = rf(ctx, userID, maxOpenReviews)
     } else {
         if ret.Get(0) != nil {
             r0 = ret.Get(0).(*entities.User)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, userID)
+    if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+        r1 = rf(ctx, userID, maxOpenReviews)
     } else {
         r1 = ret.Error(1)
     }
@@ -443,58 +3288,59 @@ func (_m *MockStorage) GetUser(ctx context.Context, userID string) (*entities.Us
     return r0, r1
 }
 
-// Storage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type Storage_GetUser_Call struct {
+// Storage_SetUserReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserReviewCapacity'
+type Storage_SetUserReviewCapacity_Call struct {
     *mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// SetUserReviewCapacity is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *MockStorage_Expecter) GetUser(ctx interface{}, userID interface{}) *Storage_GetUser_Call {
-    return &Storage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+//   - maxOpenReviews *int
+func (_e *MockStorage_Expecter) SetUserReviewCapacity(ctx interface{}, userID interface{}, maxOpenReviews interface{}) *Storage_SetUserReviewCapacity_Call {
+    return &Storage_SetUserReviewCapacity_Call{Call: _e.mock.On("SetUserReviewCapacity", ctx, userID, maxOpenReviews)}
 }
 
-func (_c *Storage_GetUser_Call) Run(run func(ctx context.Context, userID string)) *Storage_GetUser_Call {
+func (_c *Storage_SetUserReviewCapacity_Call) Run(run func(ctx context.Context, userID string, maxOpenReviews *int)) *Storage_SetUserReviewCapacity_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(string), args[2].(*int))
     })
     return _c
 }
 
-func (_c *Storage_GetUser_Call) Return(_a0 *entities.User, _a1 error) *Storage_GetUser_Call {
+func (_c *Storage_SetUserReviewCapacity_Call) Return(_a0 *entities.User, _a1 error) *Storage_SetUserReviewCapacity_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetUser_Call) RunAndReturn(run func(context.Context, string) (*entities.User, error)) *Storage_GetUser_Call {
+func (_c *Storage_SetUserReviewCapacity_Call) RunAndReturn(run func(context.Context, string, *int) (*entities.User, error)) *Storage_SetUserReviewCapacity_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// GetUsersByTeam provides a mock function with given fields: ctx, teamName, activeOnly
-func (_m *MockStorage) GetUsersByTeam(ctx context.Context, teamName string, activeOnly bool) ([]*entities.User, error) {
-    ret := _m.Called(ctx, teamName, activeOnly)
+// SetUserSkills provides a mock function with given fields: ctx, userID, skills
+func (_m *MockStorage) SetUserSkills(ctx context.Context, userID string, skills []string) (*entities.User, error) {
+    ret := _m.Called(ctx, userID, skills)
 
     if len(ret) == 0 {
-        panic("no return value specified for GetUsersByTeam")
+        panic("no return value specified for SetUserSkills")
     }
 
-    var r0 []*entities.User
+    var r0 *entities.User
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, bool) ([]*entities.User, error)); ok {
-        return rf(ctx, teamName, activeOnly)
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*entities.User, error)); ok {
+        return rf(ctx, userID, skills)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string, bool) []*entities.User); ok {
-        r0 = rf(ctx, teamName, activeOnly)
+    if rf, ok := ret.Get(0).(func(context.Context, string, []string) *entities.User); ok {
+        r0 = rf(ctx, userID, skills)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).([]*entities.User)
+            r0 = ret.Get(0).(*entities.User)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-        r1 = rf(ctx, teamName, activeOnly)
+    if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+        r1 = rf(ctx, userID, skills)
     } else {
         r1 = ret.Error(1)
     }
@@ -502,57 +3348,59 @@ func (_m *MockStorage) GetUsersByTeam(ctx context.Context, teamName string, acti
     return r0, r1
 }
 
-// Storage_GetUsersByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByTeam'
-type Storage_GetUsersByTeam_Call struct {
+// Storage_SetUserSkills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserSkills'
+type Storage_SetUserSkills_Call struct {
     *mock.Call
 }
 
-// GetUsersByTeam is a helper method to define mock.On call
+// SetUserSkills is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-//   - activeOnly bool
-func (_e *MockStorage_Expecter) GetUsersByTeam(ctx interface{}, teamName interface{}, activeOnly interface{}) *Storage_GetUsersByTeam_Call {
-    return &Storage_GetUsersByTeam_Call{Call: _e.mock.On("GetUsersByTeam", ctx, teamName, activeOnly)}
+//   - userID string
+//   - skills []string
+func (_e *MockStorage_Expecter) SetUserSkills(ctx interface{}, userID interface{}, skills interface{}) *Storage_SetUserSkills_Call {
+    return &Storage_SetUserSkills_Call{Call: _e.mock.On("SetUserSkills", ctx, userID, skills)}
 }
 
-func (_c *Storage_GetUsersByTeam_Call) Run(run func(ctx context.Context, teamName string, activeOnly bool)) *Storage_GetUsersByTeam_Call {
+func (_c *Storage_SetUserSkills_Call) Run(run func(ctx context.Context, userID string, skills []string)) *Storage_SetUserSkills_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].(bool))
+        run(args[0].(context.Context), args[1].(string), args[2].([]string))
     })
     return _c
 }
 
-func (_c *Storage_GetUsersByTeam_Call) Return(_a0 []*entities.User, _a1 error) *Storage_GetUsersByTeam_Call {
+func (_c *Storage_SetUserSkills_Call) Return(_a0 *entities.User, _a1 error) *Storage_SetUserSkills_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_GetUsersByTeam_Call) RunAndReturn(run func(context.Context, string, bool) ([]*entities.User, error)) *Storage_GetUsersByTeam_Call {
+func (_c *Storage_SetUserSkills_Call) RunAndReturn(run func(context.Context, string, []string) (*entities.User, error)) *Storage_SetUserSkills_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// IsUserAssignedToReviewer provides a mock function with given fields: ctx, prID, userID
-func (_m *MockStorage) IsUserAssignedToReviewer(ctx context.Context, prID string, userID string) (bool, error) {
-    ret := _m.Called(ctx, prID, userID)
+// StartUnavailabilityPeriods provides a mock function with given fields: ctx, now, limit, picker
+func (_m *MockStorage) StartUnavailabilityPeriods(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error) {
+    ret := _m.Called(ctx, now, limit, picker)
 
     if len(ret) == 0 {
-        panic("no return value specified for IsUserAssignedToReviewer")
+        panic("no return value specified for StartUnavailabilityPeriods")
     }
 
-    var r0 bool
+    var r0 []entities.PRReassignmentInfo
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-        return rf(ctx, prID, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)); ok {
+        return rf(ctx, now, limit, picker)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-        r0 = rf(ctx, prID, userID)
+    if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) []entities.PRReassignmentInfo); ok {
+        r0 = rf(ctx, now, limit, picker)
     } else {
-        r0 = ret.Get(0).(bool)
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).([]entities.PRReassignmentInfo)
+        }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-        r1 = rf(ctx, prID, userID)
+    if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) error); ok {
+        r1 = rf(ctx, now, limit, picker)
     } else {
         r1 = ret.Error(1)
     }
@@ -560,59 +3408,58 @@ func (_m *MockStorage) IsUserAssignedToReviewer(ctx context.Context, prID string
     return r0, r1
 }
 
-// Storage_IsUserAssignedToReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsUserAssignedToReviewer'
-type Storage_IsUserAssignedToReviewer_Call struct {
+// Storage_StartUnavailabilityPeriods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartUnavailabilityPeriods'
+type Storage_StartUnavailabilityPeriods_Call struct {
     *mock.Call
 }
 
-// IsUserAssignedToReviewer is a helper method to define mock.On call
+// StartUnavailabilityPeriods is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - userID string
-func (_e *MockStorage_Expecter) IsUserAssignedToReviewer(ctx interface{}, prID interface{}, userID interface{}) *Storage_IsUserAssignedToReviewer_Call {
-    return &Storage_IsUserAssignedToReviewer_Call{Call: _e.mock.On("IsUserAssignedToReviewer", ctx, prID, userID)}
+//   - now time.Time
+//   - limit int
+//   - picker func(teamName string) entities.ReviewerPicker
+func (_e *MockStorage_Expecter) StartUnavailabilityPeriods(ctx interface{}, now interface{}, limit interface{}, picker interface{}) *Storage_StartUnavailabilityPeriods_Call {
+    return &Storage_StartUnavailabilityPeriods_Call{Call: _e.mock.On("StartUnavailabilityPeriods", ctx, now, limit, picker)}
 }
 
-func (_c *Storage_IsUserAssignedToReviewer_Call) Run(run func(ctx context.Context, prID string, userID string)) *Storage_IsUserAssignedToReviewer_Call {
+func (_c *Storage_StartUnavailabilityPeriods_Call) Run(run func(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker)) *Storage_StartUnavailabilityPeriods_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].(string))
+        run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(func(teamName string) entities.ReviewerPicker))
     })
     return _c
 }
 
-func (_c *Storage_IsUserAssignedToReviewer_Call) Return(_a0 bool, _a1 error) *Storage_IsUserAssignedToReviewer_Call {
+func (_c *Storage_StartUnavailabilityPeriods_Call) Return(_a0 []entities.PRReassignmentInfo, _a1 error) *Storage_StartUnavailabilityPeriods_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_IsUserAssignedToReviewer_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Storage_IsUserAssignedToReviewer_Call {
+func (_c *Storage_StartUnavailabilityPeriods_Call) RunAndReturn(run func(context.Context, time.Time, int, func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)) *Storage_StartUnavailabilityPeriods_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// MergePR provides a mock function with given fields: ctx, prID, mergedAt
-func (_m *MockStorage) MergePR(ctx context.Context, prID string, mergedAt time.Time) (*entities.PullRequest, error) {
-    ret := _m.Called(ctx, prID, mergedAt)
+// TeamExists provides a mock function with given fields: ctx, teamName
+func (_m *MockStorage) TeamExists(ctx context.Context, teamName string) (bool, error) {
+    ret := _m.Called(ctx, teamName)
 
     if len(ret) == 0 {
-        panic("no return value specified for MergePR")
+        panic("no return value specified for TeamExists")
     }
 
-    var r0 *entities.PullRequest
+    var r0 bool
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entities.PullRequest, error)); ok {
-        return rf(ctx, prID, mergedAt)
+    if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+        return rf(ctx, teamName)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *entities.PullRequest); ok {
-        r0 = rf(ctx, prID, mergedAt)
+    if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+        r0 = rf(ctx, teamName)
     } else {
-        if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.PullRequest)
-        }
+        r0 = ret.Get(0).(bool)
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-        r1 = rf(ctx, prID, mergedAt)
+    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+        r1 = rf(ctx, teamName)
     } else {
         r1 = ret.Error(1)
     }
@@ -620,57 +3467,58 @@ func (_m *MockStorage) MergePR(ctx context.Context, prID string, mergedAt time.T
     return r0, r1
 }
 
-// Storage_MergePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePR'
-type Storage_MergePR_Call struct {
+// Storage_TeamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamExists'
+type Storage_TeamExists_Call struct {
     *mock.Call
 }
 
-// MergePR is a helper method to define mock.On call
+// TeamExists is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - mergedAt time.Time
-func (_e *MockStorage_Expecter) MergePR(ctx interface{}, prID interface{}, mergedAt interface{}) *Storage_MergePR_Call {
-    return &Storage_MergePR_Call{Call: _e.mock.On("MergePR", ctx, prID, mergedAt)}
+//   - teamName string
+func (_e *MockStorage_Expecter) TeamExists(ctx interface{}, teamName interface{}) *Storage_TeamExists_Call {
+    return &Storage_TeamExists_Call{Call: _e.mock.On("TeamExists", ctx, teamName)}
 }
 
-func (_c *Storage_MergePR_Call) Run(run func(ctx context.Context, prID string, mergedAt time.Time)) *Storage_MergePR_Call {
+func (_c *Storage_TeamExists_Call) Run(run func(ctx context.Context, teamName string)) *Storage_TeamExists_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+        run(args[0].(context.Context), args[1].(string))
     })
     return _c
 }
 
-func (_c *Storage_MergePR_Call) Return(_a0 *entities.PullRequest, _a1 error) *Storage_MergePR_Call {
+func (_c *Storage_TeamExists_Call) Return(_a0 bool, _a1 error) *Storage_TeamExists_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_MergePR_Call) RunAndReturn(run func(context.Context, string, time.Time) (*entities.PullRequest, error)) *Storage_MergePR_Call {
+func (_c *Storage_TeamExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Storage_TeamExists_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// PRExists provides a mock function with given fields: ctx, prID
-func (_m *MockStorage) PRExists(ctx context.Context, prID string) (bool, error) {
-    ret := _m.Called(ctx, prID)
+// UpdatePRStatus provides a mock function with given fields: ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules
+func (_m *MockStorage) UpdatePRStatus(ctx context.Context, prID string, from entities.PRStatus, to entities.PRStatus, at time.Time, reviewerIDs []string, reviewerTeams map[string]string, reviewerRules map[string]string) (*entities.PullRequest, error) {
+    ret := _m.Called(ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules)
 
     if len(ret) == 0 {
-        panic("no return value specified for PRExists")
+        panic("no return value specified for UpdatePRStatus")
     }
 
-    var r0 bool
+    var r0 *entities.PullRequest
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-        return rf(ctx, prID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, entities.PRStatus, entities.PRStatus, time.Time, []string, map[string]string, map[string]string) (*entities.PullRequest, error)); ok {
+        return rf(ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-        r0 = rf(ctx, prID)
+    if rf, ok := ret.Get(0).(func(context.Context, string, entities.PRStatus, entities.PRStatus, time.Time, []string, map[string]string, map[string]string) *entities.PullRequest); ok {
+        r0 = rf(ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules)
     } else {
-        r0 = ret.Get(0).(bool)
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.PullRequest)
+        }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, prID)
+    if rf, ok := ret.Get(1).(func(context.Context, string, entities.PRStatus, entities.PRStatus, time.Time, []string, map[string]string, map[string]string) error); ok {
+        r1 = rf(ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules)
     } else {
         r1 = ret.Error(1)
     }
@@ -678,107 +3526,123 @@ func (_m *MockStorage) PRExists(ctx context.Context, prID string) (bool, error)
     return r0, r1
 }
 
-// Storage_PRExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PRExists'
-type Storage_PRExists_Call struct {
+// Storage_UpdatePRStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePRStatus'
+type Storage_UpdatePRStatus_Call struct {
     *mock.Call
 }
 
-// PRExists is a helper method to define mock.On call
+// UpdatePRStatus is a helper method to define mock.On call
 //   - ctx context.Context
 //   - prID string
-func (_e *MockStorage_Expecter) PRExists(ctx interface{}, prID interface{}) *Storage_PRExists_Call {
-    return &Storage_PRExists_Call{Call: _e.mock.On("PRExists", ctx, prID)}
+//   - from entities.PRStatus
+//   - to entities.PRStatus
+//   - at time.Time
+//   - reviewerIDs []string
+//   - reviewerTeams map[string]string
+//   - reviewerRules map[string]string
+func (_e *MockStorage_Expecter) UpdatePRStatus(ctx interface{}, prID interface{}, from interface{}, to interface{}, at interface{}, reviewerIDs interface{}, reviewerTeams interface{}, reviewerRules interface{}) *Storage_UpdatePRStatus_Call {
+    return &Storage_UpdatePRStatus_Call{Call: _e.mock.On("UpdatePRStatus", ctx, prID, from, to, at, reviewerIDs, reviewerTeams, reviewerRules)}
 }
 
-func (_c *Storage_PRExists_Call) Run(run func(ctx context.Context, prID string)) *Storage_PRExists_Call {
+func (_c *Storage_UpdatePRStatus_Call) Run(run func(ctx context.Context, prID string, from entities.PRStatus, to entities.PRStatus, at time.Time, reviewerIDs []string, reviewerTeams map[string]string, reviewerRules map[string]string)) *Storage_UpdatePRStatus_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(string), args[2].(entities.PRStatus), args[3].(entities.PRStatus), args[4].(time.Time), args[5].([]string), args[6].(map[string]string), args[7].(map[string]string))
     })
     return _c
 }
 
-func (_c *Storage_PRExists_Call) Return(_a0 bool, _a1 error) *Storage_PRExists_Call {
+func (_c *Storage_UpdatePRStatus_Call) Return(_a0 *entities.PullRequest, _a1 error) *Storage_UpdatePRStatus_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_PRExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Storage_PRExists_Call {
+func (_c *Storage_UpdatePRStatus_Call) RunAndReturn(run func(context.Context, string, entities.PRStatus, entities.PRStatus, time.Time, []string, map[string]string, map[string]string) (*entities.PullRequest, error)) *Storage_UpdatePRStatus_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// ReassignReviewer provides a mock function with given fields: ctx, prID, oldUserID, newUserID
-func (_m *MockStorage) ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string) error {
-    ret := _m.Called(ctx, prID, oldUserID, newUserID)
+// UpdateTeamSettings provides a mock function with given fields: ctx, settings
+func (_m *MockStorage) UpdateTeamSettings(ctx context.Context, settings *entities.TeamSettingsUpdate) (*entities.TeamSettings, error) {
+    ret := _m.Called(ctx, settings)
 
     if len(ret) == 0 {
-        panic("no return value specified for ReassignReviewer")
+        panic("no return value specified for UpdateTeamSettings")
     }
 
-    var r0 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-        r0 = rf(ctx, prID, oldUserID, newUserID)
+    var r0 *entities.TeamSettings
+    var r1 error
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.TeamSettingsUpdate) (*entities.TeamSettings, error)); ok {
+        return rf(ctx, settings)
+    }
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.TeamSettingsUpdate) *entities.TeamSettings); ok {
+        r0 = rf(ctx, settings)
     } else {
-        r0 = ret.Error(0)
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.TeamSettings)
+        }
     }
 
-    return r0
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.TeamSettingsUpdate) error); ok {
+        r1 = rf(ctx, settings)
+    } else {
+        r1 = ret.Error(1)
+    }
+
+    return r0, r1
 }
 
-// Storage_ReassignReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignReviewer'
-type Storage_ReassignReviewer_Call struct {
+// Storage_UpdateTeamSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTeamSettings'
+type Storage_UpdateTeamSettings_Call struct {
     *mock.Call
 }
 
-// ReassignReviewer is a helper method to define mock.On call
+// UpdateTeamSettings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - oldUserID string
-//   - newUserID string
-func (_e *MockStorage_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldUserID interface{}, newUserID interface{}) *Storage_ReassignReviewer_Call {
-    return &Storage_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldUserID, newUserID)}
+//   - settings *entities.TeamSettingsUpdate
+func (_e *MockStorage_Expecter) UpdateTeamSettings(ctx interface{}, settings interface{}) *Storage_UpdateTeamSettings_Call {
+    return &Storage_UpdateTeamSettings_Call{Call: _e.mock.On("UpdateTeamSettings", ctx, settings)}
 }
 
-func (_c *Storage_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldUserID string, newUserID string)) *Storage_ReassignReviewer_Call {
+func (_c *Storage_UpdateTeamSettings_Call) Run(run func(ctx context.Context, settings *entities.TeamSettingsUpdate)) *Storage_UpdateTeamSettings_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+        run(args[0].(context.Context), args[1].(*entities.TeamSettingsUpdate))
     })
     return _c
 }
 
-func (_c *Storage_ReassignReviewer_Call) Return(_a0 error) *Storage_ReassignReviewer_Call {
-    _c.Call.Return(_a0)
+func (_c *Storage_UpdateTeamSettings_Call) Return(_a0 *entities.TeamSettings, _a1 error) *Storage_UpdateTeamSettings_Call {
+    _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_ReassignReviewer_Call) RunAndReturn(run func(context.Context, string, string, string) error) *Storage_ReassignReviewer_Call {
+func (_c *Storage_UpdateTeamSettings_Call) RunAndReturn(run func(context.Context, *entities.TeamSettingsUpdate) (*entities.TeamSettings, error)) *Storage_UpdateTeamSettings_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// SetUserActiveStatus provides a mock function with given fields: ctx, userID, isActive
-func (_m *MockStorage) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*entities.User, error) {
-    ret := _m.Called(ctx, userID, isActive)
+// UpdateWebhookSubscription provides a mock function with given fields: ctx, sub
+func (_m *MockStorage) UpdateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error) {
+    ret := _m.Called(ctx, sub)
 
     if len(ret) == 0 {
-        panic("no return value specified for SetUserActiveStatus")
+        panic("no return value specified for UpdateWebhookSubscription")
     }
 
-    var r0 *entities.User
+    var r0 *entities.WebhookSubscription
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*entities.User, error)); ok {
-        return rf(ctx, userID, isActive)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookSubscription) (*entities.WebhookSubscription, error)); ok {
+        return rf(ctx, sub)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string, bool) *entities.User); ok {
-        r0 = rf(ctx, userID, isActive)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookSubscription) *entities.WebhookSubscription); ok {
+        r0 = rf(ctx, sub)
     } else {
         if ret.Get(0) != nil {
-            r0 = ret.Get(0).(*entities.User)
+            r0 = ret.Get(0).(*entities.WebhookSubscription)
         }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-        r1 = rf(ctx, userID, isActive)
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.WebhookSubscription) error); ok {
+        r1 = rf(ctx, sub)
     } else {
         r1 = ret.Error(1)
     }
@@ -786,57 +3650,58 @@ func (_m *MockStorage) SetUserActiveStatus(ctx context.Context, userID string, i
     return r0, r1
 }
 
-// Storage_SetUserActiveStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserActiveStatus'
-type Storage_SetUserActiveStatus_Call struct {
+// Storage_UpdateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookSubscription'
+type Storage_UpdateWebhookSubscription_Call struct {
     *mock.Call
 }
 
-// SetUserActiveStatus is a helper method to define mock.On call
+// UpdateWebhookSubscription is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - isActive bool
-func (_e *MockStorage_Expecter) SetUserActiveStatus(ctx interface{}, userID interface{}, isActive interface{}) *Storage_SetUserActiveStatus_Call {
-    return &Storage_SetUserActiveStatus_Call{Call: _e.mock.On("SetUserActiveStatus", ctx, userID, isActive)}
+//   - sub *entities.WebhookSubscription
+func (_e *MockStorage_Expecter) UpdateWebhookSubscription(ctx interface{}, sub interface{}) *Storage_UpdateWebhookSubscription_Call {
+    return &Storage_UpdateWebhookSubscription_Call{Call: _e.mock.On("UpdateWebhookSubscription", ctx, sub)}
 }
 
-func (_c *Storage_SetUserActiveStatus_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *Storage_SetUserActiveStatus_Call {
+func (_c *Storage_UpdateWebhookSubscription_Call) Run(run func(ctx context.Context, sub *entities.WebhookSubscription)) *Storage_UpdateWebhookSubscription_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string), args[2].(bool))
+        run(args[0].(context.Context), args[1].(*entities.WebhookSubscription))
     })
     return _c
 }
 
-func (_c *Storage_SetUserActiveStatus_Call) Return(_a0 *entities.User, _a1 error) *Storage_SetUserActiveStatus_Call {
+func (_c *Storage_UpdateWebhookSubscription_Call) Return(_a0 *entities.WebhookSubscription, _a1 error) *Storage_UpdateWebhookSubscription_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_SetUserActiveStatus_Call) RunAndReturn(run func(context.Context, string, bool) (*entities.User, error)) *Storage_SetUserActiveStatus_Call {
+func (_c *Storage_UpdateWebhookSubscription_Call) RunAndReturn(run func(context.Context, *entities.WebhookSubscription) (*entities.WebhookSubscription, error)) *Storage_UpdateWebhookSubscription_Call {
     _c.Call.Return(run)
     return _c
 }
 
-// TeamExists provides a mock function with given fields: ctx, teamName
-func (_m *MockStorage) TeamExists(ctx context.Context, teamName string) (bool, error) {
-    ret := _m.Called(ctx, teamName)
+// UpsertNotificationSettings provides a mock function with given fields: ctx, settings
+func (_m *MockStorage) UpsertNotificationSettings(ctx context.Context, settings *entities.NotificationSettings) (*entities.NotificationSettings, error) {
+    ret := _m.Called(ctx, settings)
 
     if len(ret) == 0 {
-        panic("no return value specified for TeamExists")
+        panic("no return value specified for UpsertNotificationSettings")
     }
 
-    var r0 bool
+    var r0 *entities.NotificationSettings
     var r1 error
-    if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-        return rf(ctx, teamName)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.NotificationSettings) (*entities.NotificationSettings, error)); ok {
+        return rf(ctx, settings)
     }
-    if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-        r0 = rf(ctx, teamName)
+    if rf, ok := ret.Get(0).(func(context.Context, *entities.NotificationSettings) *entities.NotificationSettings); ok {
+        r0 = rf(ctx, settings)
     } else {
-        r0 = ret.Get(0).(bool)
+        if ret.Get(0) != nil {
+            r0 = ret.Get(0).(*entities.NotificationSettings)
+        }
     }
 
-    if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-        r1 = rf(ctx, teamName)
+    if rf, ok := ret.Get(1).(func(context.Context, *entities.NotificationSettings) error); ok {
+        r1 = rf(ctx, settings)
     } else {
         r1 = ret.Error(1)
     }
@@ -844,31 +3709,31 @@ func (_m *MockStorage) TeamExists(ctx context.Context, teamName string) (bool, e
     return r0, r1
 }
 
-// Storage_TeamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamExists'
-type Storage_TeamExists_Call struct {
+// Storage_UpsertNotificationSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertNotificationSettings'
+type Storage_UpsertNotificationSettings_Call struct {
     *mock.Call
 }
 
-// TeamExists is a helper method to define mock.On call
+// UpsertNotificationSettings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *MockStorage_Expecter) TeamExists(ctx interface{}, teamName interface{}) *Storage_TeamExists_Call {
-    return &Storage_TeamExists_Call{Call: _e.mock.On("TeamExists", ctx, teamName)}
+//   - settings *entities.NotificationSettings
+func (_e *MockStorage_Expecter) UpsertNotificationSettings(ctx interface{}, settings interface{}) *Storage_UpsertNotificationSettings_Call {
+    return &Storage_UpsertNotificationSettings_Call{Call: _e.mock.On("UpsertNotificationSettings", ctx, settings)}
 }
 
-func (_c *Storage_TeamExists_Call) Run(run func(ctx context.Context, teamName string)) *Storage_TeamExists_Call {
+func (_c *Storage_UpsertNotificationSettings_Call) Run(run func(ctx context.Context, settings *entities.NotificationSettings)) *Storage_UpsertNotificationSettings_Call {
     _c.Call.Run(func(args mock.Arguments) {
-        run(args[0].(context.Context), args[1].(string))
+        run(args[0].(context.Context), args[1].(*entities.NotificationSettings))
     })
     return _c
 }
 
-func (_c *Storage_TeamExists_Call) Return(_a0 bool, _a1 error) *Storage_TeamExists_Call {
+func (_c *Storage_UpsertNotificationSettings_Call) Return(_a0 *entities.NotificationSettings, _a1 error) *Storage_UpsertNotificationSettings_Call {
     _c.Call.Return(_a0, _a1)
     return _c
 }
 
-func (_c *Storage_TeamExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Storage_TeamExists_Call {
+func (_c *Storage_UpsertNotificationSettings_Call) RunAndReturn(run func(context.Context, *entities.NotificationSettings) (*entities.NotificationSettings, error)) *Storage_UpsertNotificationSettings_Call {
     _c.Call.Return(run)
     return _c
 }
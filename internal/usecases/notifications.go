@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// GetNotificationSettings возвращает настройки уведомлений пользователя или значения по умолчанию
+func (s *ServiceStorage) GetNotificationSettings(ctx context.Context, userID string) (*en.NotificationSettings, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+	settings, err := s.storage.GetNotificationSettings(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notification settings")
+	}
+	if settings == nil {
+		return en.NewDefaultNotificationSettings(userID), nil
+	}
+	return settings, nil
+}
+
+// UpdateNotificationSettings заменяет настройки уведомлений пользователя. Пустой timezone - UTC
+func (s *ServiceStorage) UpdateNotificationSettings(ctx context.Context, settings *en.NotificationSettings) (*en.NotificationSettings, error) {
+	if settings == nil || settings.UserID == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	normalized := *settings
+	if normalized.Timezone == "" {
+		normalized.Timezone = en.DefaultTimezone
+	}
+	if err := validateNotificationSettings(&normalized); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUserExists(ctx, settings.UserID); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.storage.UpsertNotificationSettings(ctx, &normalized)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save notification settings")
+	}
+	return saved, nil
+}
+
+func validateNotificationSettings(settings *en.NotificationSettings) error {
+	if settings.Email != "" {
+		if _, err := mail.ParseAddress(settings.Email); err != nil {
+			return errors.Errorf("invalid email '%s'", settings.Email)
+		}
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil {
+		return errors.Errorf("unknown timezone '%s'", settings.Timezone)
+	}
+	if settings.DigestTime != "" {
+		if _, err := en.ParseTimeOfDay(settings.DigestTime); err != nil {
+			return err
+		}
+	}
+	if (settings.QuietHoursStart == "") != (settings.QuietHoursEnd == "") {
+		return errors.New("quiet_hours_start and quiet_hours_end must be set together")
+	}
+	if settings.QuietHoursStart == "" {
+		return nil
+	}
+	if _, err := en.ParseTimeOfDay(settings.QuietHoursStart); err != nil {
+		return err
+	}
+	if _, err := en.ParseTimeOfDay(settings.QuietHoursEnd); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetReviewDigest формирует дайджест открытых ревью пользователя на текущий момент без отправки
+func (s *ServiceStorage) GetReviewDigest(ctx context.Context, userID string) (*en.ReviewDigest, error) {
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+
+	digests, err := s.storage.ListPendingReviewDigests(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pending reviews")
+	}
+	digest := &en.ReviewDigest{UserID: user.UserID, Username: user.Username, Reviews: []en.DigestReview{}}
+	if len(digests) > 0 {
+		digest = digests[0]
+	}
+	withReviewAges(digest, time.Now().UTC())
+	return digest, nil
+}
+
+// withReviewAges проставляет время формирования дайджеста и возраст каждого назначения
+func withReviewAges(digest *en.ReviewDigest, now time.Time) {
+	digest.GeneratedAt = now
+	for i := range digest.Reviews {
+		digest.Reviews[i].AgeSeconds = int64(now.Sub(digest.Reviews[i].AssignedAt).Seconds())
+	}
+}
+
+func (s *ServiceStorage) ensureUserExists(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user_id cannot be empty")
+	}
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return en.NewNotFoundError("user", userID)
+	}
+	return nil
+}
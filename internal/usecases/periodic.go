@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// runPeriodically вызывает run сразу и затем раз в interval до отмены контекста. run получает контекст
+// с логгером фоновой задачи (атрибут component); ошибка прохода пишется в лог и не останавливает цикл
+func runPeriodically(ctx context.Context, component string, interval time.Duration, run func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := en.LoggerFromContext(ctx).With("component", component)
+	ctx = en.ContextWithLogger(ctx, logger)
+	for {
+		if err := run(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "run failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	ctx, cancel := context.WithCancel(en.ContextWithLogger(context.Background(), logger))
+	defer cancel()
+
+	calls := 0
+	runPeriodically(ctx, "test_scheduler", time.Millisecond, func(ctx context.Context) error {
+		calls++
+		// проход получает логгер фоновой задачи
+		en.LoggerFromContext(ctx).InfoContext(ctx, "pass")
+		if calls == 3 {
+			cancel()
+		}
+		return errors.New("connection refused")
+	})
+
+	// первый проход сразу, ошибки не останавливают цикл, отмена контекста завершает его
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, bytes.Count(logs.Bytes(), []byte("msg=pass component=test_scheduler")))
+	// ошибка прохода, прерванного отменой, не пишется
+	assert.Equal(t, 2, bytes.Count(logs.Bytes(), []byte(`msg="run failed" component=test_scheduler error="connection refused"`)))
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPRListLimit = 20
+	maxPRListLimit     = 100
+)
+
+// normalizePRListFilter проверяет фильтр списка PR и заполняет значения по умолчанию
+func normalizePRListFilter(filter *en.PRListFilter) error {
+	switch filter.Status {
+	case "", en.StatusDraft, en.StatusOpen, en.StatusMerged, en.StatusClosed:
+	default:
+		return errors.Errorf("unknown PR status '%s'", filter.Status)
+	}
+
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = en.PRSortCreatedAt
+	case en.PRSortCreatedAt, en.PRSortName:
+	default:
+		return errors.Errorf("unknown sort field '%s'", filter.SortBy)
+	}
+
+	switch filter.Order {
+	case "":
+		filter.Order = en.SortDesc
+	case en.SortAsc, en.SortDesc:
+	default:
+		return errors.Errorf("unknown sort order '%s'", filter.Order)
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultPRListLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxPRListLimit {
+		return errors.Errorf("limit must be between 1 and %d", maxPRListLimit)
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return errors.New("created_from cannot be after created_to")
+	}
+	if filter.MergedFrom != nil && filter.MergedTo != nil && filter.MergedFrom.After(*filter.MergedTo) {
+		return errors.New("merged_from cannot be after merged_to")
+	}
+	return nil
+}
+
+// encodePRCursor кодирует позицию после pr в непрозрачную строку
+func encodePRCursor(sortBy string, pr *en.PullRequest) string {
+	cursor := en.PRCursor{SortBy: sortBy, PullRequestID: pr.PullRequestID}
+	switch sortBy {
+	case en.PRSortName:
+		cursor.SortValue = pr.PullRequestName
+	default:
+		cursor.SortValue = pr.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePRCursor разбирает курсор и проверяет, что он выдан для той же сортировки
+func decodePRCursor(raw, sortBy string) (*en.PRCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor en.PRCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.PullRequestID == "" {
+		return nil, errors.New("invalid cursor")
+	}
+	if cursor.SortBy != sortBy {
+		return nil, errors.New("cursor was issued for a different sort field")
+	}
+	if sortBy == en.PRSortCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.SortValue); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+	return &cursor, nil
+}
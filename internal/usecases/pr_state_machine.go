@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// prTransitions допустимые переходы статусов PR:
+//
+//	DRAFT  -> OPEN (markReady), CLOSED (close)
+//	OPEN   -> MERGED (merge), CLOSED (close)
+//	CLOSED -> OPEN (reopen)
+//	MERGED — конечное состояние
+var prTransitions = map[en.PRStatus][]en.PRStatus{
+	en.StatusDraft:  {en.StatusOpen, en.StatusClosed},
+	en.StatusOpen:   {en.StatusMerged, en.StatusClosed},
+	en.StatusClosed: {en.StatusOpen},
+	en.StatusMerged: {},
+}
+
+// canTransition проверяет, разрешен ли переход статуса PR
+func canTransition(from, to en.PRStatus) bool {
+	for _, allowed := range prTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTransition возвращает ошибку, если PR нельзя перевести в статус to
+func validateTransition(pr *en.PullRequest, to en.PRStatus) error {
+	if canTransition(pr.Status, to) {
+		return nil
+	}
+	if pr.Status == en.StatusMerged {
+		return en.NewPRMergedError(pr.PullRequestID)
+	}
+	return en.NewInvalidTransitionError(pr.PullRequestID, pr.Status, to)
+}
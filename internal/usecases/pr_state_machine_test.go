@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"testing"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to en.PRStatus
+		allowed  bool
+	}{
+		{en.StatusDraft, en.StatusOpen, true},
+		{en.StatusDraft, en.StatusClosed, true},
+		{en.StatusDraft, en.StatusMerged, false},
+		{en.StatusOpen, en.StatusMerged, true},
+		{en.StatusOpen, en.StatusClosed, true},
+		{en.StatusOpen, en.StatusDraft, false},
+		{en.StatusClosed, en.StatusOpen, true},
+		{en.StatusClosed, en.StatusMerged, false},
+		{en.StatusMerged, en.StatusOpen, false},
+		{en.StatusMerged, en.StatusClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, canTransition(tt.from, tt.to))
+		})
+	}
+}
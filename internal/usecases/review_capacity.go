@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// SetUserReviewCapacity задает лимит одновременных ревью OPEN PR, nil снимает ограничение.
+// Ревью, уже назначенные сверх нового лимита, не снимаются
+func (s *ServiceStorage) SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*en.User, error) {
+	if userID == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, errors.New("max_open_reviews cannot be negative")
+	}
+
+	user, err := s.storage.SetUserReviewCapacity(ctx, userID, maxOpenReviews)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set user review capacity")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+	return user, nil
+}
+
+// GetReviewerLoad возвращает число открытых ревью пользователя и его лимит
+func (s *ServiceStorage) GetReviewerLoad(ctx context.Context, userID string) (*en.ReviewerLoad, error) {
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+
+	load, err := s.storage.GetOpenReviewLoad(ctx, []string{userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get reviewer load")
+	}
+	return &en.ReviewerLoad{UserID: userID, OpenReviews: load[userID], MaxOpenReviews: user.MaxOpenReviews}, nil
+}
+
+// withinCapacity отбрасывает кандидатов, достигших лимита открытых ревью, и возвращает их количество.
+// Нагрузка запрашивается, только если лимит задан хотя бы у одного кандидата
+func (s *ServiceStorage) withinCapacity(ctx context.Context, candidates []*en.User) ([]*en.User, int, error) {
+	var limited []string
+	for _, c := range candidates {
+		if c.MaxOpenReviews != nil {
+			limited = append(limited, c.UserID)
+		}
+	}
+	if len(limited) == 0 {
+		return candidates, 0, nil
+	}
+
+	load, err := s.storage.GetOpenReviewLoad(ctx, limited)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get reviewers load")
+	}
+
+	var free []*en.User
+	full := 0
+	for _, c := range candidates {
+		if c.AtCapacity(load[c.UserID]) {
+			full++
+			continue
+		}
+		free = append(free, c)
+	}
+	return free, full, nil
+}
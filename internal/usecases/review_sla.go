@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// ListOverdueReviews возвращает назначения OPEN PR без вердикта, у которых истек SLA команды PR.
+// Пустой teamName - все команды. Эскалированные назначения, оставшиеся на PR, тоже попадают в список
+func (s *ServiceStorage) ListOverdueReviews(ctx context.Context, teamName string) ([]*en.OverdueReview, error) {
+	if teamName != "" {
+		exists, err := s.storage.TeamExists(ctx, teamName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check team existence")
+		}
+		if !exists {
+			return nil, en.NewNotFoundError("team", teamName)
+		}
+	}
+
+	overdue, err := s.storage.ListOverdueReviews(ctx, teamName, time.Now().UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list overdue reviews")
+	}
+	return overdue, nil
+}
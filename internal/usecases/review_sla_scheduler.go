@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// ReviewSLASchedulerConfig параметры эскалации просроченных ревью. Нулевые значения
+// заменяются значениями по умолчанию
+type ReviewSLASchedulerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Metrics учитывает переназначения, nil - метрики не собираются
+	Metrics Metrics
+}
+
+func (c ReviewSLASchedulerConfig) withDefaults() ReviewSLASchedulerConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Minute
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	return c
+}
+
+// ReviewSLAScheduler эскалирует назначения, по которым ревьювер не оставил вердикт за SLA команды PR
+type ReviewSLAScheduler struct {
+	storage   Storage
+	selectors *ReviewerSelectors
+	cfg       ReviewSLASchedulerConfig
+	now       func() time.Time
+}
+
+// NewReviewSLAScheduler создает планировщик. selectors может быть nil, тогда новый ревьювер выбирается случайно
+func NewReviewSLAScheduler(storage Storage, selectors *ReviewerSelectors, cfg ReviewSLASchedulerConfig) (*ReviewSLAScheduler, error) {
+	if storage == nil {
+		return nil, errors.Wrap(en.ErrNilDependency, "review sla scheduler")
+	}
+	return &ReviewSLAScheduler{storage: storage, selectors: selectors, cfg: cfg.withDefaults(), now: time.Now}, nil
+}
+
+// Run эскалирует просроченные назначения до отмены контекста
+func (r *ReviewSLAScheduler) Run(ctx context.Context) {
+	runPeriodically(ctx, "review_sla_scheduler", r.cfg.PollInterval, func(ctx context.Context) error {
+		escalations, err := r.RunOnce(ctx)
+		for _, e := range escalations {
+			en.LoggerFromContext(ctx).InfoContext(ctx, "overdue review escalated", "pull_request_id", e.PullRequestID,
+				"old_reviewer", e.OldReviewer, "action", e.Action, "new_reviewer", e.NewReviewer)
+		}
+		return err
+	})
+}
+
+// RunOnce эскалирует одну пачку просроченных назначений и возвращает результат по каждому из них
+func (r *ReviewSLAScheduler) RunOnce(ctx context.Context) ([]en.ReviewEscalation, error) {
+	escalations, err := r.storage.EscalateOverdueReviews(ctx, r.now().UTC(), r.cfg.BatchSize, loggedPicker(ctx, r.selectors))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to escalate overdue reviews")
+	}
+	if r.cfg.Metrics != nil {
+		for _, e := range escalations {
+			// без кандидата назначение только отмечается эскалированным
+			if e.NewReviewer == "" {
+				continue
+			}
+			if e.Action == en.EscalationReassign {
+				r.cfg.Metrics.ReviewersReassigned(en.ReasonReviewSLAExceeded, 1)
+			} else {
+				r.cfg.Metrics.ReviewersAssigned(en.ReasonReviewSLAExceeded, 1)
+			}
+		}
+	}
+	return escalations, nil
+}
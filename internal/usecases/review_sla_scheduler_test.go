@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewSLAScheduler_RunLogsEscalations(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	scheduler, err := NewReviewSLAScheduler(mockStorage, nil, ReviewSLASchedulerConfig{})
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	ctx, cancel := context.WithCancel(en.ContextWithLogger(context.Background(), slog.New(slog.NewTextHandler(&logs, nil))))
+	defer cancel()
+	mockStorage.EXPECT().EscalateOverdueReviews(mock.Anything, mock.Anything, 100, mock.Anything).
+		RunAndReturn(func(_ context.Context, now time.Time, _ int, _ func(string) en.ReviewerPicker) ([]en.ReviewEscalation, error) {
+			assert.Equal(t, time.UTC, now.Location())
+			cancel()
+			return []en.ReviewEscalation{
+				{PullRequestID: "pr-1", Action: en.EscalationReassign, OldReviewer: "u2", NewReviewer: "u3"},
+				{PullRequestID: "pr-2", Action: en.EscalationAddReviewer, OldReviewer: "u4"},
+			}, nil
+		}).Once()
+
+	scheduler.Run(ctx)
+
+	assert.Contains(t, logs.String(),
+		"overdue review escalated\" component=review_sla_scheduler pull_request_id=pr-1 old_reviewer=u2 action=reassign new_reviewer=u3")
+	assert.Contains(t, logs.String(), "pull_request_id=pr-2 old_reviewer=u4 action=add_reviewer new_reviewer=\"\"")
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// Стратегии выбора ревьюверов
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyWeighted    = "weighted"
+)
+
+// ReviewerSelector выбирает до count ревьюверов из списка кандидатов.
+// load содержит количество OPEN PR на ревью у кандидатов и заполняется только для стратегий с UsesLoad() == true
+type ReviewerSelector interface {
+	Select(candidates []*en.User, load map[string]int, count int) []string
+	UsesLoad() bool
+}
+
+// NewReviewerSelector создает стратегию по имени. weights используется только стратегией weighted
+func NewReviewerSelector(strategy string, weights map[string]int) (ReviewerSelector, error) {
+	switch strategy {
+	case "", StrategyRandom:
+		return RandomSelector{}, nil
+	case StrategyLeastLoaded:
+		return LeastLoadedSelector{}, nil
+	case StrategyRoundRobin:
+		return &RoundRobinSelector{}, nil
+	case StrategyWeighted:
+		for userID, w := range weights {
+			if w <= 0 {
+				return nil, fmt.Errorf("weight for user '%s' must be positive", userID)
+			}
+		}
+		return WeightedSelector{weights: weights}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy '%s'", strategy)
+	}
+}
+
+// ReviewerSelectors хранит глобальную стратегию и переопределения для отдельных команд
+type ReviewerSelectors struct {
+	Default ReviewerSelector
+	Teams   map[string]ReviewerSelector
+}
+
+func NewReviewerSelectors(defaultStrategy string, teamStrategies map[string]string, weights map[string]int) (*ReviewerSelectors, error) {
+	def, err := NewReviewerSelector(defaultStrategy, weights)
+	if err != nil {
+		return nil, err
+	}
+	teams := make(map[string]ReviewerSelector, len(teamStrategies))
+	for team, strategy := range teamStrategies {
+		sel, err := NewReviewerSelector(strategy, weights)
+		if err != nil {
+			return nil, fmt.Errorf("team '%s': %w", team, err)
+		}
+		teams[team] = sel
+	}
+	return &ReviewerSelectors{Default: def, Teams: teams}, nil
+}
+
+// ForTeam возвращает стратегию команды, а при её отсутствии — глобальную
+func (r *ReviewerSelectors) ForTeam(teamName string) ReviewerSelector {
+	if r == nil {
+		return RandomSelector{}
+	}
+	if sel, ok := r.Teams[teamName]; ok {
+		return sel
+	}
+	if r.Default == nil {
+		return RandomSelector{}
+	}
+	return r.Default
+}
+
+// RandomSelector выбирает ревьюверов случайно (Fisher-Yates shuffle)
+type RandomSelector struct{}
+
+func (RandomSelector) UsesLoad() bool { return false }
+
+func (RandomSelector) Select(candidates []*en.User, _ map[string]int, count int) []string {
+	shuffled := make([]*en.User, len(candidates))
+	copy(shuffled, candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return firstIDs(shuffled, count)
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью.
+// При равной нагрузке выбор детерминирован — по возрастанию user_id
+type LeastLoadedSelector struct{}
+
+func (LeastLoadedSelector) UsesLoad() bool { return true }
+
+func (LeastLoadedSelector) Select(candidates []*en.User, load map[string]int, count int) []string {
+	sorted := make([]*en.User, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		li, lj := load[sorted[i].UserID], load[sorted[j].UserID]
+		if li != lj {
+			return li < lj
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+	return firstIDs(sorted, count)
+}
+
+// RoundRobinSelector выбирает кандидатов по кругу в порядке user_id
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (*RoundRobinSelector) UsesLoad() bool { return false }
+
+func (s *RoundRobinSelector) Select(candidates []*en.User, _ map[string]int, count int) []string {
+	if len(candidates) == 0 {
+		return []string{}
+	}
+
+	sorted := make([]*en.User, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UserID < sorted[j].UserID })
+
+	n := min(len(sorted), count)
+
+	s.mu.Lock()
+	start := s.next % len(sorted)
+	s.next = start + n
+	s.mu.Unlock()
+
+	result := make([]string, n)
+	for i := 0; i < n; i++ {
+		result[i] = sorted[(start+i)%len(sorted)].UserID
+	}
+	return result
+}
+
+// WeightedSelector выбирает кандидатов случайно пропорционально весам (вес по умолчанию 1)
+type WeightedSelector struct {
+	weights map[string]int
+}
+
+func (WeightedSelector) UsesLoad() bool { return false }
+
+func (s WeightedSelector) Select(candidates []*en.User, _ map[string]int, count int) []string {
+	pool := make([]*en.User, len(candidates))
+	copy(pool, candidates)
+
+	n := min(len(pool), count)
+	result := make([]string, 0, n)
+	for len(result) < n {
+		total := 0
+		for _, c := range pool {
+			total += s.weight(c.UserID)
+		}
+		r := rand.Intn(total)
+		for i, c := range pool {
+			r -= s.weight(c.UserID)
+			if r < 0 {
+				result = append(result, c.UserID)
+				pool = append(pool[:i], pool[i+1:]...)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (s WeightedSelector) weight(userID string) int {
+	if w, ok := s.weights[userID]; ok {
+		return w
+	}
+	return 1
+}
+
+func firstIDs(users []*en.User, count int) []string {
+	n := min(len(users), count)
+	result := make([]string, n)
+	for i := 0; i < n; i++ {
+		result[i] = users[i].UserID
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testCandidates(ids ...string) []*en.User {
+	users := make([]*en.User, len(ids))
+	for i, id := range ids {
+		users[i] = &en.User{UserID: id, TeamName: "backend", IsActive: true}
+	}
+	return users
+}
+
+func TestNewReviewerSelector_UnknownStrategy(t *testing.T) {
+	sel, err := NewReviewerSelector("magic", nil)
+
+	require.Error(t, err)
+	assert.Nil(t, sel)
+}
+
+func TestNewReviewerSelector_NonPositiveWeight(t *testing.T) {
+	sel, err := NewReviewerSelector(StrategyWeighted, map[string]int{"u1": 0})
+
+	require.Error(t, err)
+	assert.Nil(t, sel)
+}
+
+func TestReviewerSelectors_ForTeam(t *testing.T) {
+	selectors, err := NewReviewerSelectors(StrategyRandom, map[string]string{"backend": StrategyLeastLoaded}, nil)
+	require.NoError(t, err)
+
+	assert.IsType(t, LeastLoadedSelector{}, selectors.ForTeam("backend"))
+	assert.IsType(t, RandomSelector{}, selectors.ForTeam("frontend"))
+
+	var empty *ReviewerSelectors
+	assert.IsType(t, RandomSelector{}, empty.ForTeam("backend"))
+}
+
+func TestRandomSelector_Select(t *testing.T) {
+	candidates := testCandidates("u1", "u2", "u3")
+
+	picked := RandomSelector{}.Select(candidates, nil, 2)
+
+	assert.Len(t, picked, 2)
+	assert.NotEqual(t, picked[0], picked[1])
+	assert.Empty(t, RandomSelector{}.Select(nil, nil, 2))
+	assert.NotNil(t, RandomSelector{}.Select(nil, nil, 2))
+}
+
+func TestLeastLoadedSelector_Select(t *testing.T) {
+	candidates := testCandidates("u1", "u2", "u3")
+	load := map[string]int{"u1": 5, "u2": 0, "u3": 1}
+
+	picked := LeastLoadedSelector{}.Select(candidates, load, 2)
+
+	assert.Equal(t, []string{"u2", "u3"}, picked)
+}
+
+func TestLeastLoadedSelector_DeterministicTieBreak(t *testing.T) {
+	candidates := testCandidates("u4", "u2", "u3", "u1")
+	load := map[string]int{"u1": 2, "u2": 1, "u3": 1}
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []string{"u4", "u2"}, LeastLoadedSelector{}.Select(candidates, load, 2))
+	}
+}
+
+func TestRoundRobinSelector_Select(t *testing.T) {
+	sel := &RoundRobinSelector{}
+	candidates := testCandidates("u3", "u1", "u2")
+
+	assert.Equal(t, []string{"u1"}, sel.Select(candidates, nil, 1))
+	assert.Equal(t, []string{"u2", "u3"}, sel.Select(candidates, nil, 2))
+	assert.Equal(t, []string{"u1"}, sel.Select(candidates, nil, 1))
+}
+
+func TestWeightedSelector_Select(t *testing.T) {
+	sel := WeightedSelector{weights: map[string]int{"u1": 1000000}}
+	candidates := testCandidates("u1", "u2")
+
+	picked := sel.Select(candidates, nil, 1)
+	assert.Len(t, picked, 1)
+
+	both := sel.Select(candidates, nil, 5)
+	assert.ElementsMatch(t, []string{"u1", "u2"}, both)
+}
+
+func TestCreatePR_LeastLoadedStrategy(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	selectors, err := NewReviewerSelectors(StrategyLeastLoaded, nil, nil)
+	require.NoError(t, err)
+	service, err := NewServiceStorage(mockStorage, WithReviewerSelectors(selectors))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	members := append([]*en.User{author}, testCandidates("u2", "u3", "u4")...)
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetOpenReviewLoad(ctx, []string{"u2", "u3", "u4"}).
+		Return(map[string]int{"u2": 3, "u3": 0, "u4": 1}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u3", "u4"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+}
@@ -4,7 +4,7 @@ package usecases
 
 import (
 	"context"
-	"math/rand"
+	"strings"
 	"time"
 
 	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
@@ -12,24 +12,102 @@ import (
 )
 
 type ServiceStorage struct {
-	storage Storage
+	storage     Storage
+	selectors   *ReviewerSelectors
+	mergePolicy MergePolicy
+	metrics     Metrics
 }
 
-func NewServiceStorage(storage Storage) (*ServiceStorage, error) {
+// Option настраивает ServiceStorage при создании
+type Option func(*ServiceStorage)
+
+// WithReviewerSelectors задает стратегии выбора ревьюверов (по умолчанию — случайный выбор)
+func WithReviewerSelectors(selectors *ReviewerSelectors) Option {
+	return func(s *ServiceStorage) {
+		s.selectors = selectors
+	}
+}
+
+// WithMergePolicy задает условия мержа по вердиктам ревьюверов (по умолчанию мерж не ограничен)
+func WithMergePolicy(policy MergePolicy) Option {
+	return func(s *ServiceStorage) {
+		s.mergePolicy = policy
+	}
+}
+
+// Metrics учитывает бизнес-события текущего экземпляра
+type Metrics interface {
+	NoCandidate()
+	UsersDeactivated(count int)
+	// ReviewersAssigned, ReviewersReassigned и ReviewersRemoved учитывают назначения, замены и снятия ревьюверов
+	// без замены; reason - причина события в журнале PR
+	ReviewersAssigned(reason string, count int)
+	ReviewersReassigned(reason string, count int)
+	ReviewersRemoved(reason string, count int)
+}
+
+// observeReassignments учитывает замены и снятия без замены из результата переназначения. metrics может быть nil
+func observeReassignments(metrics Metrics, reason string, infos []en.PRReassignmentInfo) {
+	if metrics == nil {
+		return
+	}
+	reassigned := 0
+	for _, info := range infos {
+		if info.NewReviewer != "" {
+			reassigned++
+		}
+	}
+	if reassigned > 0 {
+		metrics.ReviewersReassigned(reason, reassigned)
+	}
+	if removed := len(infos) - reassigned; removed > 0 {
+		metrics.ReviewersRemoved(reason, removed)
+	}
+}
+
+// WithMetrics задает получателя бизнес-метрик (по умолчанию метрики не собираются)
+func WithMetrics(metrics Metrics) Option {
+	return func(s *ServiceStorage) {
+		s.metrics = metrics
+	}
+}
+
+func NewServiceStorage(storage Storage, opts ...Option) (*ServiceStorage, error) {
 	if storage == nil {
 		return nil, errors.New("storage cannot be nil")
 	}
-	return &ServiceStorage{storage: storage}, nil
+	s := &ServiceStorage{storage: storage}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
-// createTeam создает команду с участниками
-func (s *ServiceStorage) CreateTeam(ctx context.Context, teamName string, members []en.TeamMember) (*en.Team, error) {
+// createTeam создает команду с участниками. settings может быть nil — тогда применяются настройки по умолчанию.
+// Участники других команд обрабатываются по opts.ConflictMode (по умолчанию переводятся)
+func (s *ServiceStorage) CreateTeam(ctx context.Context, teamName string, members []en.TeamMember, settings *en.TeamSettings, opts en.TeamCreateOptions) (*en.TeamCreateResult, error) {
 	if teamName == "" {
 		return nil, errors.New("team name cannot be empty")
 	}
 	if len(members) == 0 {
 		return nil, errors.New("team must have at least one member")
 	}
+	switch opts.ConflictMode {
+	case "":
+		opts.ConflictMode = en.ConflictMove
+	case en.ConflictMove, en.ConflictJoin, en.ConflictReject, en.ConflictDryRun:
+	default:
+		return nil, errors.Errorf("unknown conflict mode '%s'", opts.ConflictMode)
+	}
+
+	teamSettings := en.NewDefaultTeamSettings(teamName)
+	if settings != nil {
+		teamSettings.MinReviewers = settings.MinReviewers
+		teamSettings.MaxReviewers = settings.MaxReviewers
+	}
+	if err := validateReviewersRange(&teamSettings.MinReviewers, &teamSettings.MaxReviewers); err != nil {
+		return nil, err
+	}
 
 	exists, err := s.storage.TeamExists(ctx, teamName)
 	if err != nil {
@@ -41,23 +119,34 @@ func (s *ServiceStorage) CreateTeam(ctx context.Context, teamName string, member
 
 	users := make([]*en.User, len(members))
 	for i, m := range members {
+		skills, err := normalizeTags(m.Skills)
+		if err != nil {
+			return nil, err
+		}
+		members[i].Skills = skills
 		users[i] = &en.User{
 			UserID:   m.UserID,
 			Username: m.Username,
 			TeamName: teamName,
 			IsActive: m.IsActive,
+			Skills:   skills,
 		}
 	}
 
-	err = s.storage.CreateTeamWithUsers(ctx, teamName, users)
+	result, err := s.storage.CreateTeamWithUsers(ctx, teamName, teamSettings, users, opts, s.teamPicker(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create team with users")
 	}
+	if !result.DryRun {
+		observeReassignments(s.metrics, en.ReasonLeftTeam, result.Reassignments)
+	}
 
-	return &en.Team{
+	result.Team = &en.Team{
 		TeamName:    teamName,
 		TeamMembers: members,
-	}, nil
+		Settings:    teamSettings,
+	}
+	return result, nil
 }
 
 // getTeam возвращает команду с участниками
@@ -72,6 +161,100 @@ func (s *ServiceStorage) GetTeam(ctx context.Context, teamName string) (*en.Team
 	return team, nil
 }
 
+// getTeamSettings возвращает настройки назначения ревьюверов команды
+func (s *ServiceStorage) GetTeamSettings(ctx context.Context, teamName string) (*en.TeamSettings, error) {
+	settings, err := s.storage.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get team settings")
+	}
+	if settings == nil {
+		return nil, en.NewNotFoundError("team", teamName)
+	}
+	return settings, nil
+}
+
+// updateTeamSettings изменяет настройки команды: поля, равные nil, и пустая эскалация остаются прежними.
+// Если settings.FallbackTeams или settings.OwnerRules не nil, соответствующий список заменяется целиком
+func (s *ServiceStorage) UpdateTeamSettings(ctx context.Context, settings *en.TeamSettingsUpdate) (*en.TeamSettings, error) {
+	if settings == nil || settings.TeamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+	if err := validateTeamSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := s.validateFallbackTeams(ctx, settings); err != nil {
+		return nil, err
+	}
+	if err := s.validateOwnerRules(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.storage.UpdateTeamSettings(ctx, settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update team settings")
+	}
+	if updated == nil {
+		return nil, en.NewNotFoundError("team", settings.TeamName)
+	}
+	return updated, nil
+}
+
+func validateTeamSettings(settings *en.TeamSettingsUpdate) error {
+	if err := validateReviewersRange(settings.MinReviewers, settings.MaxReviewers); err != nil {
+		return err
+	}
+	if settings.ReviewSLAMinutes != nil && *settings.ReviewSLAMinutes < 0 {
+		return errors.New("review_sla_minutes cannot be negative")
+	}
+	switch settings.SLAEscalation {
+	case "", en.EscalationReassign, en.EscalationAddReviewer:
+	default:
+		return errors.Errorf("unknown sla_escalation '%s'", settings.SLAEscalation)
+	}
+	return nil
+}
+
+// validateReviewersRange проверяет заданные границы числа ревьюверов. Если задана только одна из них,
+// min <= max проверяет хранилище по текущим настройкам команды
+func validateReviewersRange(minReviewers, maxReviewers *int) error {
+	if minReviewers != nil && *minReviewers < 0 {
+		return errors.New("min_reviewers cannot be negative")
+	}
+	if maxReviewers != nil && *maxReviewers < 1 {
+		return errors.New("max_reviewers must be at least 1")
+	}
+	if minReviewers != nil && maxReviewers != nil && *minReviewers > *maxReviewers {
+		return en.ErrReviewersRange
+	}
+	return nil
+}
+
+// validateFallbackTeams проверяет, что резервные команды существуют, не повторяются и не совпадают с самой командой
+func (s *ServiceStorage) validateFallbackTeams(ctx context.Context, settings *en.TeamSettingsUpdate) error {
+	seen := make(map[string]bool, len(settings.FallbackTeams))
+	for _, fallback := range settings.FallbackTeams {
+		if fallback == "" {
+			return errors.New("fallback team name cannot be empty")
+		}
+		if fallback == settings.TeamName {
+			return errors.New("team cannot be its own fallback")
+		}
+		if seen[fallback] {
+			return errors.Errorf("duplicate fallback team '%s'", fallback)
+		}
+		seen[fallback] = true
+
+		exists, err := s.storage.TeamExists(ctx, fallback)
+		if err != nil {
+			return errors.Wrap(err, "failed to check fallback team existence")
+		}
+		if !exists {
+			return en.NewNotFoundError("team", fallback)
+		}
+	}
+	return nil
+}
+
 // setUserActive устанавливает флаг активности пользователя
 func (s *ServiceStorage) SetUserActive(ctx context.Context, userID string, isActive bool) (*en.User, error) {
 	user, err := s.storage.SetUserActiveStatus(ctx, userID, isActive)
@@ -81,6 +264,9 @@ func (s *ServiceStorage) SetUserActive(ctx context.Context, userID string, isAct
 	if user == nil {
 		return nil, en.NewNotFoundError("user", userID)
 	}
+	if !isActive && s.metrics != nil {
+		s.metrics.UsersDeactivated(1)
+	}
 	return user, nil
 }
 
@@ -93,8 +279,10 @@ func (s *ServiceStorage) GetUserReviews(ctx context.Context, userID string) ([]*
 	return prs, nil
 }
 
-// createPullRequest создает PR и автоматически назначает до 2 ревьюверов из команды автора
-func (s *ServiceStorage) CreatePullRequest(ctx context.Context, prID, prName, authorID string) (*en.PullRequest, error) {
+// createPullRequest создает PR и автоматически назначает ревьюверов из выбранной команды автора
+// (по умолчанию основной) и ее резервных команд в пределах min_reviewers..max_reviewers из настроек команды.
+// Владельцы измененных файлов по правилам команды назначаются в первую очередь. Черновик создается без ревьюверов
+func (s *ServiceStorage) CreatePullRequest(ctx context.Context, prID, prName, authorID string, opts en.PRCreateOptions) (*en.PullRequest, error) {
 	if prID == "" || prName == "" || authorID == "" {
 		return nil, errors.New("prID, prName and authorID cannot be empty")
 	}
@@ -115,42 +303,253 @@ func (s *ServiceStorage) CreatePullRequest(ctx context.Context, prID, prName, au
 		return nil, en.NewNotFoundError("author", authorID)
 	}
 
-	// получаем только активных участников команды
-	teamMembers, err := s.storage.GetUsersByTeam(ctx, author.TeamName, true)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get team members")
-	}
-
-	// исключаем автора из кандидатов
-	var candidates []*en.User
-	for _, member := range teamMembers {
-		if member.UserID != authorID {
-			candidates = append(candidates, member)
+	teamName := author.TeamName
+	if opts.TeamName != "" {
+		if !author.InTeam(opts.TeamName) {
+			return nil, en.NewInvalidTeamUserError(authorID, opts.TeamName, "does not belong to")
 		}
+		teamName = opts.TeamName
 	}
 
-	reviewerIDs := selectRandomReviewers(candidates, 2)
+	changedFiles, err := normalizeChangedFiles(opts.ChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := normalizeTags(opts.Labels)
+	if err != nil {
+		return nil, err
+	}
 
 	pr := &en.PullRequest{
 		PullRequestID:     prID,
 		PullRequestName:   prName,
 		AuthorID:          authorID,
 		Status:            en.StatusOpen,
-		AssignedReviewers: reviewerIDs,
-		CreatedAt:         time.Now(),
+		AssignedReviewers: []string{},
+		TeamName:          teamName,
+		CreatedAt:         time.Now().UTC(),
 		MergedAt:          nil,
+		ChangedFiles:      changedFiles,
+		Labels:            labels,
+	}
+	if opts.Draft {
+		pr.Status = en.StatusDraft
+	} else {
+		selection, err := s.selectInitialReviewers(ctx, pr, teamName)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers, pr.ReviewerTeams, pr.ReviewerRules = selection.ids, selection.teams, selection.rules
 	}
 
-	err = s.storage.CreatePRWithReviewers(ctx, pr, reviewerIDs)
+	err = s.storage.CreatePRWithReviewers(ctx, pr, pr.AssignedReviewers)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create PR with reviewers")
 	}
+	if s.metrics != nil && len(pr.AssignedReviewers) > 0 {
+		s.metrics.ReviewersAssigned(en.ReasonAutoAssign, len(pr.AssignedReviewers))
+	}
+	en.LoggerFromContext(ctx).InfoContext(ctx, "reviewers assigned",
+		"pull_request_id", prID, "team", teamName, "reviewers", pr.AssignedReviewers, "status", pr.Status)
 
 	return pr, nil
 }
 
+// reviewerSelection выбранные ревьюверы, команды, от которых они назначены, и правила владения,
+// по которым назначены владельцы. expert - есть ли среди ревьюверов совпадение навыков с метками PR
+type reviewerSelection struct {
+	ids    []string
+	teams  map[string]string
+	rules  map[string]string
+	expert bool
+}
+
+func newReviewerSelection() *reviewerSelection {
+	return &reviewerSelection{ids: []string{}}
+}
+
+func (r *reviewerSelection) add(userID, team, rule string) {
+	r.ids = append(r.ids, userID)
+	if r.teams == nil {
+		r.teams = make(map[string]string)
+	}
+	r.teams[userID] = team
+	if rule != "" {
+		if r.rules == nil {
+			r.rules = make(map[string]string)
+		}
+		r.rules[userID] = rule
+	}
+}
+
+func (r *reviewerSelection) remove(userID string) {
+	for i, id := range r.ids {
+		if id == userID {
+			r.ids = append(r.ids[:i], r.ids[i+1:]...)
+			break
+		}
+	}
+	delete(r.teams, userID)
+	delete(r.rules, userID)
+}
+
+// selectInitialReviewers выбирает ревьюверов для PR из активных участников команды teamName.
+// Сначала назначаются владельцы измененных файлов по правилам команды. Если кандидатов команды не хватает
+// до max_reviewers, недостающие добираются из резервных команд по порядку стратегией каждой из них.
+// Внутри команды первыми выбираются кандидаты с навыками из меток PR
+func (s *ServiceStorage) selectInitialReviewers(ctx context.Context, pr *en.PullRequest, teamName string) (*reviewerSelection, error) {
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID := pr.AuthorID
+	selection := newReviewerSelection()
+	atCapacity, err := s.selectOwners(ctx, selection, settings, pr, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// unsearched команды, до которых перебор не дошел: в просмотренных эксперт уже выбран бы первым
+	sources := append([]string{teamName}, settings.FallbackTeams...)
+	var unsearched []string
+	for i, source := range sources {
+		need := settings.MaxReviewers - len(selection.ids)
+		if need <= 0 {
+			unsearched = sources[i:]
+			break
+		}
+
+		// исключаем автора и уже выбранных ревьюверов
+		candidates, full, err := s.teamCandidates(ctx, source, append([]string{authorID}, selection.ids...))
+		if err != nil {
+			return nil, err
+		}
+		atCapacity += full
+		picked, expert, err := s.pickBySkills(ctx, source, candidates, pr.Labels, need)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range picked {
+			selection.add(id, source, "")
+		}
+		selection.expert = selection.expert || expert
+	}
+	if err := s.ensureExpert(ctx, selection, settings, authorID, pr.Labels, unsearched); err != nil {
+		return nil, err
+	}
+
+	// не хватило ревьюверов из-за лимитов нагрузки
+	if atCapacity > 0 && (len(selection.ids) == 0 || len(selection.ids) < settings.MinReviewers) {
+		return nil, en.NewNoCapacityError(teamName)
+	}
+	if len(selection.ids) < settings.MinReviewers {
+		return nil, en.NewNotEnoughReviewersError(teamName, settings.MinReviewers, len(selection.ids))
+	}
+	return selection, nil
+}
+
+// teamCandidates возвращает активных участников команды, кроме пользователей из exclude,
+// недоступных в момент назначения и исчерпавших лимит открытых ревью. Второе значение - сколько
+// участников пропущено из-за лимита
+func (s *ServiceStorage) teamCandidates(ctx context.Context, teamName string, exclude []string) ([]*en.User, int, error) {
+	teamMembers, err := s.storage.GetUsersByTeam(ctx, teamName, true)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get team members")
+	}
+	return s.availableCandidates(ctx, teamMembers, exclude)
+}
+
+// availableCandidates оставляет из users тех, кого нет в exclude, кто доступен в момент назначения
+// и не исчерпал лимит открытых ревью. Второе значение - сколько пользователей пропущено из-за лимита
+func (s *ServiceStorage) availableCandidates(ctx context.Context, users []*en.User, exclude []string) ([]*en.User, int, error) {
+	now := time.Now()
+	var candidates []*en.User
+	for _, user := range users {
+		if !contains(exclude, user.UserID) && !user.UnavailableAt(now) {
+			candidates = append(candidates, user)
+		}
+	}
+	return s.withinCapacity(ctx, candidates)
+}
+
+// normalizeChangedFiles проверяет пути измененных файлов и убирает повторы, сохраняя порядок
+func normalizeChangedFiles(files []string) ([]string, error) {
+	normalized := make([]string, 0, len(files))
+	for _, file := range files {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			return nil, errors.New("changed file path cannot be empty")
+		}
+		if !contains(normalized, file) {
+			normalized = append(normalized, file)
+		}
+	}
+	return normalized, nil
+}
+
+// getPullRequest возвращает PR с назначенными ревьюверами
+func (s *ServiceStorage) GetPullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	if prID == "" {
+		return nil, errors.New("prID cannot be empty")
+	}
+	return s.getExistingPR(ctx, prID)
+}
+
+// listPullRequests возвращает страницу PR по фильтрам. Следующая страница запрашивается по NextCursor
+func (s *ServiceStorage) ListPullRequests(ctx context.Context, filter en.PRListFilter) (*en.PRPage, error) {
+	if err := normalizePRListFilter(&filter); err != nil {
+		return nil, err
+	}
+	if filter.Cursor != "" {
+		after, err := decodePRCursor(filter.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	// запрашиваем на один PR больше, чтобы понять, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	prs, err := s.storage.ListPRs(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list PRs")
+	}
+
+	page := &en.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodePRCursor(filter.SortBy, prs[limit-1])
+	}
+	if page.PullRequests == nil {
+		page.PullRequests = []*en.PullRequest{}
+	}
+	return page, nil
+}
+
+// getPullRequestHistory возвращает журнал назначений, переназначений и смен статуса PR
+func (s *ServiceStorage) GetPullRequestHistory(ctx context.Context, prID string) ([]*en.PREvent, error) {
+	if _, err := s.getExistingPR(ctx, prID); err != nil {
+		return nil, err
+	}
+	events, err := s.storage.GetPREvents(ctx, prID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get PR history")
+	}
+	if events == nil {
+		events = []*en.PREvent{}
+	}
+	return events, nil
+}
+
 // mergePullRequest помечает PR как MERGED. Операция идемпотентная
 func (s *ServiceStorage) MergePullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	return s.mergePullRequest(ctx, prID, true)
+}
+
+// mergePullRequest без проверки политики используется, когда PR уже смержен во внешней системе
+func (s *ServiceStorage) mergePullRequest(ctx context.Context, prID string, enforcePolicy bool) (*en.PullRequest, error) {
 	pr, err := s.storage.GetPR(ctx, prID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get PR")
@@ -163,8 +562,20 @@ func (s *ServiceStorage) MergePullRequest(ctx context.Context, prID string) (*en
 	if pr.Status == en.StatusMerged {
 		return pr, nil
 	}
+	if err := validateTransition(pr, en.StatusMerged); err != nil {
+		return nil, err
+	}
+	if enforcePolicy && s.mergePolicy.enabled() {
+		reviews, err := s.storage.GetPRReviews(ctx, prID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get PR reviews")
+		}
+		if err := s.mergePolicy.check(prID, summarizeReviews(reviews, pr.AssignedReviewers)); err != nil {
+			return nil, err
+		}
+	}
 
-	mergedPR, err := s.storage.MergePR(ctx, prID, time.Now())
+	mergedPR, err := s.storage.MergePR(ctx, prID, time.Now().UTC())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to merge PR")
 	}
@@ -172,18 +583,160 @@ func (s *ServiceStorage) MergePullRequest(ctx context.Context, prID string) (*en
 	return mergedPR, nil
 }
 
-// reassignReviewer заменяет ревьювера на случайного активного участника из команды заменяемого
-func (s *ServiceStorage) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*en.PullRequest, string, error) {
+// submitReview сохраняет вердикт назначенного ревьювера по открытому PR
+func (s *ServiceStorage) SubmitReview(ctx context.Context, prID, reviewerID string, verdict en.ReviewVerdict, comment string) (*en.Review, error) {
+	if prID == "" || reviewerID == "" {
+		return nil, errors.New("prID and reviewerID cannot be empty")
+	}
+	switch verdict {
+	case en.VerdictApproved, en.VerdictChangesRequested, en.VerdictCommented:
+	default:
+		return nil, errors.Errorf("unknown review verdict '%s'", verdict)
+	}
+
+	pr, err := s.getExistingPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == en.StatusMerged {
+		return nil, en.NewPRMergedError(prID)
+	}
+	if pr.Status != en.StatusOpen {
+		return nil, en.NewPRNotOpenError(prID, pr.Status)
+	}
+	if !contains(pr.AssignedReviewers, reviewerID) {
+		return nil, en.NewNotAssignedError(reviewerID, prID)
+	}
+
+	review := &en.Review{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		Verdict:       verdict,
+		Comment:       comment,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if err := s.storage.CreateReview(ctx, review); err != nil {
+		return nil, errors.Wrap(err, "failed to create review")
+	}
+	return review, nil
+}
+
+// closePullRequest закрывает PR без мержа. Повторное закрытие возвращает текущее состояние
+func (s *ServiceStorage) ClosePullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	pr, err := s.getExistingPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == en.StatusClosed {
+		return pr, nil
+	}
+	return s.transitionPR(ctx, pr, en.StatusClosed, nil)
+}
+
+// reopenPullRequest возвращает закрытый PR в OPEN. Если у PR нет ревьюверов (например, закрыт черновик),
+// они назначаются так же, как при создании
+func (s *ServiceStorage) ReopenPullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	pr, err := s.getExistingPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == en.StatusOpen {
+		return pr, nil
+	}
+	if err := validateTransition(pr, en.StatusOpen); err != nil {
+		return nil, err
+	}
+
+	var selection *reviewerSelection
+	if len(pr.AssignedReviewers) == 0 {
+		selection, err = s.reviewersForPR(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.transitionPR(ctx, pr, en.StatusOpen, selection)
+}
+
+// markPullRequestReady переводит черновик в OPEN и назначает ревьюверов
+func (s *ServiceStorage) MarkPullRequestReady(ctx context.Context, prID string) (*en.PullRequest, error) {
+	pr, err := s.getExistingPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == en.StatusOpen {
+		return pr, nil
+	}
+	if pr.Status != en.StatusDraft {
+		return nil, en.NewInvalidTransitionError(prID, pr.Status, en.StatusOpen)
+	}
+
+	selection, err := s.reviewersForPR(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return s.transitionPR(ctx, pr, en.StatusOpen, selection)
+}
+
+func (s *ServiceStorage) getExistingPR(ctx context.Context, prID string) (*en.PullRequest, error) {
 	pr, err := s.storage.GetPR(ctx, prID)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "failed to get PR")
+		return nil, errors.Wrap(err, "failed to get PR")
 	}
 	if pr == nil {
-		return nil, "", en.NewNotFoundError("pull request", prID)
+		return nil, en.NewNotFoundError("pull request", prID)
 	}
+	return pr, nil
+}
 
-	if pr.Status == en.StatusMerged {
-		return nil, "", en.NewPRMergedError(prID)
+// reviewersForPR выбирает ревьюверов из команды PR; для PR без команды - из основной команды автора
+func (s *ServiceStorage) reviewersForPR(ctx context.Context, pr *en.PullRequest) (*reviewerSelection, error) {
+	if pr.TeamName != "" {
+		return s.selectInitialReviewers(ctx, pr, pr.TeamName)
+	}
+	author, err := s.storage.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get author")
+	}
+	if author == nil {
+		return nil, en.NewNotFoundError("author", pr.AuthorID)
+	}
+	return s.selectInitialReviewers(ctx, pr, author.TeamName)
+}
+
+// transitionPR проверяет переход по машине состояний и сохраняет новый статус.
+// Если статус PR успели изменить конкурентно, возвращается ошибка перехода
+func (s *ServiceStorage) transitionPR(ctx context.Context, pr *en.PullRequest, to en.PRStatus, selection *reviewerSelection) (*en.PullRequest, error) {
+	if err := validateTransition(pr, to); err != nil {
+		return nil, err
+	}
+
+	var reviewerIDs []string
+	var reviewerTeams, reviewerRules map[string]string
+	if selection != nil {
+		reviewerIDs, reviewerTeams, reviewerRules = selection.ids, selection.teams, selection.rules
+	}
+	updated, err := s.storage.UpdatePRStatus(ctx, pr.PullRequestID, pr.Status, to, time.Now().UTC(), reviewerIDs, reviewerTeams, reviewerRules)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update PR status")
+	}
+	if updated == nil {
+		return nil, en.NewInvalidTransitionError(pr.PullRequestID, pr.Status, to)
+	}
+	// уже назначенные ревьюверы повторно не добавляются
+	if added := len(updated.AssignedReviewers) - len(pr.AssignedReviewers); s.metrics != nil && added > 0 {
+		s.metrics.ReviewersAssigned(en.ReasonAutoAssign, added)
+	}
+	return updated, nil
+}
+
+// reassignReviewer заменяет ревьювера на активного участника команды PR, выбранного стратегией команды.
+// Если в команде нет кандидатов, замена ищется в ее резервных командах. Правила владения при замене не учитываются,
+// навыки - учитываются.
+// Для PR без команды, как и при ручном назначении, используется основная команда автора
+func (s *ServiceStorage) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*en.PullRequest, string, error) {
+	pr, err := s.openPRForReviewers(ctx, prID)
+	if err != nil {
+		return nil, "", err
 	}
 
 	isAssigned, err := s.storage.IsUserAssignedToReviewer(ctx, prID, oldUserID)
@@ -194,70 +747,104 @@ func (s *ServiceStorage) ReassignReviewer(ctx context.Context, prID, oldUserID s
 		return nil, "", en.NewNotAssignedError(oldUserID, prID)
 	}
 
-	oldUser, err := s.storage.GetUser(ctx, oldUserID)
+	teamName, err := s.prTeam(ctx, pr)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "failed to get old reviewer")
-	}
-	if oldUser == nil {
-		return nil, "", en.NewNotFoundError("user", oldUserID)
+		return nil, "", err
 	}
 
-	// получаем активных участников команды заменяемого ревьювера
-	teamMembers, err := s.storage.GetUsersByTeam(ctx, oldUser.TeamName, true)
+	// кандидаты ищутся в команде PR, а если в ней никого не осталось - в резервных командах по порядку
+	settings, err := s.teamSettings(ctx, teamName)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "failed to get team members")
-	}
-
-	// исключаем автора и уже назначенных ревьюверов
-	var candidates []*en.User
-	for _, member := range teamMembers {
-		if member.UserID == pr.AuthorID {
-			continue
+		return nil, "", err
+	}
+	exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	var newUserID, sourceTeam string
+	atCapacity := 0
+	for _, source := range append([]string{teamName}, settings.FallbackTeams...) {
+		candidates, full, err := s.teamCandidates(ctx, source, exclude)
+		if err != nil {
+			return nil, "", err
 		}
-		if contains(pr.AssignedReviewers, member.UserID) {
+		atCapacity += full
+		if len(candidates) == 0 {
 			continue
 		}
-		candidates = append(candidates, member)
-	}
 
-	if len(candidates) == 0 {
-		return nil, "", en.NewNoCandidateError(oldUser.TeamName)
+		picked, _, err := s.pickBySkills(ctx, source, candidates, pr.Labels, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		newUserID, sourceTeam = picked[0], source
+		break
+	}
+	if newUserID == "" {
+		en.LoggerFromContext(ctx).WarnContext(ctx, "no reviewer candidate",
+			"pull_request_id", prID, "old_reviewer", oldUserID, "team", teamName, "at_capacity", atCapacity)
+	}
+	if newUserID == "" && atCapacity > 0 {
+		return nil, "", en.NewNoCapacityError(teamName)
+	}
+	if newUserID == "" {
+		if s.metrics != nil {
+			s.metrics.NoCandidate()
+		}
+		return nil, "", en.NewNoCandidateError(teamName)
 	}
 
-	newUserID := candidates[rand.Intn(len(candidates))].UserID
-
-	err = s.storage.ReassignReviewer(ctx, prID, oldUserID, newUserID)
+	err = s.storage.ReassignReviewer(ctx, prID, oldUserID, newUserID, sourceTeam, 0)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "failed to reassign reviewer")
 	}
+	if s.metrics != nil {
+		s.metrics.ReviewersReassigned(en.ReasonManualReassign, 1)
+	}
+	en.LoggerFromContext(ctx).InfoContext(ctx, "reviewer reassigned",
+		"pull_request_id", prID, "old_reviewer", oldUserID, "new_reviewer", newUserID, "team", sourceTeam)
 
-	updatedPR, err := s.storage.GetPR(ctx, prID)
+	updatedPR, err := s.updatedPR(ctx, prID)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "failed to get updated PR")
+		return nil, "", err
 	}
-
 	return updatedPR, newUserID, nil
 }
 
-func selectRandomReviewers(candidates []*en.User, max int) []string {
-	if len(candidates) == 0 {
-		return []string{}
+// teamSettings возвращает настройки команды или настройки по умолчанию, если команда не найдена
+func (s *ServiceStorage) teamSettings(ctx context.Context, teamName string) (*en.TeamSettings, error) {
+	settings, err := s.storage.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get team settings")
 	}
+	if settings == nil {
+		return en.NewDefaultTeamSettings(teamName), nil
+	}
+	return settings, nil
+}
 
-	count := min(len(candidates), max)
-
-	shuffled := make([]*en.User, len(candidates))
-	copy(shuffled, candidates)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
+// pickReviewers выбирает до count ревьюверов стратегией команды, подгружая нагрузку кандидатов при необходимости
+func (s *ServiceStorage) pickReviewers(ctx context.Context, teamName string, candidates []*en.User, count int) ([]string, error) {
+	selector := s.selectors.ForTeam(teamName)
 
-	result := make([]string, count)
-	for i := 0; i < count; i++ {
-		result[i] = shuffled[i].UserID
+	var load map[string]int
+	if selector.UsesLoad() && len(candidates) > 0 {
+		ids := make([]string, len(candidates))
+		for i, c := range candidates {
+			ids[i] = c.UserID
+		}
+		var err error
+		load, err = s.storage.GetOpenReviewLoad(ctx, ids)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get reviewers load")
+		}
 	}
 
-	return result
+	picked := selector.Select(candidates, load, count)
+	logSelection(ctx, teamName, candidates, load, count, picked)
+	return picked, nil
+}
+
+// teamPicker функция выбора ревьюверов стратегией команды для хранилища
+func (s *ServiceStorage) teamPicker(ctx context.Context) func(teamName string) en.ReviewerPicker {
+	return loggedPicker(ctx, s.selectors)
 }
 
 func contains(slice []string, item string) bool {
@@ -277,6 +864,7 @@ func min(a, b int) int {
 }
 
 // DeactivateTeamMembers массово деактивирует пользователей команды и переназначает их открытые PR
+// во всех командах, из которых назначены ревью
 func (s *ServiceStorage) DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) (*en.DeactivateResult, error) {
 	if teamName == "" {
 		return nil, errors.New("team name cannot be empty")
@@ -285,10 +873,14 @@ func (s *ServiceStorage) DeactivateTeamMembers(ctx context.Context, teamName str
 		return nil, errors.New("user IDs cannot be empty")
 	}
 
-	result, err := s.storage.DeactivateTeamMembersWithReassignment(ctx, teamName, userIDs)
+	result, err := s.storage.DeactivateTeamMembersWithReassignment(ctx, teamName, userIDs, s.teamPicker(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to deactivate team members with reassignment")
 	}
+	if s.metrics != nil {
+		s.metrics.UsersDeactivated(len(result.DeactivatedUsers))
+	}
+	observeReassignments(s.metrics, en.ReasonReviewerDeactivated, result.Reassignments)
 
 	return result, nil
 }
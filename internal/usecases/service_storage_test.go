@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +14,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // 1. CreateTeam Tests
@@ -25,14 +33,15 @@ func TestCreateTeam_Success(t *testing.T) {
 	}
 
 	mockStorage.EXPECT().TeamExists(ctx, teamName).Return(false, nil).Once()
-	mockStorage.EXPECT().CreateTeamWithUsers(ctx, teamName, mock.AnythingOfType("[]*entities.User")).Return(nil).Once()
+	mockStorage.EXPECT().CreateTeamWithUsers(ctx, teamName, mock.AnythingOfType("*entities.TeamSettings"), mock.AnythingOfType("[]*entities.User"), en.TeamCreateOptions{ConflictMode: en.ConflictMove}, mock.Anything).
+		Return(&en.TeamCreateResult{}, nil).Once()
 
-	team, err := service.CreateTeam(ctx, teamName, members)
+	result, err := service.CreateTeam(ctx, teamName, members, nil, en.TeamCreateOptions{})
 
 	require.NoError(t, err)
-	assert.NotNil(t, team)
-	assert.Equal(t, teamName, team.TeamName)
-	assert.Len(t, team.TeamMembers, 2)
+	require.NotNil(t, result.Team)
+	assert.Equal(t, teamName, result.Team.TeamName)
+	assert.Len(t, result.Team.TeamMembers, 2)
 }
 
 func TestCreateTeam_EmptyTeamName(t *testing.T) {
@@ -44,7 +53,7 @@ func TestCreateTeam_EmptyTeamName(t *testing.T) {
 		{UserID: "u1", Username: "Alice", IsActive: true},
 	}
 
-	team, err := service.CreateTeam(ctx, "", members)
+	team, err := service.CreateTeam(ctx, "", members, nil, en.TeamCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, team)
@@ -58,7 +67,7 @@ func TestCreateTeam_NoMembers(t *testing.T) {
 	ctx := context.Background()
 	teamName := "backend"
 
-	team, err := service.CreateTeam(ctx, teamName, []en.TeamMember{})
+	team, err := service.CreateTeam(ctx, teamName, []en.TeamMember{}, nil, en.TeamCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, team)
@@ -77,7 +86,7 @@ func TestCreateTeam_AlreadyExists(t *testing.T) {
 
 	mockStorage.EXPECT().TeamExists(ctx, teamName).Return(true, nil).Once()
 
-	team, err := service.CreateTeam(ctx, teamName, members)
+	team, err := service.CreateTeam(ctx, teamName, members, nil, en.TeamCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, team)
@@ -97,9 +106,9 @@ func TestCreateTeam_StorageError(t *testing.T) {
 	}
 
 	mockStorage.EXPECT().TeamExists(ctx, teamName).Return(false, nil).Once()
-	mockStorage.EXPECT().CreateTeamWithUsers(ctx, teamName, mock.Anything).Return(errors.New("storage error")).Once()
+	mockStorage.EXPECT().CreateTeamWithUsers(ctx, teamName, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("storage error")).Once()
 
-	team, err := service.CreateTeam(ctx, teamName, members)
+	team, err := service.CreateTeam(ctx, teamName, members, nil, en.TeamCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, team)
@@ -287,13 +296,14 @@ func TestCreatePR_Success_TwoReviewers(t *testing.T) {
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(false, nil).Once()
 	mockStorage.EXPECT().GetUser(ctx, authorID).Return(author, nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
 	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.MatchedBy(func(pr *en.PullRequest) bool {
 		return pr.PullRequestID == prID && pr.PullRequestName == prName && pr.AuthorID == authorID
 	}), mock.MatchedBy(func(reviewers []string) bool {
 		return len(reviewers) == 2
 	})).Return(nil).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID)
+	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID, en.PRCreateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, pr)
@@ -317,11 +327,12 @@ func TestCreatePR_Success_OneReviewer(t *testing.T) {
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(false, nil).Once()
 	mockStorage.EXPECT().GetUser(ctx, authorID).Return(author, nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
 	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.MatchedBy(func(reviewers []string) bool {
 		return len(reviewers) == 1
 	})).Return(nil).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID)
+	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID, en.PRCreateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, pr)
@@ -341,11 +352,12 @@ func TestCreatePR_Success_NoReviewers(t *testing.T) {
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(false, nil).Once()
 	mockStorage.EXPECT().GetUser(ctx, authorID).Return(author, nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{}, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
 	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.MatchedBy(func(reviewers []string) bool {
 		return len(reviewers) == 0
 	})).Return(nil).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID)
+	pr, err := service.CreatePullRequest(ctx, prID, prName, authorID, en.PRCreateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, pr)
@@ -370,7 +382,7 @@ func TestCreatePR_EmptyFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pr, err := service.CreatePullRequest(ctx, tt.prID, tt.prName, tt.authorID)
+			pr, err := service.CreatePullRequest(ctx, tt.prID, tt.prName, tt.authorID, en.PRCreateOptions{})
 			require.Error(t, err)
 			assert.Nil(t, pr)
 			assert.Contains(t, err.Error(), "cannot be empty")
@@ -387,7 +399,7 @@ func TestCreatePR_AlreadyExists(t *testing.T) {
 
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(true, nil).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, "Feature", "u1")
+	pr, err := service.CreatePullRequest(ctx, prID, "Feature", "u1", en.PRCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, pr)
@@ -407,7 +419,7 @@ func TestCreatePR_AuthorNotFound(t *testing.T) {
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(false, nil).Once()
 	mockStorage.EXPECT().GetUser(ctx, authorID).Return(nil, nil).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, "Feature", authorID)
+	pr, err := service.CreatePullRequest(ctx, prID, "Feature", authorID, en.PRCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, pr)
@@ -429,9 +441,10 @@ func TestCreatePR_StorageError(t *testing.T) {
 	mockStorage.EXPECT().PRExists(ctx, prID).Return(false, nil).Once()
 	mockStorage.EXPECT().GetUser(ctx, authorID).Return(author, nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{}, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
 	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(errors.New("storage error")).Once()
 
-	pr, err := service.CreatePullRequest(ctx, prID, "Feature", authorID)
+	pr, err := service.CreatePullRequest(ctx, prID, "Feature", authorID, en.PRCreateOptions{})
 
 	require.Error(t, err)
 	assert.Nil(t, pr)
@@ -548,7 +561,7 @@ func TestReassignReviewer_Success(t *testing.T) {
 		AuthorID:          "u1",
 		AssignedReviewers: []string{oldUserID, "u3"},
 	}
-	oldUser := &en.User{UserID: oldUserID, Username: "Bob", TeamName: "backend", IsActive: true}
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
 	candidates := []*en.User{
 		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
 		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
@@ -563,9 +576,10 @@ func TestReassignReviewer_Success(t *testing.T) {
 
 	mockStorage.EXPECT().GetPR(ctx, prID).Return(pr, nil).Once()
 	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, prID, oldUserID).Return(true, nil).Once()
-	mockStorage.EXPECT().GetUser(ctx, oldUserID).Return(oldUser, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
-	mockStorage.EXPECT().ReassignReviewer(ctx, prID, oldUserID, mock.AnythingOfType("string")).Return(nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, prID, oldUserID, mock.AnythingOfType("string"), "backend", 0).Return(nil).Once()
 	mockStorage.EXPECT().GetPR(ctx, prID).Return(updatedPR, nil).Once()
 
 	result, newReviewerID, err := service.ReassignReviewer(ctx, prID, oldUserID)
@@ -643,11 +657,12 @@ func TestReassignReviewer_NoCandidate(t *testing.T) {
 		AuthorID:          "u1",
 		AssignedReviewers: []string{oldUserID},
 	}
-	oldUser := &en.User{UserID: oldUserID, Username: "Bob", TeamName: "small-team", IsActive: true}
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "small-team", IsActive: true}
 
 	mockStorage.EXPECT().GetPR(ctx, prID).Return(pr, nil).Once()
 	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, prID, oldUserID).Return(true, nil).Once()
-	mockStorage.EXPECT().GetUser(ctx, oldUserID).Return(oldUser, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "small-team").Return(en.NewDefaultTeamSettings("small-team"), nil).Once()
 	mockStorage.EXPECT().GetUsersByTeam(ctx, "small-team", true).Return([]*en.User{}, nil).Once()
 
 	result, newReviewerID, err := service.ReassignReviewer(ctx, prID, oldUserID)
@@ -680,24 +695,24 @@ func TestReassignReviewer_PRNotFound(t *testing.T) {
 	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
 }
 
-func TestReassignReviewer_UserNotFound(t *testing.T) {
+func TestReassignReviewer_AuthorNotFound(t *testing.T) {
 	mockStorage := NewMockStorage(t)
 	service := &ServiceStorage{storage: mockStorage}
 
 	ctx := context.Background()
 	prID := "pr-1"
-	oldUserID := "nonexistent"
+	oldUserID := "u2"
 
 	pr := &en.PullRequest{
 		PullRequestID:     prID,
 		Status:            en.StatusOpen,
-		AuthorID:          "u1",
-		AssignedReviewers: []string{"u2"},
+		AuthorID:          "ghost",
+		AssignedReviewers: []string{oldUserID},
 	}
 
 	mockStorage.EXPECT().GetPR(ctx, prID).Return(pr, nil).Once()
 	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, prID, oldUserID).Return(true, nil).Once()
-	mockStorage.EXPECT().GetUser(ctx, oldUserID).Return(nil, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
 
 	result, newReviewerID, err := service.ReassignReviewer(ctx, prID, oldUserID)
 
@@ -708,3 +723,2229 @@ func TestReassignReviewer_UserNotFound(t *testing.T) {
 	require.ErrorAs(t, err, &appErr)
 	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
 }
+
+// 8. TeamSettings Tests
+func TestCreatePR_NotEnoughReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*en.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}
+	settings := &en.TeamSettings{TeamName: "backend", MinReviewers: 2, MaxReviewers: 3}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotEnoughReviewers, appErr.Code)
+}
+
+func TestCreatePR_UsesTeamMaxReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*en.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true},
+	}
+	settings := &en.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 3}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.MatchedBy(func(reviewers []string) bool {
+		return len(reviewers) == 3
+	})).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Len(t, pr.AssignedReviewers, 3)
+}
+
+func TestCreateTeam_InvalidSettings(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	members := []en.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+	}
+
+	team, err := service.CreateTeam(ctx, "backend", members, &en.TeamSettings{MinReviewers: 3, MaxReviewers: 2}, en.TeamCreateOptions{})
+
+	require.Error(t, err)
+	assert.Nil(t, team)
+	assert.Contains(t, err.Error(), "min_reviewers")
+}
+
+func TestUpdateTeamSettings_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := &en.TeamSettingsUpdate{TeamName: "backend", MinReviewers: intPtr(1), MaxReviewers: intPtr(3)}
+	stored := &en.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 3}
+
+	mockStorage.EXPECT().UpdateTeamSettings(ctx, settings).Return(stored, nil).Once()
+
+	updated, err := service.UpdateTeamSettings(ctx, settings)
+
+	require.NoError(t, err)
+	assert.Equal(t, stored, updated)
+}
+
+func TestUpdateTeamSettings_PartialReviewersRange(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	// max_reviewers не передан: проверку min <= max выполняет хранилище по текущим настройкам
+	settings := &en.TeamSettingsUpdate{TeamName: "backend", MinReviewers: intPtr(3)}
+
+	mockStorage.EXPECT().UpdateTeamSettings(ctx, settings).Return(nil, en.ErrReviewersRange).Once()
+
+	updated, err := service.UpdateTeamSettings(ctx, settings)
+
+	require.ErrorIs(t, err, en.ErrReviewersRange)
+	assert.Nil(t, updated)
+}
+
+func TestUpdateTeamSettings_InvalidReviewersRange(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	for _, settings := range []*en.TeamSettingsUpdate{
+		{TeamName: "backend", MinReviewers: intPtr(-1)},
+		{TeamName: "backend", MaxReviewers: intPtr(0)},
+		{TeamName: "backend", MinReviewers: intPtr(3), MaxReviewers: intPtr(2)},
+	} {
+		_, err := service.UpdateTeamSettings(context.Background(), settings)
+
+		require.Error(t, err)
+	}
+}
+
+func TestUpdateTeamSettings_NotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := &en.TeamSettingsUpdate{TeamName: "ghost", MinReviewers: intPtr(0), MaxReviewers: intPtr(2)}
+
+	mockStorage.EXPECT().UpdateTeamSettings(ctx, settings).Return(nil, nil).Once()
+
+	updated, err := service.UpdateTeamSettings(ctx, settings)
+
+	require.Error(t, err)
+	assert.Nil(t, updated)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 9. PR lifecycle Tests
+func TestCreatePR_Draft(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.MatchedBy(func(pr *en.PullRequest) bool {
+		return pr.Status == en.StatusDraft
+	}), []string{}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{Draft: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusDraft, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func TestMarkPRReady_AssignsReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	draft := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusDraft}
+	author := &en.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	members := []*en.User{author, {UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}}
+	ready := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(draft, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().UpdatePRStatus(ctx, "pr-1", en.StatusDraft, en.StatusOpen, mock.AnythingOfType("time.Time"), []string{"u2"}, map[string]string{"u2": "backend"}, map[string]string(nil)).
+		Return(ready, nil).Once()
+
+	pr, err := service.MarkPullRequestReady(ctx, "pr-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusOpen, pr.Status)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestClosePR_Merged(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	merged := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusMerged}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(merged, nil).Once()
+
+	pr, err := service.ClosePullRequest(ctx, "pr-1")
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodePRMerged, appErr.Code)
+}
+
+func TestClosePR_ConcurrentStatusChange(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	open := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(open, nil).Once()
+	mockStorage.EXPECT().UpdatePRStatus(ctx, "pr-1", en.StatusOpen, en.StatusClosed, mock.AnythingOfType("time.Time"), []string(nil), map[string]string(nil), map[string]string(nil)).
+		Return(nil, nil).Once()
+
+	pr, err := service.ClosePullRequest(ctx, "pr-1")
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeInvalidTransition, appErr.Code)
+}
+
+func TestReopenPR_KeepsReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	closed := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusClosed, AssignedReviewers: []string{"u2"}}
+	reopened := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(closed, nil).Once()
+	mockStorage.EXPECT().UpdatePRStatus(ctx, "pr-1", en.StatusClosed, en.StatusOpen, mock.AnythingOfType("time.Time"), []string(nil), map[string]string(nil), map[string]string(nil)).
+		Return(reopened, nil).Once()
+
+	pr, err := service.ReopenPullRequest(ctx, "pr-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusOpen, pr.Status)
+}
+
+func TestMergePR_Draft(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	draft := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusDraft}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(draft, nil).Once()
+
+	pr, err := service.MergePullRequest(ctx, "pr-1")
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeInvalidTransition, appErr.Code)
+}
+
+// 10. PR listing Tests
+func TestGetPullRequest_NotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetPR(ctx, "pr-404").Return(nil, nil).Once()
+
+	pr, err := service.GetPullRequest(ctx, "pr-404")
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestListPullRequests_Defaults(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	expected := en.PRListFilter{
+		Status: en.StatusOpen,
+		SortBy: en.PRSortCreatedAt,
+		Order:  en.SortDesc,
+		Limit:  defaultPRListLimit + 1,
+	}
+	mockStorage.EXPECT().ListPRs(ctx, expected).Return(nil, nil).Once()
+
+	page, err := service.ListPullRequests(ctx, en.PRListFilter{Status: en.StatusOpen})
+
+	require.NoError(t, err)
+	assert.NotNil(t, page.PullRequests)
+	assert.Empty(t, page.PullRequests)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestListPullRequests_CursorRoundTrip(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	created := time.Date(2025, 11, 16, 10, 0, 0, 123000, time.UTC)
+	prs := []*en.PullRequest{
+		{PullRequestID: "pr-3", CreatedAt: created.Add(2 * time.Minute)},
+		{PullRequestID: "pr-2", CreatedAt: created},
+		{PullRequestID: "pr-1", CreatedAt: created.Add(-time.Minute)},
+	}
+	mockStorage.EXPECT().ListPRs(ctx, mock.MatchedBy(func(f en.PRListFilter) bool { return f.After == nil })).
+		Return(prs, nil).Once()
+
+	page, err := service.ListPullRequests(ctx, en.PRListFilter{Limit: 2})
+
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	mockStorage.EXPECT().ListPRs(ctx, mock.MatchedBy(func(f en.PRListFilter) bool {
+		return f.After != nil && f.After.PullRequestID == "pr-2" && f.After.SortValue == "2025-11-16T10:00:00.000123Z"
+	})).Return(prs[2:], nil).Once()
+
+	page, err = service.ListPullRequests(ctx, en.PRListFilter{Limit: 2, Cursor: page.NextCursor})
+
+	require.NoError(t, err)
+	assert.Len(t, page.PullRequests, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestListPullRequests_InvalidFilter(t *testing.T) {
+	service := &ServiceStorage{storage: NewMockStorage(t)}
+	ctx := context.Background()
+	from := time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	filters := []en.PRListFilter{
+		{Status: "UNKNOWN"},
+		{SortBy: "author_id"},
+		{Order: "up"},
+		{Limit: maxPRListLimit + 1},
+		{CreatedFrom: &from, CreatedTo: &to},
+		{Cursor: "not-a-cursor"},
+		{SortBy: en.PRSortName, Cursor: encodePRCursor(en.PRSortCreatedAt, &en.PullRequest{PullRequestID: "pr-1"})},
+	}
+	for _, f := range filters {
+		_, err := service.ListPullRequests(ctx, f)
+		assert.Error(t, err)
+	}
+}
+
+// 11. Review Tests
+func TestSubmitReview_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().CreateReview(ctx, mock.MatchedBy(func(r *en.Review) bool {
+		return r.ReviewerID == "u2" && r.Verdict == en.VerdictApproved && r.Comment == "lgtm"
+	})).Return(nil).Once()
+
+	review, err := service.SubmitReview(ctx, "pr-1", "u2", en.VerdictApproved, "lgtm")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.VerdictApproved, review.Verdict)
+}
+
+func TestSubmitReview_NotAssigned(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+
+	review, err := service.SubmitReview(ctx, "pr-1", "u3", en.VerdictApproved, "")
+
+	require.Error(t, err)
+	assert.Nil(t, review)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotAssigned, appErr.Code)
+}
+
+func TestSubmitReview_InvalidVerdict(t *testing.T) {
+	service := &ServiceStorage{storage: NewMockStorage(t)}
+
+	review, err := service.SubmitReview(context.Background(), "pr-1", "u2", "LGTM", "")
+
+	require.Error(t, err)
+	assert.Nil(t, review)
+}
+
+func TestMergePR_BlockedByPolicy(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage, mergePolicy: MergePolicy{RequiredApprovals: 2}}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().GetPRReviews(ctx, "pr-1").Return([]*en.Review{
+		{ReviewerID: "u2", Verdict: en.VerdictApproved},
+	}, nil).Once()
+
+	merged, err := service.MergePullRequest(ctx, "pr-1")
+
+	require.Error(t, err)
+	assert.Nil(t, merged)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeMergeBlocked, appErr.Code)
+}
+
+func TestMergePR_AllowedByPolicy(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage, mergePolicy: MergePolicy{RequiredApprovals: 1, BlockOnChangesRequested: true}}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	mergedPR := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusMerged, AssignedReviewers: []string{"u2", "u3"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().GetPRReviews(ctx, "pr-1").Return([]*en.Review{
+		{ReviewerID: "u3", Verdict: en.VerdictChangesRequested},
+		{ReviewerID: "u2", Verdict: en.VerdictApproved},
+		{ReviewerID: "u3", Verdict: en.VerdictApproved},
+	}, nil).Once()
+	mockStorage.EXPECT().MergePR(ctx, "pr-1", mock.AnythingOfType("time.Time")).Return(mergedPR, nil).Once()
+
+	merged, err := service.MergePullRequest(ctx, "pr-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusMerged, merged.Status)
+}
+
+func TestMergePR_RequiredApprovalsCappedByReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage, mergePolicy: MergePolicy{RequiredApprovals: 2}}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen, AssignedReviewers: []string{"u2"}}
+	mergedPR := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusMerged, AssignedReviewers: []string{"u2"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().GetPRReviews(ctx, "pr-1").Return([]*en.Review{
+		{ReviewerID: "u2", Verdict: en.VerdictApproved},
+	}, nil).Once()
+	mockStorage.EXPECT().MergePR(ctx, "pr-1", mock.AnythingOfType("time.Time")).Return(mergedPR, nil).Once()
+
+	merged, err := service.MergePullRequest(ctx, "pr-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusMerged, merged.Status)
+}
+
+// 12. PR history Tests
+func TestGetPullRequestHistory_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: en.StatusOpen}
+	events := []*en.PREvent{
+		{EventID: 1, PullRequestID: "pr-1", Type: en.EventPRCreated, Actor: en.SystemActor, Reason: en.ReasonCreated},
+		{EventID: 2, PullRequestID: "pr-1", Type: en.EventReviewerReassigned, Actor: "u1", Reason: en.ReasonManualReassign, OldReviewerID: "u2", NewReviewerID: "u3"},
+	}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().GetPREvents(ctx, "pr-1").Return(events, nil).Once()
+
+	history, err := service.GetPullRequestHistory(ctx, "pr-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, events, history)
+}
+
+func TestGetPullRequestHistory_PRNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetPR(ctx, "pr-404").Return(nil, nil).Once()
+
+	history, err := service.GetPullRequestHistory(ctx, "pr-404")
+
+	require.Error(t, err)
+	assert.Nil(t, history)
+}
+
+// 13. Webhook subscription Tests
+func TestCreateWebhookSubscription_Validation(t *testing.T) {
+	service := &ServiceStorage{storage: NewMockStorage(t)}
+	ctx := context.Background()
+
+	invalid := []*en.WebhookSubscription{
+		{URL: "ftp://hooks.example.com", Secret: "s"},
+		{URL: "https://", Secret: "s"},
+		{URL: "https://hooks.example.com"},
+		{URL: "https://hooks.example.com", Secret: "s", EventTypes: []en.PREventType{"PR_DELETED"}},
+	}
+	for _, sub := range invalid {
+		_, err := service.CreateWebhookSubscription(ctx, sub)
+		assert.Error(t, err)
+	}
+}
+
+func TestCreateWebhookSubscription_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	sub := &en.WebhookSubscription{URL: "https://hooks.example.com/pr", Secret: "s3cr3t", IsActive: true}
+	created := &en.WebhookSubscription{SubscriptionID: 1, URL: sub.URL, Secret: sub.Secret, EventTypes: []en.PREventType{}, IsActive: true}
+
+	mockStorage.EXPECT().CreateWebhookSubscription(ctx, sub).Return(created, nil).Once()
+
+	result, err := service.CreateWebhookSubscription(ctx, sub)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.SubscriptionID)
+	assert.NotNil(t, sub.EventTypes)
+}
+
+func TestRedeliverWebhook_NotDead(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().RequeueWebhookDelivery(ctx, int64(7), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+	err := service.RedeliverWebhook(ctx, 7)
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 14. Git events Tests
+func TestHandleGitEvent_OpenedCreatesPR(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	event := en.GitPREvent{
+		Provider: en.GitProviderGitHub, Action: en.GitActionOpened, Repository: "org/repo", Number: 42,
+		Title: "Add search", AuthorLogin: "alice-gh", SenderLogin: "alice-gh", Draft: true,
+	}
+	author := &en.User{UserID: "u1", TeamName: "backend", IsActive: true}
+
+	mockStorage.EXPECT().PRExists(mock.Anything, "github:org/repo#42").Return(false, nil).Twice()
+	mockStorage.EXPECT().GetUserIDByExternalLogin(mock.Anything, en.GitProviderGitHub, "alice-gh").Return("u1", nil).Once()
+	mockStorage.EXPECT().GetUser(mock.Anything, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(mock.MatchedBy(func(c context.Context) bool {
+		return en.ActorFromContext(c) == "github:alice-gh"
+	}), mock.Anything, []string{}).Return(nil).Once()
+
+	result, err := service.HandleGitEvent(ctx, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, en.GitEventProcessed, result.Status)
+	assert.Equal(t, "github:org/repo#42", result.PR.PullRequestID)
+	assert.Equal(t, en.StatusDraft, result.PR.Status)
+}
+
+func TestHandleGitEvent_OpenedRedelivery(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	event := en.GitPREvent{Provider: en.GitProviderGitLab, Action: en.GitActionOpened, Repository: "org/repo", Number: 7, AuthorLogin: "bob"}
+
+	mockStorage.EXPECT().PRExists(ctx, "gitlab:org/repo#7").Return(true, nil).Once()
+
+	result, err := service.HandleGitEvent(ctx, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, en.GitEventIgnored, result.Status)
+}
+
+func TestHandleGitEvent_UnknownAuthor(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	event := en.GitPREvent{Provider: en.GitProviderGitHub, Action: en.GitActionOpened, Repository: "org/repo", Number: 1, AuthorLogin: "ghost"}
+
+	mockStorage.EXPECT().PRExists(ctx, "github:org/repo#1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUserIDByExternalLogin(ctx, en.GitProviderGitHub, "ghost").Return("", nil).Once()
+
+	result, err := service.HandleGitEvent(ctx, event)
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestHandleGitEvent_MergedSkipsPolicy(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage, mergePolicy: MergePolicy{RequiredApprovals: 2}}
+
+	ctx := context.Background()
+	event := en.GitPREvent{Provider: en.GitProviderGitHub, Action: en.GitActionMerged, Repository: "org/repo", Number: 42}
+	pr := &en.PullRequest{PullRequestID: "github:org/repo#42", Status: en.StatusOpen}
+	merged := &en.PullRequest{PullRequestID: "github:org/repo#42", Status: en.StatusMerged}
+
+	mockStorage.EXPECT().GetPR(ctx, "github:org/repo#42").Return(pr, nil).Once()
+	mockStorage.EXPECT().MergePR(ctx, "github:org/repo#42", mock.AnythingOfType("time.Time")).Return(merged, nil).Once()
+
+	result, err := service.HandleGitEvent(ctx, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, en.StatusMerged, result.PR.Status)
+}
+
+func TestHandleGitEvent_UnsupportedAction(t *testing.T) {
+	service := &ServiceStorage{storage: NewMockStorage(t)}
+
+	result, err := service.HandleGitEvent(context.Background(), en.GitPREvent{
+		Provider: en.GitProviderGitHub, Action: "labeled", Repository: "org/repo", Number: 1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, en.GitEventIgnored, result.Status)
+}
+
+// 15. API token Tests
+func TestIssueAPIToken_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	var storedHash string
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", IsActive: true}, nil).Once()
+	mockStorage.EXPECT().CreateAPIToken(ctx, &en.APIToken{UserID: "u1", Role: en.RoleUser}, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, token *en.APIToken, hash string) (*en.APIToken, error) {
+			storedHash = hash
+			return &en.APIToken{TokenID: 1, UserID: token.UserID, Role: token.Role}, nil
+		}).Once()
+
+	token, err := service.IssueAPIToken(ctx, "u1", "")
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token.Token, apiTokenPrefix))
+	assert.Equal(t, hashAPIToken(token.Token), storedHash)
+}
+
+func TestIssueAPIToken_UnknownRole(t *testing.T) {
+	service := &ServiceStorage{storage: NewMockStorage(t)}
+
+	token, err := service.IssueAPIToken(context.Background(), "u1", "root")
+
+	require.Error(t, err)
+	assert.Nil(t, token)
+}
+
+func TestAuthenticateAPIToken(t *testing.T) {
+	revokedAt := time.Now()
+	tests := []struct {
+		name     string
+		token    *en.APIToken
+		user     *en.User
+		wantErr  bool
+		wantRole en.Role
+	}{
+		{name: "valid", token: &en.APIToken{UserID: "u1", Role: en.RoleAdmin}, user: &en.User{UserID: "u1", IsActive: true}, wantRole: en.RoleAdmin},
+		{name: "unknown", wantErr: true},
+		{name: "revoked", token: &en.APIToken{UserID: "u1", Role: en.RoleUser, RevokedAt: &revokedAt}, wantErr: true},
+		{name: "inactive owner", token: &en.APIToken{UserID: "u1", Role: en.RoleUser}, user: &en.User{UserID: "u1", IsActive: false}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			service := &ServiceStorage{storage: mockStorage}
+			ctx := context.Background()
+
+			mockStorage.EXPECT().GetAPITokenByHash(ctx, hashAPIToken("prs_abc")).Return(tt.token, nil).Once()
+			if tt.token != nil && tt.token.RevokedAt == nil {
+				mockStorage.EXPECT().GetUser(ctx, "u1").Return(tt.user, nil).Once()
+			}
+
+			principal, err := service.AuthenticateAPIToken(ctx, "prs_abc")
+
+			if tt.wantErr {
+				var appErr *en.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, en.ErrCodeUnauthorized, appErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "u1", principal.UserID)
+			assert.Equal(t, tt.wantRole, principal.Role)
+		})
+	}
+}
+
+func TestRevokeAPIToken_NotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().RevokeAPIToken(ctx, int64(9), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+	err := service.RevokeAPIToken(ctx, 9)
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 16. Team membership Tests
+func TestAddTeamMembers_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	members := []en.TeamMember{{UserID: "u3", Username: "Carol", IsActive: true}}
+	team := &en.Team{TeamName: "backend", TeamMembers: []en.TeamMember{{UserID: "u1"}, {UserID: "u3"}}}
+
+	mockStorage.EXPECT().AddTeamMembers(ctx, "backend", []*en.User{
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+	}).Return(nil).Once()
+	mockStorage.EXPECT().GetTeamByName(ctx, "backend").Return(team, nil).Once()
+
+	result, err := service.AddTeamMembers(ctx, "backend", members)
+
+	require.NoError(t, err)
+	assert.Len(t, result.TeamMembers, 2)
+}
+
+func TestMoveTeamMember_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	infos := []en.PRReassignmentInfo{{PullRequestID: "pr-1", OldReviewer: "u2", NewReviewer: "u3"}}
+
+	mockStorage.EXPECT().GetUser(ctx, "u2").Return(&en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().TeamExists(ctx, "platform").Return(true, nil).Once()
+	mockStorage.EXPECT().MoveTeamMember(ctx, "u2", "backend", "platform", mock.Anything).Return(infos, nil).Once()
+
+	result, err := service.MoveTeamMember(ctx, "u2", "", "platform")
+
+	require.NoError(t, err)
+	assert.Equal(t, "backend", result.FromTeam)
+	assert.Equal(t, "platform", result.ToTeam)
+	assert.Equal(t, infos, result.Reassignments)
+}
+
+func TestMoveTeamMember_SameTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "u2").Return(&en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend"}}, nil).Once()
+
+	result, err := service.MoveTeamMember(ctx, "u2", "", "backend")
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestMoveTeamMember_TargetNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "u2").Return(&en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend"}}, nil).Once()
+	mockStorage.EXPECT().TeamExists(ctx, "nope").Return(false, nil).Once()
+
+	_, err := service.MoveTeamMember(ctx, "u2", "", "nope")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestRenameTeam_TargetExists(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().TeamExists(ctx, "platform").Return(true, nil).Once()
+
+	_, err := service.RenameTeam(ctx, "backend", "platform")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeTeamExists, appErr.Code)
+}
+
+func TestDeleteTeam_NotEmpty(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().DeleteTeam(ctx, "backend").Return(false, en.NewTeamNotEmptyError("backend", 2)).Once()
+
+	err := service.DeleteTeam(ctx, "backend")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeTeamNotEmpty, appErr.Code)
+}
+
+// 17. Team conflict Tests
+func TestCreateTeam_RejectModePassedToStorage(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	members := []en.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}
+	conflictErr := en.NewTeamMemberConflictError("frontend", []en.TeamMemberConflict{
+		{UserID: "u1", CurrentTeam: "backend", OpenReviews: []string{"pr-1"}},
+	})
+
+	mockStorage.EXPECT().TeamExists(ctx, "frontend").Return(false, nil).Once()
+	mockStorage.EXPECT().CreateTeamWithUsers(ctx, "frontend", mock.Anything, mock.Anything, en.TeamCreateOptions{ConflictMode: en.ConflictReject}, mock.Anything).
+		Return(nil, conflictErr).Once()
+
+	result, err := service.CreateTeam(ctx, "frontend", members, nil, en.TeamCreateOptions{ConflictMode: en.ConflictReject})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeTeamMemberConflict, appErr.Code)
+	assert.Contains(t, appErr.Message, "u1 (backend, 1 open reviews)")
+}
+
+func TestCreateTeam_DryRunReturnsPlan(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	members := []en.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}
+	planned := &en.TeamCreateResult{
+		Conflicts:     []en.TeamMemberConflict{{UserID: "u1", CurrentTeam: "backend", OpenReviews: []string{"pr-1"}}},
+		Reassignments: []en.PRReassignmentInfo{{PullRequestID: "pr-1", OldReviewer: "u1", NewReviewer: "u2"}},
+		DryRun:        true,
+	}
+
+	mockStorage.EXPECT().TeamExists(ctx, "frontend").Return(false, nil).Once()
+	mockStorage.EXPECT().CreateTeamWithUsers(ctx, "frontend", mock.Anything, mock.Anything, en.TeamCreateOptions{ConflictMode: en.ConflictDryRun}, mock.Anything).
+		Return(planned, nil).Once()
+
+	result, err := service.CreateTeam(ctx, "frontend", members, nil, en.TeamCreateOptions{ConflictMode: en.ConflictDryRun})
+
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.Conflicts, 1)
+	assert.Len(t, result.Reassignments, 1)
+	assert.Equal(t, "frontend", result.Team.TeamName)
+}
+
+func TestCreateTeam_UnknownConflictMode(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	members := []en.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}
+
+	result, err := service.CreateTeam(context.Background(), "frontend", members, nil, en.TeamCreateOptions{ConflictMode: "steal"})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "unknown conflict mode")
+}
+
+// 18. Multi-team Tests
+func TestCreatePR_ChosenTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend", "guild"}, IsActive: true}
+	candidates := []*en.User{
+		{UserID: "g1", TeamName: "frontend", IsActive: true},
+		{UserID: "g2", TeamName: "guild", IsActive: true},
+	}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "guild", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "guild").Return(en.NewDefaultTeamSettings("guild"), nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.MatchedBy(func(pr *en.PullRequest) bool {
+		return pr.TeamName == "guild"
+	}), mock.MatchedBy(func(reviewers []string) bool {
+		return len(reviewers) == 2
+	})).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Guild", "u1", en.PRCreateOptions{TeamName: "guild"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "guild", pr.TeamName)
+	assert.ElementsMatch(t, []string{"g1", "g2"}, pr.AssignedReviewers)
+}
+
+func TestCreatePR_DefaultsToPrimaryTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend", "guild"}, IsActive: true}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{{UserID: "u2", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u2"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "backend", pr.TeamName)
+}
+
+func TestCreatePR_AuthorNotInChosenTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{TeamName: "guild"})
+
+	require.Error(t, err)
+	assert.Nil(t, pr)
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeInvalidTeamUser, appErr.Code)
+}
+
+func TestReassignReviewer_UsesPRTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, TeamName: "guild"}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "guild").Return(en.NewDefaultTeamSettings("guild"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "guild", true).Return([]*en.User{{UserID: "g1", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "g1", "guild", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+
+	_, newReviewerID, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "g1", newReviewerID)
+}
+
+func TestReassignReviewer_TeamlessPRUsesAuthorTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	// команду PR удалили: замена ищется в основной команде автора, а не заменяемого, как и при ручном назначении
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{{UserID: "b1", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "b1", "backend", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+
+	_, newReviewerID, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "b1", newReviewerID)
+}
+
+func TestMoveTeamMember_FromSecondaryTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	user := &en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend", "guild"}, IsActive: true}
+
+	mockStorage.EXPECT().GetUser(ctx, "u2").Return(user, nil).Once()
+	mockStorage.EXPECT().TeamExists(ctx, "platform").Return(true, nil).Once()
+	mockStorage.EXPECT().MoveTeamMember(ctx, "u2", "guild", "platform", mock.Anything).Return([]en.PRReassignmentInfo{}, nil).Once()
+
+	result, err := service.MoveTeamMember(ctx, "u2", "guild", "platform")
+
+	require.NoError(t, err)
+	assert.Equal(t, "guild", result.FromTeam)
+}
+
+func TestMoveTeamMember_NotInSourceTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	user := &en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+
+	mockStorage.EXPECT().GetUser(ctx, "u2").Return(user, nil).Once()
+
+	_, err := service.MoveTeamMember(ctx, "u2", "guild", "platform")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeInvalidTeamUser, appErr.Code)
+}
+
+// 19. Reviewer fallback Tests
+func TestCreatePR_FallbackTeamFillsReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"platform", "infra"}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{author}, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "platform", true).Return([]*en.User{{UserID: "p1", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "infra", true).Return([]*en.User{{UserID: "i1", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.MatchedBy(func(pr *en.PullRequest) bool {
+		return pr.TeamName == "backend" && pr.ReviewerTeams["p1"] == "platform" && pr.ReviewerTeams["i1"] == "infra"
+	}), []string{"p1", "i1"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1", "i1"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"p1": "platform", "i1": "infra"}, pr.ReviewerTeams)
+}
+
+func TestCreatePR_FallbackNotUsedWhenTeamIsEnough(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	members := []*en.User{author, {UserID: "u2", IsActive: true}, {UserID: "u3", IsActive: true}}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"platform"}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"u2": "backend", "u3": "backend"}, pr.ReviewerTeams)
+}
+
+func TestCreatePR_FallbackSkipsAuthorAndPicked(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend", "platform"}, IsActive: true}
+	reviewer := &en.User{UserID: "u2", TeamName: "backend", Teams: []string{"backend", "platform"}, IsActive: true}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"platform"}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{author, reviewer}, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "platform", true).Return([]*en.User{author, reviewer}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u2"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestReassignReviewer_FallbackTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, TeamName: "backend"}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"platform"}
+	updated := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"p1"},
+		TeamName: "backend", ReviewerTeams: map[string]string{"p1": "platform"}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{{UserID: "u1", IsActive: true}, {UserID: "u2", TeamName: "backend", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "platform", true).Return([]*en.User{{UserID: "p1", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "p1", "platform", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(updated, nil).Once()
+
+	result, newReviewerID, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "p1", newReviewerID)
+	assert.Equal(t, "platform", result.ReviewerTeams["p1"])
+}
+
+func TestReassignReviewer_FallbackExhausted(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, TeamName: "backend"}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"platform"}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return([]*en.User{{UserID: "u2", TeamName: "backend", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "platform", true).Return([]*en.User{}, nil).Once()
+
+	_, _, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNoCandidate, appErr.Code)
+}
+
+func TestUpdateTeamSettings_FallbackValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		fallbacks []string
+	}{
+		{name: "self reference", fallbacks: []string{"backend"}},
+		{name: "duplicate", fallbacks: []string{"platform", "platform"}},
+		{name: "empty name", fallbacks: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			service := &ServiceStorage{storage: mockStorage}
+			mockStorage.EXPECT().TeamExists(ctx, "platform").Return(true, nil).Maybe()
+
+			settings, err := service.UpdateTeamSettings(ctx, &en.TeamSettingsUpdate{
+				TeamName: "backend", FallbackTeams: tt.fallbacks,
+			})
+
+			require.Error(t, err)
+			assert.Nil(t, settings)
+		})
+	}
+}
+
+func TestUpdateTeamSettings_FallbackTeamNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().TeamExists(ctx, "ghost").Return(false, nil).Once()
+
+	_, err := service.UpdateTeamSettings(ctx, &en.TeamSettingsUpdate{
+		TeamName: "backend", FallbackTeams: []string{"ghost"},
+	})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 20. Unavailability Tests
+func TestCreatePR_SkipsUnavailableReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	until := time.Now().Add(24 * time.Hour)
+	members := []*en.User{author, {UserID: "u2", IsActive: true, UnavailableUntil: &until}, {UserID: "u3", IsActive: true}}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u3"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+}
+
+func TestCreateUnavailability_Validation(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		period *en.Unavailability
+	}{
+		{"empty user", &en.Unavailability{StartsAt: start, EndsAt: start.Add(time.Hour)}},
+		{"missing start", &en.Unavailability{UserID: "u1", EndsAt: start}},
+		{"end before start", &en.Unavailability{UserID: "u1", StartsAt: start, EndsAt: start.Add(-time.Minute)}},
+		{"already ended", &en.Unavailability{UserID: "u1", StartsAt: start.Add(-3 * time.Hour), EndsAt: start.Add(-2 * time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateUnavailability(ctx, tt.period)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCreateUnavailability_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	msk := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2030, 1, 10, 9, 0, 0, 0, msk)
+	end := time.Date(2030, 1, 20, 9, 0, 0, 0, msk)
+
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", IsActive: true}, nil).Once()
+	mockStorage.EXPECT().CreateUnavailability(ctx, mock.MatchedBy(func(p *en.Unavailability) bool {
+		return p.UserID == "u1" && p.StartsAt.Location() == time.UTC && p.StartsAt.Equal(start) && p.Reason == "vacation"
+	})).Return(&en.Unavailability{PeriodID: 1, UserID: "u1", StartsAt: start.UTC(), EndsAt: end.UTC()}, nil).Once()
+
+	period, err := service.CreateUnavailability(ctx, &en.Unavailability{UserID: "u1", StartsAt: start, EndsAt: end, Reason: "vacation"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), period.PeriodID)
+}
+
+func TestCreateUnavailability_UserNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour)
+
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
+
+	_, err := service.CreateUnavailability(ctx, &en.Unavailability{UserID: "ghost", StartsAt: start, EndsAt: start.Add(time.Hour)})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestDeleteUnavailability_NotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().DeleteUnavailability(ctx, int64(42)).Return(false, nil).Once()
+
+	err := service.DeleteUnavailability(ctx, 42)
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 21. Review capacity Tests
+func intPtr(v int) *int { return &v }
+
+func TestCreatePR_SkipsReviewersAtCapacity(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	members := []*en.User{
+		author,
+		{UserID: "u2", IsActive: true, MaxOpenReviews: intPtr(2)},
+		{UserID: "u3", IsActive: true, MaxOpenReviews: intPtr(3)},
+		{UserID: "u4", IsActive: true},
+	}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetOpenReviewLoad(ctx, []string{"u2", "u3"}).Return(map[string]int{"u2": 2, "u3": 1}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+}
+
+func TestCreatePR_NoCapacity(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	members := []*en.User{author, {UserID: "u2", IsActive: true, MaxOpenReviews: intPtr(1)}}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetOpenReviewLoad(ctx, []string{"u2"}).Return(map[string]int{"u2": 1}, nil).Once()
+
+	_, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNoCapacity, appErr.Code)
+}
+
+func TestReassignReviewer_NoCapacity(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, TeamName: "backend"}
+	members := []*en.User{{UserID: "u1", IsActive: true}, {UserID: "u2", TeamName: "backend", IsActive: true}, {UserID: "u3", IsActive: true, MaxOpenReviews: intPtr(0)}}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetOpenReviewLoad(ctx, []string{"u3"}).Return(map[string]int{}, nil).Once()
+
+	_, _, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNoCapacity, appErr.Code)
+}
+
+func TestSetUserReviewCapacity_Negative(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	_, err := service.SetUserReviewCapacity(context.Background(), "u1", intPtr(-1))
+
+	require.Error(t, err)
+}
+
+func TestGetReviewerLoad_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", MaxOpenReviews: intPtr(5)}, nil).Once()
+	mockStorage.EXPECT().GetOpenReviewLoad(ctx, []string{"u1"}).Return(map[string]int{"u1": 3}, nil).Once()
+
+	load, err := service.GetReviewerLoad(ctx, "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, load.OpenReviews)
+	assert.Equal(t, 5, *load.MaxOpenReviews)
+}
+
+func TestGetReviewerLoad_UserNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
+
+	_, err := service.GetReviewerLoad(ctx, "ghost")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 22. Code owners Tests
+func TestMatchOwnerPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/usecases/service.go", true},
+		{"*.go", "README.md", false},
+		{"docs/", "docs/api/openapi.yml", true},
+		{"docs/", "docs", false},
+		{"docs", "docs", true},
+		{"docs", "internal/docs/readme.md", true},
+		{"/docs/", "internal/docs/readme.md", false},
+		{"internal/usecases", "internal/usecases/service.go", true},
+		{"internal/*.go", "internal/usecases/service.go", false},
+		{"internal/**/*.go", "internal/usecases/service.go", true},
+		{"**/migrations/*.sql", "deployment/migrations/postgres/1.up.sql", false},
+		{"**/postgres/*.sql", "deployment/migrations/postgres/1.up.sql", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchOwnerPattern(tt.pattern, tt.path), "%s vs %s", tt.pattern, tt.path)
+	}
+}
+
+func TestMatchOwnerRules_LastMatchWins(t *testing.T) {
+	rules := []en.OwnerRule{
+		{Pattern: "*", Owners: []string{"u2"}},
+		{Pattern: "*.go", Owners: []string{"u3"}},
+		{Pattern: "*.sql", Owners: []string{"u4"}},
+	}
+
+	matched := matchOwnerRules(rules, []string{"main.go", "app.go", "README.md"})
+
+	require.Len(t, matched, 2)
+	assert.Equal(t, "*.go", matched[0].Pattern)
+	assert.Equal(t, "*", matched[1].Pattern)
+}
+
+func TestCreatePR_PrefersCodeOwners(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.OwnerRules = []en.OwnerRule{
+		{Pattern: "*.go", Owners: []string{"@platform"}},
+		{Pattern: "docs/", Owners: []string{"u5"}},
+	}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "platform", true).Return([]*en.User{{UserID: "u6", IsActive: true}}, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u5").
+		Return(&en.User{UserID: "u5", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u6", "u5"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{
+		ChangedFiles: []string{"cmd/main.go", "docs/api.md", "cmd/main.go"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cmd/main.go", "docs/api.md"}, pr.ChangedFiles)
+	assert.Equal(t, map[string]string{"u6": "platform", "u5": "backend"}, pr.ReviewerTeams)
+	assert.Equal(t, map[string]string{"u6": "*.go", "u5": "docs/"}, pr.ReviewerRules)
+}
+
+func TestCreatePR_CodeOwnersFallBackToTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.OwnerRules = []en.OwnerRule{{Pattern: "*.go", Owners: []string{"u1"}}}
+	members := []*en.User{author, {UserID: "u2", IsActive: true}}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Twice()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u2"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{ChangedFiles: []string{"main.go"}})
+
+	require.NoError(t, err)
+	assert.Nil(t, pr.ReviewerRules)
+}
+
+func TestCreatePR_EmptyChangedFile(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+	_, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{ChangedFiles: []string{" "}})
+
+	require.Error(t, err)
+}
+
+func TestUpdateTeamSettings_UnknownOwner(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := &en.TeamSettingsUpdate{
+		TeamName:   "backend",
+		OwnerRules: []en.OwnerRule{{Pattern: "*.go", Owners: []string{"@platform", "ghost"}}},
+	}
+	mockStorage.EXPECT().TeamExists(ctx, "platform").Return(true, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
+
+	_, err := service.UpdateTeamSettings(ctx, settings)
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestUpdateTeamSettings_InvalidOwnerPattern(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	_, err := service.UpdateTeamSettings(context.Background(), &en.TeamSettingsUpdate{
+		TeamName:   "backend",
+		OwnerRules: []en.OwnerRule{{Pattern: "src/[", Owners: []string{"u2"}}},
+	})
+
+	require.Error(t, err)
+}
+
+// 23. Skills Tests
+func TestCreatePR_PrefersSkilledReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	members := []*en.User{
+		author,
+		{UserID: "u2", IsActive: true},
+		{UserID: "u3", IsActive: true, Skills: []string{"go"}},
+		{UserID: "u4", IsActive: true},
+	}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{Labels: []string{" Go", "go"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, pr.Labels)
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Equal(t, "u3", pr.AssignedReviewers[0])
+}
+
+func TestCreatePR_ExpertFromFallbackTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"dba"}
+	members := []*en.User{author, {UserID: "u2", IsActive: true}, {UserID: "u3", IsActive: true}}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "dba", true).
+		Return([]*en.User{{UserID: "d1", IsActive: true, Skills: []string{"sql"}}}, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Schema", "u1", en.PRCreateOptions{Labels: []string{"sql"}})
+
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Contains(t, pr.AssignedReviewers, "d1")
+	assert.Equal(t, "dba", pr.ReviewerTeams["d1"])
+	assert.Len(t, pr.ReviewerTeams, 2)
+}
+
+func TestCreatePR_NoExpertAvailable(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	author := &en.User{UserID: "u1", TeamName: "backend", Teams: []string{"backend"}, IsActive: true}
+	members := []*en.User{author, {UserID: "u2", IsActive: true}}
+
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(members, nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, []string{"u2"}).Return(nil).Once()
+
+	pr, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{Labels: []string{"frontend"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestSetUserSkills_Normalizes(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().SetUserSkills(ctx, "u1", []string{"go", "sql"}).
+		Return(&en.User{UserID: "u1", Skills: []string{"go", "sql"}}, nil).Once()
+
+	user, err := service.SetUserSkills(ctx, "u1", []string{" SQL", "go", "Go"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "sql"}, user.Skills)
+}
+
+func TestSetUserSkills_EmptySkill(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	_, err := service.SetUserSkills(context.Background(), "u1", []string{"go", " "})
+
+	require.Error(t, err)
+}
+
+func TestSetUserSkills_UserNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().SetUserSkills(ctx, "ghost", []string{}).Return(nil, nil).Once()
+
+	_, err := service.SetUserSkills(ctx, "ghost", nil)
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+// 24. Manual reviewer Tests
+func openPR() *en.PullRequest {
+	return &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, TeamName: "backend"}
+}
+
+func TestAddReviewer_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	updated := openPR()
+	updated.AssignedReviewers = []string{"u2", "u3"}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u3").Return(&en.User{UserID: "u3", Teams: []string{"backend"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "", "u3", "backend", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(updated, nil).Once()
+
+	pr, err := service.AddReviewer(ctx, "pr-1", "u3")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+}
+
+func TestAddReviewer_FromFallbackTeam(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.FallbackTeams = []string{"infra", "platform"}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "p1").Return(&en.User{UserID: "p1", Teams: []string{"platform"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "", "p1", "platform", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+
+	_, err := service.AddReviewer(ctx, "pr-1", "p1")
+
+	require.NoError(t, err)
+}
+
+func TestAddReviewer_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		user   *en.User
+		code   en.ErrorCode
+	}{
+		{"author", "u1", nil, en.ErrCodeInvalidReviewer},
+		{"already assigned", "u2", nil, en.ErrCodeAlreadyAssigned},
+		{"unknown", "ghost", nil, en.ErrCodeNotFound},
+		{"inactive", "u3", &en.User{UserID: "u3", Teams: []string{"backend"}}, en.ErrCodeInvalidReviewer},
+		{"foreign team", "u4", &en.User{UserID: "u4", Teams: []string{"frontend"}, IsActive: true}, en.ErrCodeInvalidTeamUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			service := &ServiceStorage{storage: mockStorage}
+
+			ctx := context.Background()
+			mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+			if tt.userID != "u1" && tt.userID != "u2" {
+				mockStorage.EXPECT().GetUser(ctx, tt.userID).Return(tt.user, nil).Once()
+			}
+			if tt.code == en.ErrCodeInvalidTeamUser {
+				mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+			}
+
+			_, err := service.AddReviewer(ctx, "pr-1", tt.userID)
+
+			var appErr *en.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestAddReviewer_MergedPR(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	pr := openPR()
+	pr.Status = en.StatusMerged
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+
+	_, err := service.AddReviewer(ctx, "pr-1", "u3")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodePRMerged, appErr.Code)
+}
+
+func TestRemoveReviewer_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	updated := openPR()
+	updated.AssignedReviewers = []string{}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "", "", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(updated, nil).Once()
+
+	pr, err := service.RemoveReviewer(ctx, "pr-1", "u2")
+
+	require.NoError(t, err)
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func TestRemoveReviewer_BelowMinReviewers(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := en.NewDefaultTeamSettings("backend")
+	settings.MinReviewers = 1
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(settings, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "", "", 1).
+		Return(en.NewNotEnoughReviewersError("backend", 1, 0)).Once()
+
+	_, err := service.RemoveReviewer(ctx, "pr-1", "u2")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotEnoughReviewers, appErr.Code)
+}
+
+func TestRemoveReviewer_NotAssigned(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+
+	_, err := service.RemoveReviewer(ctx, "pr-1", "u3")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotAssigned, appErr.Code)
+}
+
+func TestReassignReviewerTo_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	updated := openPR()
+	updated.AssignedReviewers = []string{"u3"}
+
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u3").Return(&en.User{UserID: "u3", Teams: []string{"backend"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "u3", "backend", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(updated, nil).Once()
+
+	pr, err := service.ReassignReviewerTo(ctx, "pr-1", "u2", "u3")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+}
+
+// 25. Review SLA Tests
+func TestListOverdueReviews_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	overdue := []*en.OverdueReview{{PullRequestID: "pr-1", ReviewerID: "u2", TeamName: "backend"}}
+	mockStorage.EXPECT().TeamExists(ctx, "backend").Return(true, nil).Once()
+	mockStorage.EXPECT().ListOverdueReviews(ctx, "backend", mock.Anything).Return(overdue, nil).Once()
+
+	result, err := service.ListOverdueReviews(ctx, "backend")
+
+	require.NoError(t, err)
+	assert.Equal(t, overdue, result)
+}
+
+func TestListOverdueReviews_AllTeams(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().ListOverdueReviews(ctx, "", mock.Anything).Return([]*en.OverdueReview{}, nil).Once()
+
+	result, err := service.ListOverdueReviews(ctx, "")
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestListOverdueReviews_TeamNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().TeamExists(ctx, "ghost").Return(false, nil).Once()
+
+	_, err := service.ListOverdueReviews(ctx, "ghost")
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestUpdateTeamSettings_ReviewSLA(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := &en.TeamSettingsUpdate{
+		TeamName: "backend", ReviewSLAMinutes: intPtr(240), SLAEscalation: en.EscalationAddReviewer,
+	}
+	stored := &en.TeamSettings{
+		TeamName: "backend", MinReviewers: 1, MaxReviewers: 2,
+		ReviewSLAMinutes: 240, SLAEscalation: en.EscalationAddReviewer,
+	}
+	mockStorage.EXPECT().UpdateTeamSettings(ctx, settings).Return(stored, nil).Once()
+
+	updated, err := service.UpdateTeamSettings(ctx, settings)
+
+	require.NoError(t, err)
+	assert.Equal(t, 240, updated.ReviewSLAMinutes)
+}
+
+func TestUpdateTeamSettings_InvalidReviewSLA(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	for _, settings := range []*en.TeamSettingsUpdate{
+		{TeamName: "backend", ReviewSLAMinutes: intPtr(-1)},
+		{TeamName: "backend", SLAEscalation: "notify"},
+	} {
+		_, err := service.UpdateTeamSettings(context.Background(), settings)
+
+		require.Error(t, err)
+	}
+}
+
+// 26. Notification Tests
+func TestGetNotificationSettings_Defaults(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1"}, nil).Once()
+	mockStorage.EXPECT().GetNotificationSettings(ctx, "u1").Return(nil, nil).Once()
+
+	settings, err := service.GetNotificationSettings(ctx, "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, en.NewDefaultNotificationSettings("u1"), settings)
+}
+
+func TestUpdateNotificationSettings_Success(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	settings := &en.NotificationSettings{
+		UserID: "u1", Email: "u1@example.com", QuietHoursStart: "22:00", QuietHoursEnd: "08:00", DigestTime: "09:30",
+	}
+	expected := &en.NotificationSettings{
+		UserID: "u1", Email: "u1@example.com", QuietHoursStart: "22:00", QuietHoursEnd: "08:00", DigestTime: "09:30",
+		Timezone: en.DefaultTimezone,
+	}
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1"}, nil).Once()
+	mockStorage.EXPECT().UpsertNotificationSettings(ctx, expected).Return(expected, nil).Once()
+
+	saved, err := service.UpdateNotificationSettings(ctx, settings)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, saved)
+}
+
+func TestUpdateNotificationSettings_Invalid(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	for _, settings := range []*en.NotificationSettings{
+		{UserID: "u1", Email: "not an email"},
+		{UserID: "u1", Timezone: "Mars/Olympus"},
+		{UserID: "u1", QuietHoursStart: "22:00"},
+		{UserID: "u1", QuietHoursStart: "25:00", QuietHoursEnd: "08:00"},
+		{UserID: "u1", DigestTime: "9am"},
+		{UserID: ""},
+	} {
+		_, err := service.UpdateNotificationSettings(context.Background(), settings)
+
+		require.Error(t, err)
+	}
+}
+
+func TestUpdateNotificationSettings_UserNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
+
+	_, err := service.UpdateNotificationSettings(ctx, &en.NotificationSettings{UserID: "ghost"})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestGetReviewDigest_NoReviews(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", Username: "Alice"}, nil).Once()
+	mockStorage.EXPECT().ListPendingReviewDigests(ctx, "u1").Return([]*en.ReviewDigest{}, nil).Once()
+
+	digest, err := service.GetReviewDigest(ctx, "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", digest.Username)
+	assert.Empty(t, digest.Reviews)
+	assert.False(t, digest.GeneratedAt.IsZero())
+}
+
+func TestGetReviewDigest_ReviewAges(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	assignedAt := time.Now().UTC().Add(-3 * time.Hour)
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", Username: "Alice"}, nil).Once()
+	mockStorage.EXPECT().ListPendingReviewDigests(ctx, "u1").Return([]*en.ReviewDigest{{
+		UserID: "u1", Username: "Alice",
+		Reviews: []en.DigestReview{{PullRequestID: "pr-1", AssignedAt: assignedAt}},
+	}}, nil).Once()
+
+	digest, err := service.GetReviewDigest(ctx, "u1")
+
+	require.NoError(t, err)
+	require.Len(t, digest.Reviews, 1)
+	assert.InDelta(t, 3*3600, digest.Reviews[0].AgeSeconds, 5)
+}
+
+// 27. Analytics Tests
+func TestGetPRThroughput_Defaults(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	points := []en.ThroughputPoint{{TeamName: "backend", Created: 2, Merged: 1}}
+	mockStorage.EXPECT().GetPRThroughput(ctx, mock.MatchedBy(func(f en.AnalyticsFilter) bool {
+		return f.Bucket == en.BucketDay && f.To.Sub(f.From) == defaultAnalyticsPeriod && f.To.Location() == time.UTC
+	})).Return(points, nil).Once()
+
+	result, err := service.GetPRThroughput(ctx, en.AnalyticsFilter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, points, result)
+}
+
+func TestGetReviewTimings_TeamFilter(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	filter := en.AnalyticsFilter{TeamName: "backend", From: from, To: to, Bucket: en.BucketWeek}
+	timings := &en.ReviewTimings{Teams: []en.TeamTimings{{TeamName: "backend"}}}
+	mockStorage.EXPECT().TeamExists(ctx, "backend").Return(true, nil).Once()
+	mockStorage.EXPECT().GetReviewTimings(ctx, filter).Return(timings, nil).Once()
+
+	result, err := service.GetReviewTimings(ctx, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, timings, result)
+}
+
+func TestGetReassignmentStats_TeamNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().TeamExists(ctx, "ghost").Return(false, nil).Once()
+
+	_, err := service.GetReassignmentStats(ctx, en.AnalyticsFilter{TeamName: "ghost"})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestGetWorkloadTrend_UserNotFound(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetUser(ctx, "ghost").Return(nil, nil).Once()
+
+	_, err := service.GetWorkloadTrend(ctx, en.AnalyticsFilter{UserID: "ghost"})
+
+	var appErr *en.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, en.ErrCodeNotFound, appErr.Code)
+}
+
+func TestAnalytics_InvalidFilter(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	now := time.Now()
+	for _, filter := range []en.AnalyticsFilter{
+		{Bucket: "month"},
+		{From: now, To: now},
+		{From: now, To: now.Add(-time.Hour)},
+	} {
+		_, err := service.GetPRThroughput(context.Background(), filter)
+
+		require.Error(t, err)
+	}
+}
+
+// 28. Metrics Tests
+type fakeMetrics struct {
+	noCandidate int
+	deactivated int
+	assigned    map[string]int
+	reassigned  map[string]int
+	removed     map[string]int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{assigned: map[string]int{}, reassigned: map[string]int{}, removed: map[string]int{}}
+}
+
+func (f *fakeMetrics) NoCandidate()               { f.noCandidate++ }
+func (f *fakeMetrics) UsersDeactivated(count int) { f.deactivated += count }
+func (f *fakeMetrics) ReviewersAssigned(reason string, count int) {
+	f.assigned[reason] += count
+}
+func (f *fakeMetrics) ReviewersReassigned(reason string, count int) {
+	f.reassigned[reason] += count
+}
+func (f *fakeMetrics) ReviewersRemoved(reason string, count int) {
+	f.removed[reason] += count
+}
+
+func TestReassignReviewer_NoCandidateMetric(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	metrics := newFakeMetrics()
+	service := &ServiceStorage{storage: mockStorage, metrics: metrics}
+
+	ctx := context.Background()
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().IsUserAssignedToReviewer(ctx, "pr-1", "u2").Return(true, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(&en.User{UserID: "u1", TeamName: "small-team", IsActive: true}, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "small-team").Return(en.NewDefaultTeamSettings("small-team"), nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "small-team", true).Return([]*en.User{}, nil).Once()
+
+	_, _, err := service.ReassignReviewer(ctx, "pr-1", "u2")
+
+	require.Error(t, err)
+	assert.Equal(t, 1, metrics.noCandidate)
+}
+
+func TestDeactivation_Metrics(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	metrics := newFakeMetrics()
+	service := &ServiceStorage{storage: mockStorage, metrics: metrics}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().SetUserActiveStatus(ctx, "u1", false).Return(&en.User{UserID: "u1"}, nil).Once()
+	mockStorage.EXPECT().SetUserActiveStatus(ctx, "u1", true).Return(&en.User{UserID: "u1", IsActive: true}, nil).Once()
+	mockStorage.EXPECT().DeactivateTeamMembersWithReassignment(ctx, "backend", []string{"u2", "u3"}, mock.Anything).
+		Return(&en.DeactivateResult{DeactivatedUsers: []string{"u2", "u3"}}, nil).Once()
+
+	_, err := service.SetUserActive(ctx, "u1", false)
+	require.NoError(t, err)
+	_, err = service.SetUserActive(ctx, "u1", true)
+	require.NoError(t, err)
+	_, err = service.DeactivateTeamMembers(ctx, "backend", []string{"u2", "u3"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, metrics.deactivated)
+}
+
+func TestReviewerChanges_Metrics(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	metrics := newFakeMetrics()
+	service := &ServiceStorage{storage: mockStorage, metrics: metrics}
+
+	ctx := context.Background()
+	mockStorage.EXPECT().GetPR(ctx, "pr-1").Return(openPR(), nil)
+	mockStorage.EXPECT().GetUser(ctx, "u3").Return(&en.User{UserID: "u3", Teams: []string{"backend"}, IsActive: true}, nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "", "u3", "backend", 0).Return(nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().ReassignReviewer(ctx, "pr-1", "u2", "", "", 0).Return(nil).Once()
+	mockStorage.EXPECT().DeactivateTeamMembersWithReassignment(ctx, "backend", []string{"u4", "u5"}, mock.Anything).
+		Return(&en.DeactivateResult{
+			DeactivatedUsers: []string{"u4", "u5"},
+			Reassignments: []en.PRReassignmentInfo{
+				{PullRequestID: "pr-2", OldReviewer: "u4", NewReviewer: "u6"},
+				{PullRequestID: "pr-3", OldReviewer: "u4", NewReviewer: "u7"},
+				{PullRequestID: "pr-3", OldReviewer: "u5"},
+			},
+		}, nil).Once()
+
+	_, err := service.AddReviewer(ctx, "pr-1", "u3")
+	require.NoError(t, err)
+	_, err = service.RemoveReviewer(ctx, "pr-1", "u2")
+	require.NoError(t, err)
+	_, err = service.DeactivateTeamMembers(ctx, "backend", []string{"u4", "u5"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{en.ReasonManualAdd: 1}, metrics.assigned)
+	assert.Equal(t, map[string]int{en.ReasonReviewerDeactivated: 2}, metrics.reassigned)
+	assert.Equal(t, map[string]int{en.ReasonManualRemove: 1, en.ReasonReviewerDeactivated: 1}, metrics.removed)
+}
+
+// 29. Tracing Tests
+func TestTracedService_Spans(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	traced, err := NewTracedService(&ServiceStorage{storage: mockStorage}, provider)
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	pr := &en.PullRequest{PullRequestID: "pr-1", Status: en.StatusOpen}
+	mockStorage.EXPECT().GetPR(mock.Anything, "pr-1").Return(pr, nil).Once()
+	mockStorage.EXPECT().GetPR(mock.Anything, "pr-2").Return(nil, nil).Once()
+
+	got, err := traced.GetPullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, pr, got)
+	_, err = traced.GetPullRequest(ctx, "pr-2")
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	ok, failed := spans[0], spans[1]
+	assert.Equal(t, "ServiceStorage.GetPullRequest", ok.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), ok.Parent().SpanID())
+	assert.Equal(t, codes.Unset, ok.Status().Code)
+
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Attributes(), attribute.String("app.error_code", string(en.ErrCodeNotFound)))
+}
+
+func TestNewTracedService_NilDependency(t *testing.T) {
+	_, err := NewTracedService(nil, sdktrace.NewTracerProvider())
+	require.ErrorIs(t, err, en.ErrNilDependency)
+}
+
+// 30. Logging Tests
+func TestCreatePR_LogsReviewerSelection(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	service := &ServiceStorage{storage: mockStorage}
+
+	var buf bytes.Buffer
+	ctx := en.ContextWithLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)).With("request_id", "req-1"))
+	author := &en.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	candidates := []*en.User{
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+	}
+	mockStorage.EXPECT().PRExists(ctx, "pr-1").Return(false, nil).Once()
+	mockStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	mockStorage.EXPECT().GetUsersByTeam(ctx, "backend", true).Return(candidates, nil).Once()
+	mockStorage.EXPECT().GetTeamSettings(ctx, "backend").Return(en.NewDefaultTeamSettings("backend"), nil).Once()
+	mockStorage.EXPECT().CreatePRWithReviewers(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := service.CreatePullRequest(ctx, "pr-1", "Feature", "u1", en.PRCreateOptions{})
+	require.NoError(t, err)
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, "reviewers selected", lines[0]["msg"])
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, []interface{}{"u2", "u3"}, lines[0]["candidates"])
+	assert.Len(t, lines[0]["chosen"], 2)
+	assert.Equal(t, "reviewers assigned", lines[1]["msg"])
+	assert.Equal(t, "pr-1", lines[1]["pull_request_id"])
+	assert.ElementsMatch(t, lines[0]["chosen"], lines[1]["reviewers"])
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// SetUserSkills заменяет навыки пользователя, пустой список очищает их
+func (s *ServiceStorage) SetUserSkills(ctx context.Context, userID string, skills []string) (*en.User, error) {
+	if userID == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	normalized, err := normalizeTags(skills)
+	if err != nil {
+		return nil, err
+	}
+	if normalized == nil {
+		normalized = []string{}
+	}
+
+	user, err := s.storage.SetUserSkills(ctx, userID, normalized)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set user skills")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+	return user, nil
+}
+
+// normalizeTags приводит навыки или метки к нижнему регистру, убирает повторы и сортирует.
+// nil остается nil, чтобы отличать "не передано" от пустого списка
+func normalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, errors.New("tag cannot be empty")
+		}
+		if !contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// rankBySkills разбивает кандидатов на группы по числу навыков, совпадающих с labels, от большего к меньшему.
+// Без меток все кандидаты попадают в одну группу
+func rankBySkills(candidates []*en.User, labels []string) [][]*en.User {
+	if len(labels) == 0 {
+		return [][]*en.User{candidates}
+	}
+	byOverlap := make(map[int][]*en.User)
+	for _, c := range candidates {
+		overlap := c.SkillOverlap(labels)
+		byOverlap[overlap] = append(byOverlap[overlap], c)
+	}
+
+	tiers := make([][]*en.User, 0, len(byOverlap))
+	for overlap := len(labels); overlap >= 0; overlap-- {
+		if tier, ok := byOverlap[overlap]; ok {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers
+}
+
+// pickBySkills выбирает до count ревьюверов стратегией команды, начиная с кандидатов с наибольшим
+// совпадением навыков с labels. Второе значение - есть ли среди выбранных хотя бы один эксперт
+func (s *ServiceStorage) pickBySkills(ctx context.Context, teamName string, candidates []*en.User, labels []string, count int) ([]string, bool, error) {
+	var picked []string
+	expert := false
+	for _, tier := range rankBySkills(candidates, labels) {
+		need := count - len(picked)
+		if need <= 0 {
+			break
+		}
+		ids, err := s.pickReviewers(ctx, teamName, tier, need)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(ids) > 0 && len(labels) > 0 && tier[0].SkillOverlap(labels) > 0 {
+			expert = true
+		}
+		picked = append(picked, ids...)
+	}
+	return picked, expert, nil
+}
+
+// ensureExpert гарантирует хотя бы одного ревьювера с навыком из меток PR, если такой кандидат есть в одной
+// из команд sources. Эксперт добавляется на свободное место, а если мест нет - заменяет последнего
+// ревьювера, выбранного не по правилу владения
+func (s *ServiceStorage) ensureExpert(ctx context.Context, selection *reviewerSelection, settings *en.TeamSettings,
+	authorID string, labels []string, sources []string,
+) error {
+	if len(labels) == 0 || selection.expert {
+		return nil
+	}
+
+	replaced := ""
+	if len(selection.ids) >= settings.MaxReviewers {
+		for i := len(selection.ids) - 1; i >= 0; i-- {
+			if selection.rules[selection.ids[i]] == "" {
+				replaced = selection.ids[i]
+				break
+			}
+		}
+		if replaced == "" {
+			return nil
+		}
+	}
+
+	for _, source := range sources {
+		candidates, _, err := s.teamCandidates(ctx, source, append([]string{authorID}, selection.ids...))
+		if err != nil {
+			return err
+		}
+		var experts []*en.User
+		for _, c := range candidates {
+			if c.SkillOverlap(labels) > 0 {
+				experts = append(experts, c)
+			}
+		}
+		if len(experts) == 0 {
+			continue
+		}
+
+		picked, _, err := s.pickBySkills(ctx, source, experts, labels, 1)
+		if err != nil {
+			return err
+		}
+		if len(picked) == 0 {
+			continue
+		}
+		if replaced != "" {
+			selection.remove(replaced)
+		}
+		selection.add(picked[0], source, "")
+		selection.expert = true
+		return nil
+	}
+	return nil
+}
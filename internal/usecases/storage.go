@@ -9,30 +9,106 @@ import (
 
 // интерфейс для взаимодействия с хранилищем данных
 type Storage interface {
-	// Teams. createTeamWithUsers создает команду и всех пользователей атомарно в одной транзакции
-	CreateTeamWithUsers(ctx context.Context, teamName string, users []*entities.User) error
+	// Teams. createTeamWithUsers создает команду и всех пользователей атомарно в одной транзакции,
+	// переводя участников других команд по opts.ConflictMode
+	CreateTeamWithUsers(ctx context.Context, teamName string, settings *entities.TeamSettings, users []*entities.User, opts entities.TeamCreateOptions, picker func(teamName string) entities.ReviewerPicker) (*entities.TeamCreateResult, error)
 	GetTeamByName(ctx context.Context, teamName string) (*entities.Team, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*entities.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, settings *entities.TeamSettingsUpdate) (*entities.TeamSettings, error)
+	// Изменение состава выполняется в одной транзакции с переназначением открытых ревью ушедших участников
+	AddTeamMembers(ctx context.Context, teamName string, users []*entities.User) error
+	RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)
+	MoveTeamMember(ctx context.Context, userID, fromTeam, toTeam string, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (bool, error)
+	DeleteTeam(ctx context.Context, teamName string) (bool, error)
 
 	// Users
 	GetUser(ctx context.Context, userID string) (*entities.User, error)
 	GetUsersByTeam(ctx context.Context, teamName string, activeOnly bool) ([]*entities.User, error)
 	SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*entities.User, error)
+	SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*entities.User, error)
+	SetUserSkills(ctx context.Context, userID string, skills []string) (*entities.User, error)
 
-	// Pull Requests. createPRWithReviewers создает PR и назначает ревьюверов атомарно
+	// Pull Requests. createPRWithReviewers создает PR и назначает ревьюверов атомарно, команды ревьюверов берутся из pr.ReviewerTeams
 	CreatePRWithReviewers(ctx context.Context, pr *entities.PullRequest, reviewerIDs []string) error
 	GetPR(ctx context.Context, prID string) (*entities.PullRequest, error)
 	MergePR(ctx context.Context, prID string, mergedAt time.Time) (*entities.PullRequest, error)
+	// updatePRStatus переводит PR из from в to и назначает reviewerIDs атомарно, запоминая команды из reviewerTeams
+	// и правила владения из reviewerRules.
+	// nil — PR не найден или статус уже не from
+	UpdatePRStatus(ctx context.Context, prID string, from, to entities.PRStatus, at time.Time, reviewerIDs []string, reviewerTeams, reviewerRules map[string]string) (*entities.PullRequest, error)
 	PRExists(ctx context.Context, prID string) (bool, error)
+	// listPRs возвращает до filter.Limit PR, отсортированных по filter.SortBy и следующих после filter.After
+	ListPRs(ctx context.Context, filter entities.PRListFilter) ([]*entities.PullRequest, error)
 
-	// Reviewers. reassignReviewer заменяет ревьювера атомарно (удаление старого + добавление нового)
-	ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string) error
+	// Reviewers. reassignReviewer заменяет ревьювера атомарно (удаление старого + добавление нового из sourceTeam).
+	// Пустой oldUserID - только добавление, пустой newUserID - только снятие, если останется не меньше minReviewers
+	ReassignReviewer(ctx context.Context, prID string, oldUserID string, newUserID string, sourceTeam string, minReviewers int) error
 	GetPRsByReviewer(ctx context.Context, userID string) ([]*entities.PullRequestShort, error)
 	IsUserAssignedToReviewer(ctx context.Context, prID string, userID string) (bool, error)
+	// getOpenReviewLoad возвращает количество OPEN PR на ревью у каждого из пользователей
+	GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error)
 
-	// Teams - массовая деактивация
-	DeactivateTeamMembersWithReassignment(ctx context.Context, teamName string, userIDs []string) (*entities.DeactivateResult, error)
+	// Reviews. getPRReviews возвращает все ревью PR в порядке создания
+	CreateReview(ctx context.Context, review *entities.Review) error
+	GetPRReviews(ctx context.Context, prID string) ([]*entities.Review, error)
+
+	// History. getPREvents возвращает журнал изменений PR в порядке записи
+	GetPREvents(ctx context.Context, prID string) ([]*entities.PREvent, error)
+
+	// Webhooks. Доставки ставятся в очередь вместе с событиями PR, воркер забирает их через claimWebhookDeliveries
+	CreateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*entities.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) (bool, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]*entities.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryID int64, at time.Time) error
+	MarkWebhookFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt *time.Time) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status entities.DeliveryStatus) ([]*entities.WebhookDelivery, error)
+	RequeueWebhookDelivery(ctx context.Context, deliveryID int64, at time.Time) (bool, error)
+
+	// External identities. getUserIDByExternalLogin возвращает пустую строку, если соответствие не задано
+	SetExternalIdentity(ctx context.Context, identity *entities.ExternalIdentity) error
+	GetUserIDByExternalLogin(ctx context.Context, provider entities.GitProvider, login string) (string, error)
+	ListExternalIdentities(ctx context.Context, provider entities.GitProvider) ([]*entities.ExternalIdentity, error)
+	DeleteExternalIdentity(ctx context.Context, provider entities.GitProvider, login string) (bool, error)
+
+	CreateAPIToken(ctx context.Context, token *entities.APIToken, tokenHash string) (*entities.APIToken, error)
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (*entities.APIToken, error)
+	ListAPITokens(ctx context.Context, userID string) ([]*entities.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID int64, at time.Time) (bool, error)
+
+	// Unavailability. listUnavailability возвращает периоды, не закончившиеся к моменту from; пустой userID - всех пользователей.
+	// startUnavailabilityPeriods переназначает открытые ревью пользователей, чьи периоды начались к now
+	CreateUnavailability(ctx context.Context, period *entities.Unavailability) (*entities.Unavailability, error)
+	GetUnavailability(ctx context.Context, periodID int64) (*entities.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string, from time.Time) ([]*entities.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, periodID int64) (bool, error)
+	StartUnavailabilityPeriods(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker) ([]entities.PRReassignmentInfo, error)
+
+	// Review SLA. listOverdueReviews возвращает просроченные к now назначения, пустой teamName - всех команд.
+	// escalateOverdueReviews эскалирует до limit еще не эскалированных просроченных назначений
+	ListOverdueReviews(ctx context.Context, teamName string, now time.Time) ([]*entities.OverdueReview, error)
+	EscalateOverdueReviews(ctx context.Context, now time.Time, limit int, picker func(teamName string) entities.ReviewerPicker) ([]entities.ReviewEscalation, error)
+
+	// Notifications. listPendingReviewDigests возвращает открытые ревью активных пользователей, пустой userID - всех.
+	// listDueReviewDigests - только тех, кому к now пора отправить дайджест, defaultTime - время отправки по умолчанию.
+	// setDigestSentAt меняет время последнего дайджеста, только если оно все еще равно prev
+	GetNotificationSettings(ctx context.Context, userID string) (*entities.NotificationSettings, error)
+	UpsertNotificationSettings(ctx context.Context, settings *entities.NotificationSettings) (*entities.NotificationSettings, error)
+	ListPendingReviewDigests(ctx context.Context, userID string) ([]*entities.ReviewDigest, error)
+	ListDueReviewDigests(ctx context.Context, now time.Time, defaultTime string) ([]*entities.ReviewDigest, error)
+	SetDigestSentAt(ctx context.Context, userID string, prev, sentAt *time.Time) (bool, error)
+
+	// Teams - массовая деактивация. pick выбирает замену для каждого снимаемого ревьювера
+	DeactivateTeamMembersWithReassignment(ctx context.Context, teamName string, userIDs []string, picker func(teamName string) entities.ReviewerPicker) (*entities.DeactivateResult, error)
 
 	// Stats
 	GetStats(ctx context.Context) (*entities.Stats, error)
+	GetPRThroughput(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.ThroughputPoint, error)
+	GetReviewTimings(ctx context.Context, filter entities.AnalyticsFilter) (*entities.ReviewTimings, error)
+	GetReassignmentStats(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.TeamReassignments, error)
+	GetWorkloadTrend(ctx context.Context, filter entities.AnalyticsFilter) ([]entities.WorkloadPoint, error)
 }
@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// AddTeamMembers добавляет пользователей в существующую команду. Участники других команд
+// остаются в них, основная команда пользователя не меняется
+func (s *ServiceStorage) AddTeamMembers(ctx context.Context, teamName string, members []en.TeamMember) (*en.Team, error) {
+	if teamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+	if len(members) == 0 {
+		return nil, errors.New("members cannot be empty")
+	}
+
+	users := make([]*en.User, len(members))
+	for i, m := range members {
+		if m.UserID == "" {
+			return nil, errors.New("user_id cannot be empty")
+		}
+		skills, err := normalizeTags(m.Skills)
+		if err != nil {
+			return nil, err
+		}
+		users[i] = &en.User{UserID: m.UserID, Username: m.Username, TeamName: teamName, IsActive: m.IsActive, Skills: skills}
+	}
+
+	if err := s.storage.AddTeamMembers(ctx, teamName, users); err != nil {
+		return nil, errors.Wrap(err, "failed to add team members")
+	}
+	return s.GetTeam(ctx, teamName)
+}
+
+// RemoveTeamMembers исключает пользователей из команды. Пользователи остаются в других своих командах
+// или в системе без команды, их открытые ревью в PR команды переназначаются на ее участников
+func (s *ServiceStorage) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*en.TeamMembershipChange, error) {
+	if teamName == "" {
+		return nil, errors.New("team name cannot be empty")
+	}
+	if len(userIDs) == 0 {
+		return nil, errors.New("user IDs cannot be empty")
+	}
+
+	infos, err := s.storage.RemoveTeamMembers(ctx, teamName, userIDs, s.teamPicker(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove team members")
+	}
+	observeReassignments(s.metrics, en.ReasonLeftTeam, infos)
+	return &en.TeamMembershipChange{UserIDs: userIDs, FromTeam: teamName, Reassignments: infos}, nil
+}
+
+// MoveTeamMember переводит пользователя из fromTeam (по умолчанию основной команды) в toTeam.
+// Открытые ревью переназначаются в fromTeam, авторские PR остаются за пользователем
+func (s *ServiceStorage) MoveTeamMember(ctx context.Context, userID, fromTeam, toTeam string) (*en.TeamMembershipChange, error) {
+	if userID == "" || toTeam == "" {
+		return nil, errors.New("user_id and team name cannot be empty")
+	}
+
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", userID)
+	}
+	if fromTeam == "" {
+		fromTeam = user.TeamName
+	}
+	if fromTeam == "" {
+		return nil, errors.Errorf("user '%s' has no team, add it with /team/members/add", userID)
+	}
+	if !user.InTeam(fromTeam) {
+		return nil, en.NewInvalidTeamUserError(userID, fromTeam, "does not belong to")
+	}
+	if user.InTeam(toTeam) {
+		return nil, errors.Errorf("user '%s' is already in team '%s'", userID, toTeam)
+	}
+
+	exists, err := s.storage.TeamExists(ctx, toTeam)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check team existence")
+	}
+	if !exists {
+		return nil, en.NewNotFoundError("team", toTeam)
+	}
+
+	infos, err := s.storage.MoveTeamMember(ctx, userID, fromTeam, toTeam, s.teamPicker(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to move team member")
+	}
+	observeReassignments(s.metrics, en.ReasonLeftTeam, infos)
+	return &en.TeamMembershipChange{
+		UserIDs:       []string{userID},
+		FromTeam:      fromTeam,
+		ToTeam:        toTeam,
+		Reassignments: infos,
+	}, nil
+}
+
+// RenameTeam переименовывает команду вместе с настройками и составом
+func (s *ServiceStorage) RenameTeam(ctx context.Context, oldName, newName string) (*en.Team, error) {
+	if oldName == "" || newName == "" {
+		return nil, errors.New("team names cannot be empty")
+	}
+	if oldName == newName {
+		return s.GetTeam(ctx, oldName)
+	}
+
+	exists, err := s.storage.TeamExists(ctx, newName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check team existence")
+	}
+	if exists {
+		return nil, en.NewTeamExistsError(newName)
+	}
+
+	renamed, err := s.storage.RenameTeam(ctx, oldName, newName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to rename team")
+	}
+	if !renamed {
+		return nil, en.NewNotFoundError("team", oldName)
+	}
+	return s.GetTeam(ctx, newName)
+}
+
+// DeleteTeam удаляет пустую команду. Участников нужно предварительно перевести или исключить
+func (s *ServiceStorage) DeleteTeam(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return errors.New("team name cannot be empty")
+	}
+	deleted, err := s.storage.DeleteTeam(ctx, teamName)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete team")
+	}
+	if !deleted {
+		return en.NewNotFoundError("team", teamName)
+	}
+	return nil
+}
@@ -0,0 +1,434 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+const tracerName = "github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+
+// TracedService оборачивает ServiceStorage и создает спан на каждый вызов юзкейса.
+// Родителем становится спан HTTP-обработчика из контекста, спаны запросов к базе - дочерними
+type TracedService struct {
+	service *ServiceStorage
+	tracer  trace.Tracer
+}
+
+func NewTracedService(service *ServiceStorage, provider trace.TracerProvider) (*TracedService, error) {
+	if service == nil || provider == nil {
+		return nil, errors.Wrap(en.ErrNilDependency, "traced service")
+	}
+	return &TracedService{service: service, tracer: provider.Tracer(tracerName)}, nil
+}
+
+// endSpan отмечает спан ошибкой и завершает его. Код AppError сохраняется атрибутом,
+// чтобы ожидаемые отказы (NOT_FOUND, PR_MERGED) отличались от сбоев при поиске трассировок
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		var appErr *en.AppError
+		if errors.As(err, &appErr) {
+			span.SetAttributes(attribute.String("app.error_code", string(appErr.Code)))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *TracedService) CreateTeam(ctx context.Context, teamName string, members []en.TeamMember, settings *en.TeamSettings, opts en.TeamCreateOptions) (*en.TeamCreateResult, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.CreateTeam")
+	result, err := t.service.CreateTeam(ctx, teamName, members, settings, opts)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetTeam(ctx context.Context, teamName string) (*en.Team, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetTeam")
+	result, err := t.service.GetTeam(ctx, teamName)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetTeamSettings(ctx context.Context, teamName string) (*en.TeamSettings, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetTeamSettings")
+	result, err := t.service.GetTeamSettings(ctx, teamName)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) UpdateTeamSettings(ctx context.Context, settings *en.TeamSettingsUpdate) (*en.TeamSettings, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.UpdateTeamSettings")
+	result, err := t.service.UpdateTeamSettings(ctx, settings)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) AddTeamMembers(ctx context.Context, teamName string, members []en.TeamMember) (*en.Team, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.AddTeamMembers")
+	result, err := t.service.AddTeamMembers(ctx, teamName, members)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*en.TeamMembershipChange, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.RemoveTeamMembers")
+	result, err := t.service.RemoveTeamMembers(ctx, teamName, userIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) MoveTeamMember(ctx context.Context, userID, fromTeam, toTeam string) (*en.TeamMembershipChange, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.MoveTeamMember")
+	result, err := t.service.MoveTeamMember(ctx, userID, fromTeam, toTeam)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) RenameTeam(ctx context.Context, oldName, newName string) (*en.Team, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.RenameTeam")
+	result, err := t.service.RenameTeam(ctx, oldName, newName)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) DeleteTeam(ctx context.Context, teamName string) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.DeleteTeam")
+	err := t.service.DeleteTeam(ctx, teamName)
+	endSpan(span, err)
+	return err
+}
+
+func (t *TracedService) SetUserActive(ctx context.Context, userID string, isActive bool) (*en.User, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.SetUserActive")
+	result, err := t.service.SetUserActive(ctx, userID, isActive)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetUserReviews(ctx context.Context, userID string) ([]*en.PullRequestShort, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetUserReviews")
+	result, err := t.service.GetUserReviews(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) SetUserReviewCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*en.User, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.SetUserReviewCapacity")
+	result, err := t.service.SetUserReviewCapacity(ctx, userID, maxOpenReviews)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetReviewerLoad(ctx context.Context, userID string) (*en.ReviewerLoad, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetReviewerLoad")
+	result, err := t.service.GetReviewerLoad(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) SetUserSkills(ctx context.Context, userID string, skills []string) (*en.User, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.SetUserSkills")
+	result, err := t.service.SetUserSkills(ctx, userID, skills)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) CreateUnavailability(ctx context.Context, period *en.Unavailability) (*en.Unavailability, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.CreateUnavailability")
+	result, err := t.service.CreateUnavailability(ctx, period)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetUnavailability(ctx context.Context, periodID int64) (*en.Unavailability, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetUnavailability")
+	result, err := t.service.GetUnavailability(ctx, periodID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListUnavailability(ctx context.Context, userID string) ([]*en.Unavailability, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListUnavailability")
+	result, err := t.service.ListUnavailability(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) DeleteUnavailability(ctx context.Context, periodID int64) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.DeleteUnavailability")
+	err := t.service.DeleteUnavailability(ctx, periodID)
+	endSpan(span, err)
+	return err
+}
+
+func (t *TracedService) GetNotificationSettings(ctx context.Context, userID string) (*en.NotificationSettings, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetNotificationSettings")
+	result, err := t.service.GetNotificationSettings(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) UpdateNotificationSettings(ctx context.Context, settings *en.NotificationSettings) (*en.NotificationSettings, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.UpdateNotificationSettings")
+	result, err := t.service.UpdateNotificationSettings(ctx, settings)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetReviewDigest(ctx context.Context, userID string) (*en.ReviewDigest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetReviewDigest")
+	result, err := t.service.GetReviewDigest(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) CreatePullRequest(ctx context.Context, prID, prName, authorID string, opts en.PRCreateOptions) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.CreatePullRequest")
+	result, err := t.service.CreatePullRequest(ctx, prID, prName, authorID, opts)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetPullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetPullRequest")
+	result, err := t.service.GetPullRequest(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListPullRequests(ctx context.Context, filter en.PRListFilter) (*en.PRPage, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListPullRequests")
+	result, err := t.service.ListPullRequests(ctx, filter)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetPullRequestHistory(ctx context.Context, prID string) ([]*en.PREvent, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetPullRequestHistory")
+	result, err := t.service.GetPullRequestHistory(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListOverdueReviews(ctx context.Context, teamName string) ([]*en.OverdueReview, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListOverdueReviews")
+	result, err := t.service.ListOverdueReviews(ctx, teamName)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) MergePullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.MergePullRequest")
+	result, err := t.service.MergePullRequest(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ClosePullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ClosePullRequest")
+	result, err := t.service.ClosePullRequest(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ReopenPullRequest(ctx context.Context, prID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ReopenPullRequest")
+	result, err := t.service.ReopenPullRequest(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) MarkPullRequestReady(ctx context.Context, prID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.MarkPullRequestReady")
+	result, err := t.service.MarkPullRequestReady(ctx, prID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) SubmitReview(ctx context.Context, prID, reviewerID string, verdict en.ReviewVerdict, comment string) (*en.Review, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.SubmitReview")
+	result, err := t.service.SubmitReview(ctx, prID, reviewerID, verdict, comment)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*en.PullRequest, string, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ReassignReviewer")
+	result, newReviewerID, err := t.service.ReassignReviewer(ctx, prID, oldUserID)
+	endSpan(span, err)
+	return result, newReviewerID, err
+}
+
+func (t *TracedService) ReassignReviewerTo(ctx context.Context, prID, oldUserID, newUserID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ReassignReviewerTo")
+	result, err := t.service.ReassignReviewerTo(ctx, prID, oldUserID, newUserID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) AddReviewer(ctx context.Context, prID, userID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.AddReviewer")
+	result, err := t.service.AddReviewer(ctx, prID, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) RemoveReviewer(ctx context.Context, prID, userID string) (*en.PullRequest, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.RemoveReviewer")
+	result, err := t.service.RemoveReviewer(ctx, prID, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) (*en.DeactivateResult, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.DeactivateTeamMembers")
+	result, err := t.service.DeactivateTeamMembers(ctx, teamName, userIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetStats(ctx context.Context) (*en.Stats, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetStats")
+	result, err := t.service.GetStats(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetPRThroughput(ctx context.Context, filter en.AnalyticsFilter) ([]en.ThroughputPoint, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetPRThroughput")
+	result, err := t.service.GetPRThroughput(ctx, filter)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetReviewTimings(ctx context.Context, filter en.AnalyticsFilter) (*en.ReviewTimings, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetReviewTimings")
+	result, err := t.service.GetReviewTimings(ctx, filter)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetReassignmentStats(ctx context.Context, filter en.AnalyticsFilter) ([]en.TeamReassignments, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetReassignmentStats")
+	result, err := t.service.GetReassignmentStats(ctx, filter)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetWorkloadTrend(ctx context.Context, filter en.AnalyticsFilter) ([]en.WorkloadPoint, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetWorkloadTrend")
+	result, err := t.service.GetWorkloadTrend(ctx, filter)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) CreateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.CreateWebhookSubscription")
+	result, err := t.service.CreateWebhookSubscription(ctx, sub)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*en.WebhookSubscription, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.GetWebhookSubscription")
+	result, err := t.service.GetWebhookSubscription(ctx, subscriptionID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListWebhookSubscriptions(ctx context.Context) ([]*en.WebhookSubscription, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListWebhookSubscriptions")
+	result, err := t.service.ListWebhookSubscriptions(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) UpdateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.UpdateWebhookSubscription")
+	result, err := t.service.UpdateWebhookSubscription(ctx, sub)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.DeleteWebhookSubscription")
+	err := t.service.DeleteWebhookSubscription(ctx, subscriptionID)
+	endSpan(span, err)
+	return err
+}
+
+func (t *TracedService) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status en.DeliveryStatus) ([]*en.WebhookDelivery, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListWebhookDeliveries")
+	result, err := t.service.ListWebhookDeliveries(ctx, subscriptionID, status)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) RedeliverWebhook(ctx context.Context, deliveryID int64) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.RedeliverWebhook")
+	err := t.service.RedeliverWebhook(ctx, deliveryID)
+	endSpan(span, err)
+	return err
+}
+
+func (t *TracedService) HandleGitEvent(ctx context.Context, event en.GitPREvent) (*en.GitEventResult, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.HandleGitEvent")
+	result, err := t.service.HandleGitEvent(ctx, event)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) SetExternalIdentity(ctx context.Context, identity *en.ExternalIdentity) (*en.ExternalIdentity, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.SetExternalIdentity")
+	result, err := t.service.SetExternalIdentity(ctx, identity)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListExternalIdentities(ctx context.Context, provider en.GitProvider) ([]*en.ExternalIdentity, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListExternalIdentities")
+	result, err := t.service.ListExternalIdentities(ctx, provider)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) DeleteExternalIdentity(ctx context.Context, provider en.GitProvider, login string) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.DeleteExternalIdentity")
+	err := t.service.DeleteExternalIdentity(ctx, provider, login)
+	endSpan(span, err)
+	return err
+}
+
+func (t *TracedService) IssueAPIToken(ctx context.Context, userID string, role en.Role) (*en.APIToken, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.IssueAPIToken")
+	result, err := t.service.IssueAPIToken(ctx, userID, role)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) AuthenticateAPIToken(ctx context.Context, plain string) (*en.Principal, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.AuthenticateAPIToken")
+	result, err := t.service.AuthenticateAPIToken(ctx, plain)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) ListAPITokens(ctx context.Context, userID string) ([]*en.APIToken, error) {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.ListAPITokens")
+	result, err := t.service.ListAPITokens(ctx, userID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t *TracedService) RevokeAPIToken(ctx context.Context, tokenID int64) error {
+	ctx, span := t.tracer.Start(ctx, "ServiceStorage.RevokeAPIToken")
+	err := t.service.RevokeAPIToken(ctx, tokenID)
+	endSpan(span, err)
+	return err
+}
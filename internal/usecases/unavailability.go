@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+// CreateUnavailability добавляет период недоступности пользователя. Пока период идет, пользователь
+// не выбирается ревьювером; его открытые ревью переназначает AvailabilityScheduler после начала периода
+func (s *ServiceStorage) CreateUnavailability(ctx context.Context, period *en.Unavailability) (*en.Unavailability, error) {
+	if period == nil || period.UserID == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	if period.StartsAt.IsZero() || period.EndsAt.IsZero() {
+		return nil, errors.New("starts_at and ends_at are required")
+	}
+	if !period.EndsAt.After(period.StartsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+	if !period.EndsAt.After(time.Now()) {
+		return nil, errors.New("unavailability period has already ended")
+	}
+
+	user, err := s.storage.GetUser(ctx, period.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, en.NewNotFoundError("user", period.UserID)
+	}
+
+	created, err := s.storage.CreateUnavailability(ctx, &en.Unavailability{
+		UserID:   period.UserID,
+		StartsAt: period.StartsAt.UTC(),
+		EndsAt:   period.EndsAt.UTC(),
+		Reason:   period.Reason,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create unavailability period")
+	}
+	return created, nil
+}
+
+func (s *ServiceStorage) GetUnavailability(ctx context.Context, periodID int64) (*en.Unavailability, error) {
+	period, err := s.storage.GetUnavailability(ctx, periodID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get unavailability period")
+	}
+	if period == nil {
+		return nil, en.NewNotFoundError("unavailability period", formatID(periodID))
+	}
+	return period, nil
+}
+
+// ListUnavailability возвращает текущие и будущие периоды пользователя, userID может быть пустым
+func (s *ServiceStorage) ListUnavailability(ctx context.Context, userID string) ([]*en.Unavailability, error) {
+	periods, err := s.storage.ListUnavailability(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list unavailability periods")
+	}
+	if periods == nil {
+		periods = []*en.Unavailability{}
+	}
+	return periods, nil
+}
+
+// DeleteUnavailability удаляет период. Ревью, уже переназначенные при его начале, не возвращаются
+func (s *ServiceStorage) DeleteUnavailability(ctx context.Context, periodID int64) error {
+	deleted, err := s.storage.DeleteUnavailability(ctx, periodID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete unavailability period")
+	}
+	if !deleted {
+		return en.NewNotFoundError("unavailability period", formatID(periodID))
+	}
+	return nil
+}
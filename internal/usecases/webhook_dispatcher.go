@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// WebhookSender отправляет подписанный payload доставки. Ошибка означает, что попытку нужно повторить
+type WebhookSender interface {
+	Send(ctx context.Context, delivery *en.WebhookDelivery) error
+}
+
+// WebhookDispatcherConfig параметры доставки. Нулевые значения заменяются значениями по умолчанию
+type WebhookDispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	SendTimeout  time.Duration // таймаут одной отправки
+	// Lease на сколько откладываются забранные доставки. Пачка отправляется последовательно, поэтому аренда
+	// не бывает меньше BatchSize * SendTimeout с запасом, иначе другой воркер заберет еще не отправленные доставки
+	Lease time.Duration
+}
+
+// leaseMargin запас аренды на отметку результатов отправки в базе
+const leaseMargin = 30 * time.Second
+
+func (c WebhookDispatcherConfig) withDefaults() WebhookDispatcherConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 50
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 10 * time.Minute
+	}
+	if c.SendTimeout <= 0 {
+		c.SendTimeout = 5 * time.Second
+	}
+	if minLease := time.Duration(c.BatchSize)*c.SendTimeout + leaseMargin; c.Lease < minLease {
+		c.Lease = minLease
+	}
+	return c
+}
+
+// WebhookDispatcher периодически забирает доставки из outbox и отправляет их подписчикам
+type WebhookDispatcher struct {
+	storage Storage
+	sender  WebhookSender
+	cfg     WebhookDispatcherConfig
+	now     func() time.Time
+}
+
+func NewWebhookDispatcher(storage Storage, sender WebhookSender, cfg WebhookDispatcherConfig) (*WebhookDispatcher, error) {
+	if storage == nil || sender == nil {
+		return nil, errors.Wrap(en.ErrNilDependency, "webhook dispatcher")
+	}
+	return &WebhookDispatcher{storage: storage, sender: sender, cfg: cfg.withDefaults(), now: time.Now}, nil
+}
+
+// Run обрабатывает очередь до отмены контекста
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	runPeriodically(ctx, "webhook_dispatcher", d.cfg.PollInterval, func(ctx context.Context) error {
+		_, err := d.DispatchOnce(ctx)
+		return err
+	})
+}
+
+// DispatchOnce отправляет одну пачку готовых доставок и возвращает их количество
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.storage.ClaimWebhookDeliveries(ctx, d.cfg.BatchSize, d.now().UTC(), d.cfg.Lease)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to claim webhook deliveries")
+	}
+
+	for _, delivery := range deliveries {
+		sendErr := d.sender.Send(ctx, delivery)
+		if sendErr == nil {
+			if err := d.storage.MarkWebhookDelivered(ctx, delivery.DeliveryID, d.now().UTC()); err != nil {
+				return 0, errors.Wrap(err, "failed to mark webhook delivered")
+			}
+			continue
+		}
+
+		// после последней попытки доставка уходит в DEAD
+		var next *time.Time
+		if attempt := delivery.Attempts + 1; attempt < d.cfg.MaxAttempts {
+			at := d.now().UTC().Add(d.backoff(attempt))
+			next = &at
+		}
+		if err := d.storage.MarkWebhookFailed(ctx, delivery.DeliveryID, sendErr.Error(), next); err != nil {
+			return 0, errors.Wrap(err, "failed to mark webhook failed")
+		}
+	}
+	return len(deliveries), nil
+}
+
+// backoff экспоненциальная задержка перед попыткой attempt+1: base, 2*base, 4*base ... не больше MaxBackoff
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender struct {
+	fail map[int64]bool
+	sent []int64
+}
+
+func (f *fakeSender) Send(_ context.Context, d *en.WebhookDelivery) error {
+	f.sent = append(f.sent, d.DeliveryID)
+	if f.fail[d.DeliveryID] {
+		return errors.New("unexpected status 500")
+	}
+	return nil
+}
+
+func newTestDispatcher(t *testing.T, storage Storage, sender WebhookSender, now time.Time) *WebhookDispatcher {
+	d, err := NewWebhookDispatcher(storage, sender, WebhookDispatcherConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  3 * time.Second,
+	})
+	require.NoError(t, err)
+	d.now = func() time.Time { return now }
+	return d
+}
+
+func TestWebhookDispatcher_DispatchOnce(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	sender := &fakeSender{fail: map[int64]bool{2: true, 3: true}}
+	now := time.Date(2025, 11, 16, 10, 0, 0, 0, time.UTC)
+	dispatcher := newTestDispatcher(t, mockStorage, sender, now)
+
+	ctx := context.Background()
+	deliveries := []*en.WebhookDelivery{
+		{DeliveryID: 1, Attempts: 0},
+		{DeliveryID: 2, Attempts: 1},
+		{DeliveryID: 3, Attempts: 2},
+	}
+
+	// пачка из 50 доставок по 5 секунд на отправку и запас 30 секунд
+	mockStorage.EXPECT().ClaimWebhookDeliveries(ctx, 50, now, 280*time.Second).Return(deliveries, nil).Once()
+	mockStorage.EXPECT().MarkWebhookDelivered(ctx, int64(1), now).Return(nil).Once()
+	retryAt := now.Add(2 * time.Second)
+	mockStorage.EXPECT().MarkWebhookFailed(ctx, int64(2), "unexpected status 500", &retryAt).Return(nil).Once()
+	mockStorage.EXPECT().MarkWebhookFailed(ctx, int64(3), "unexpected status 500", (*time.Time)(nil)).Return(nil).Once()
+
+	n, err := dispatcher.DispatchOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []int64{1, 2, 3}, sender.sent)
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	dispatcher := newTestDispatcher(t, NewMockStorage(t), &fakeSender{}, time.Now())
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 3*time.Second, dispatcher.backoff(3))
+	assert.Equal(t, 3*time.Second, dispatcher.backoff(10))
+}
+
+func TestWebhookDispatcherConfig_LeaseCoversBatch(t *testing.T) {
+	cfg := WebhookDispatcherConfig{BatchSize: 10, SendTimeout: 10 * time.Second, Lease: time.Minute}.withDefaults()
+	assert.Equal(t, 130*time.Second, cfg.Lease)
+
+	cfg = WebhookDispatcherConfig{BatchSize: 10, SendTimeout: time.Second, Lease: time.Hour}.withDefaults()
+	assert.Equal(t, time.Hour, cfg.Lease)
+}
+
+func TestWebhookDispatcher_ClaimError(t *testing.T) {
+	mockStorage := NewMockStorage(t)
+	now := time.Now().UTC()
+	dispatcher := newTestDispatcher(t, mockStorage, &fakeSender{}, now)
+
+	ctx := context.Background()
+	mockStorage.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, now, mock.Anything).
+		Return(nil, errors.New("connection refused")).Once()
+
+	_, err := dispatcher.DispatchOnce(ctx)
+
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/pkg/errors"
+)
+
+// createWebhookSubscription регистрирует подписку на события PR
+func (s *ServiceStorage) CreateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	if err := validateWebhookSubscription(sub); err != nil {
+		return nil, err
+	}
+	created, err := s.storage.CreateWebhookSubscription(ctx, sub)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook subscription")
+	}
+	return created, nil
+}
+
+// getWebhookSubscription возвращает подписку по идентификатору
+func (s *ServiceStorage) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (*en.WebhookSubscription, error) {
+	sub, err := s.storage.GetWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook subscription")
+	}
+	if sub == nil {
+		return nil, en.NewNotFoundError("webhook subscription", formatID(subscriptionID))
+	}
+	return sub, nil
+}
+
+// listWebhookSubscriptions возвращает все подписки
+func (s *ServiceStorage) ListWebhookSubscriptions(ctx context.Context) ([]*en.WebhookSubscription, error) {
+	subs, err := s.storage.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook subscriptions")
+	}
+	if subs == nil {
+		subs = []*en.WebhookSubscription{}
+	}
+	return subs, nil
+}
+
+// updateWebhookSubscription заменяет адрес, секрет, типы событий и флаг активности подписки
+func (s *ServiceStorage) UpdateWebhookSubscription(ctx context.Context, sub *en.WebhookSubscription) (*en.WebhookSubscription, error) {
+	if err := validateWebhookSubscription(sub); err != nil {
+		return nil, err
+	}
+	updated, err := s.storage.UpdateWebhookSubscription(ctx, sub)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update webhook subscription")
+	}
+	if updated == nil {
+		return nil, en.NewNotFoundError("webhook subscription", formatID(sub.SubscriptionID))
+	}
+	return updated, nil
+}
+
+// deleteWebhookSubscription удаляет подписку вместе с её очередью доставок
+func (s *ServiceStorage) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) error {
+	deleted, err := s.storage.DeleteWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete webhook subscription")
+	}
+	if !deleted {
+		return en.NewNotFoundError("webhook subscription", formatID(subscriptionID))
+	}
+	return nil
+}
+
+// listWebhookDeliveries возвращает последние доставки подписки, опционально по статусу
+func (s *ServiceStorage) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status en.DeliveryStatus) ([]*en.WebhookDelivery, error) {
+	switch status {
+	case "", en.DeliveryPending, en.DeliveryDelivered, en.DeliveryDead:
+	default:
+		return nil, errors.Errorf("unknown delivery status '%s'", status)
+	}
+	if _, err := s.GetWebhookSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.storage.ListWebhookDeliveries(ctx, subscriptionID, status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook deliveries")
+	}
+	if deliveries == nil {
+		deliveries = []*en.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// redeliverWebhook возвращает доставку из DEAD в очередь
+func (s *ServiceStorage) RedeliverWebhook(ctx context.Context, deliveryID int64) error {
+	requeued, err := s.storage.RequeueWebhookDelivery(ctx, deliveryID, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to requeue webhook delivery")
+	}
+	if !requeued {
+		return en.NewNotFoundError("dead webhook delivery", formatID(deliveryID))
+	}
+	return nil
+}
+
+func validateWebhookSubscription(sub *en.WebhookSubscription) error {
+	if sub == nil {
+		return errors.New("subscription cannot be empty")
+	}
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid webhook url '%s'", sub.URL)
+	}
+	if sub.Secret == "" {
+		return errors.New("webhook secret cannot be empty")
+	}
+	for _, t := range sub.EventTypes {
+		switch t {
+		case en.EventPRCreated, en.EventStatusChanged, en.EventReviewerAssigned, en.EventReviewerReassigned, en.EventReviewerRemoved:
+		default:
+			return errors.Errorf("unknown event type '%s'", t)
+		}
+	}
+	if sub.EventTypes == nil {
+		sub.EventTypes = []en.PREventType{}
+	}
+	return nil
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,123 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestAnalytics(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "stats",
+		"members": []map[string]interface{}{
+			{"user_id": "st1", "username": "ST1", "is_active": true},
+			{"user_id": "st2", "username": "ST2", "is_active": true},
+			{"user_id": "st3", "username": "ST3", "is_active": true},
+			{"user_id": "st4", "username": "ST4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	reviewers := make(map[string][]interface{})
+	for _, id := range []string{"pr-st-1", "pr-st-2", "pr-st-3"} {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": id, "pull_request_name": "Stats", "author_id": "st1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		reviewers[id] = result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+		require.Len(t, reviewers[id], 2)
+	}
+
+	for _, id := range []string{"pr-st-1", "pr-st-2"} {
+		code, _ := postPR(t, env, "/pullRequest/review", map[string]string{
+			"pull_request_id": id, "reviewer_id": reviewers[id][0].(string), "verdict": "APPROVED",
+		})
+		require.Equal(t, http.StatusOK, code)
+		code, _ = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": id})
+		require.Equal(t, http.StatusOK, code)
+	}
+	code, _ = postPR(t, env, "/pullRequest/reassign", map[string]string{
+		"pull_request_id": "pr-st-3", "old_user_id": reviewers["pr-st-3"][0].(string),
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Format(time.DateOnly)
+	period := "from=" + today + "&to=" + tomorrow
+
+	t.Run("throughput", func(t *testing.T) {
+		code, result := getJSON(t, env, "/stats/throughput?team_name=stats&"+period)
+		require.Equal(t, http.StatusOK, code)
+		points := result["points"].([]interface{})
+		require.Len(t, points, 1)
+		point := points[0].(map[string]interface{})
+		assert.Equal(t, "stats", point["team_name"])
+		assert.Equal(t, float64(3), point["created"])
+		assert.Equal(t, float64(2), point["merged"])
+		assert.Equal(t, today+"T00:00:00Z", point["bucket_start"])
+
+		code, _ = getJSON(t, env, "/stats/throughput?bucket=month")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = getJSON(t, env, "/stats/throughput?from=yesterday")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = getJSON(t, env, "/stats/throughput?team_name=ghost")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("timings", func(t *testing.T) {
+		code, result := getJSON(t, env, "/stats/timings?team_name=stats&"+period)
+		require.Equal(t, http.StatusOK, code)
+		timings := result["timings"].(map[string]interface{})
+		overall := timings["overall"].(map[string]interface{})
+		toMerge := overall["time_to_merge"].(map[string]interface{})
+		assert.Equal(t, float64(2), toMerge["count"])
+		assert.NotNil(t, toMerge["median_seconds"])
+		assert.NotNil(t, toMerge["p90_seconds"])
+		assert.Equal(t, float64(2), overall["time_to_first_review"].(map[string]interface{})["count"])
+
+		teams := timings["teams"].([]interface{})
+		require.Len(t, teams, 1)
+		assert.Equal(t, "stats", teams[0].(map[string]interface{})["team_name"])
+
+		// без данных за период перцентили не определены
+		code, result = getJSON(t, env, "/stats/timings?team_name=stats&from=2020-01-01&to=2020-02-01")
+		require.Equal(t, http.StatusOK, code)
+		empty := result["timings"].(map[string]interface{})["overall"].(map[string]interface{})["time_to_merge"].(map[string]interface{})
+		assert.Equal(t, float64(0), empty["count"])
+		assert.Nil(t, empty["median_seconds"])
+	})
+
+	t.Run("reassignments", func(t *testing.T) {
+		code, result := getJSON(t, env, "/stats/reassignments?team_name=stats&"+period)
+		require.Equal(t, http.StatusOK, code)
+		teams := result["teams"].([]interface{})
+		require.Len(t, teams, 1)
+		team := teams[0].(map[string]interface{})
+		assert.Equal(t, float64(7), team["assignments"])
+		assert.Equal(t, float64(1), team["reassignments"])
+		assert.InDelta(t, 1.0/7, team["rate"], 0.0001)
+		assert.Equal(t, float64(1), team["reasons"].(map[string]interface{})["manual_reassign"])
+	})
+
+	t.Run("workload", func(t *testing.T) {
+		reviewer := reviewers["pr-st-1"][0].(string)
+		code, result := getJSON(t, env, "/stats/workload?bucket=week&user_id="+reviewer+"&team_name=stats")
+		require.Equal(t, http.StatusOK, code)
+		points := result["points"].([]interface{})
+		require.Len(t, points, 1)
+		point := points[0].(map[string]interface{})
+		assert.Equal(t, reviewer, point["user_id"])
+		assert.GreaterOrEqual(t, point["assigned"], float64(1))
+		assert.GreaterOrEqual(t, point["reviewed"], float64(1))
+
+		code, _ = getJSON(t, env, "/stats/workload?user_id=ghost")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
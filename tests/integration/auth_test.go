@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/auth"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+)
+
+func doWithToken(t *testing.T, method, url, token string, payload interface{}) (int, map[string]interface{}) {
+	var body bytes.Buffer
+	if payload != nil {
+		require.NoError(t, json.NewEncoder(&body).Encode(payload))
+	}
+	req, err := http.NewRequest(method, url, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+//nolint:funlen
+func TestAuthentication(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	const adminToken = "admin-token"
+	jwtSecret := []byte("jwt-secret")
+	verifier, err := auth.NewHS256Verifier(jwtSecret)
+	require.NoError(t, err)
+	srv := env.StartServer(t, public.WithAuth(public.AuthConfig{AdminToken: adminToken, JWT: verifier}))
+
+	team := map[string]interface{}{
+		"team_name": "secure",
+		"members": []map[string]interface{}{
+			{"user_id": "a1", "username": "Alice", "is_active": true},
+			{"user_id": "a2", "username": "Bob", "is_active": true},
+		},
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		code, result := doWithToken(t, http.MethodPost, srv.URL+"/team/add", "", team)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Equal(t, "UNAUTHORIZED", result["error"].(map[string]interface{})["code"])
+	})
+
+	code, _ := doWithToken(t, http.MethodPost, srv.URL+"/team/add", adminToken, team)
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result := doWithToken(t, http.MethodPost, srv.URL+"/auth/tokens/create", adminToken, map[string]string{"user_id": "a1"})
+	require.Equal(t, http.StatusCreated, code)
+	issued := result["token"].(map[string]interface{})
+	userToken := issued["token"].(string)
+
+	t.Run("user token cannot call admin endpoints", func(t *testing.T) {
+		code, result := doWithToken(t, http.MethodPost, srv.URL+"/users/setIsActive", userToken,
+			map[string]interface{}{"user_id": "a2", "is_active": false})
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, "FORBIDDEN", result["error"].(map[string]interface{})["code"])
+
+		code, _ = doWithToken(t, http.MethodPost, srv.URL+"/team/deactivateMembers", userToken,
+			map[string]interface{}{"team_name": "secure", "user_ids": []string{"a2"}})
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("getReview only for self", func(t *testing.T) {
+		code, _ := doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a1", userToken, nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		code, _ = doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a2", userToken, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+
+		code, _ = doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a2", adminToken, nil)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("actor comes from token", func(t *testing.T) {
+		code, _ := doWithToken(t, http.MethodPost, srv.URL+"/pullRequest/create", userToken, map[string]string{
+			"pull_request_id": "pr-auth", "pull_request_name": "Auth", "author_id": "a1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+
+		code, result := doWithToken(t, http.MethodGet, srv.URL+"/pullRequest/history?pull_request_id=pr-auth", userToken, nil)
+		require.Equal(t, http.StatusOK, code)
+		events := result["events"].([]interface{})
+		require.NotEmpty(t, events)
+		assert.Equal(t, "a1", events[0].(map[string]interface{})["actor"])
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		valid, err := auth.SignHS256(jwtSecret, auth.Claims{Subject: "a2", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+		require.NoError(t, err)
+		code, _ := doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a2", valid, nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		expired, err := auth.SignHS256(jwtSecret, auth.Claims{Subject: "a2", ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+		require.NoError(t, err)
+		code, _ = doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a2", expired, nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		forged, err := auth.SignHS256([]byte("other"), auth.Claims{Subject: "a2", Role: "admin", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+		require.NoError(t, err)
+		code, _ = doWithToken(t, http.MethodPost, srv.URL+"/team/add", forged, team)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		code, _ := doWithToken(t, http.MethodPost, srv.URL+"/auth/tokens/revoke", adminToken,
+			map[string]interface{}{"token_id": issued["token_id"]})
+		require.Equal(t, http.StatusOK, code)
+
+		code, _ = doWithToken(t, http.MethodGet, srv.URL+"/users/getReview?user_id=a1", userToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestCodeOwners(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "core",
+		"members": []map[string]interface{}{
+			{"user_id": "co1", "username": "CO1", "is_active": true},
+			{"user_id": "co2", "username": "CO2", "is_active": true},
+			{"user_id": "co3", "username": "CO3", "is_active": true},
+			{"user_id": "co4", "username": "CO4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "dba",
+		"members": []map[string]interface{}{
+			{"user_id": "db1", "username": "DB1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("unknown owner is rejected", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "core", "min_reviewers": 1, "max_reviewers": 2,
+			"owner_rules": []map[string]interface{}{{"pattern": "*.go", "owners": []string{"@ghosts"}}},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("rules are saved in order", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "core", "min_reviewers": 1, "max_reviewers": 2,
+			"owner_rules": []map[string]interface{}{
+				{"pattern": "*.go", "owners": []string{"co2"}},
+				{"pattern": "/migrations/", "owners": []string{"@dba"}},
+			},
+		})
+		require.Equal(t, http.StatusOK, code)
+		rules := result["settings"].(map[string]interface{})["owner_rules"].([]interface{})
+		require.Len(t, rules, 2)
+		assert.Equal(t, "*.go", rules[0].(map[string]interface{})["pattern"])
+
+		code, settings := getJSON(t, env, "/team/settings?team_name=core")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, settings["settings"].(map[string]interface{})["owner_rules"], 2)
+	})
+
+	t.Run("owners are assigned first", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-co-1", "pull_request_name": "Schema", "author_id": "co1",
+			"changed_files": []string{"internal/app.go", "migrations/001.up.sql"},
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.ElementsMatch(t, []interface{}{"co2", "db1"}, pr["assigned_reviewers"])
+		assert.Equal(t, map[string]interface{}{"co2": "*.go", "db1": "/migrations/"}, pr["reviewer_rules"])
+		assert.Equal(t, "dba", pr["reviewer_teams"].(map[string]interface{})["db1"])
+
+		code, stored := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-co-1")
+		require.Equal(t, http.StatusOK, code)
+		storedPR := stored["pr"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"internal/app.go", "migrations/001.up.sql"}, storedPR["changed_files"])
+		assert.Equal(t, pr["reviewer_rules"], storedPR["reviewer_rules"])
+	})
+
+	t.Run("team members fill remaining slots", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-co-2", "pull_request_name": "Code", "author_id": "co1",
+			"changed_files": []string{"main.go"},
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		reviewers := pr["assigned_reviewers"].([]interface{})
+		require.Len(t, reviewers, 2)
+		assert.Contains(t, reviewers, "co2")
+		assert.Equal(t, map[string]interface{}{"co2": "*.go"}, pr["reviewer_rules"])
+	})
+
+	t.Run("author is not assigned as owner", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-co-3", "pull_request_name": "Own", "author_id": "co2",
+			"changed_files": []string{"main.go"},
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.NotContains(t, pr["assigned_reviewers"], "co2")
+		assert.Nil(t, pr["reviewer_rules"])
+	})
+}
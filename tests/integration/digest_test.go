@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/notify"
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestReviewDigest(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "digest",
+		"members": []map[string]interface{}{
+			{"user_id": "dg1", "username": "DG1", "is_active": true},
+			{"user_id": "dg2", "username": "DG2", "is_active": true},
+			{"user_id": "dg3", "username": "DG3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	for _, id := range []string{"pr-dg-1", "pr-dg-2"} {
+		code, _ := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": id, "pull_request_name": "Digest", "author_id": "dg1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+	}
+	code, _ = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-dg-2"})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("settings", func(t *testing.T) {
+		code, result := getJSON(t, env, "/users/notifications?user_id=dg2")
+		require.Equal(t, http.StatusOK, code)
+		settings := result["settings"].(map[string]interface{})
+		assert.Equal(t, "UTC", settings["timezone"])
+		assert.Equal(t, false, settings["digest_opt_out"])
+
+		code, result = postPR(t, env, "/users/notifications", map[string]interface{}{
+			"user_id": "dg2", "email": "dg2@example.com", "timezone": "Europe/Moscow",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "dg2@example.com", result["settings"].(map[string]interface{})["email"])
+
+		code, _ = postPR(t, env, "/users/notifications", map[string]interface{}{
+			"user_id": "dg2", "quiet_hours_start": "22:00",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, _ = postPR(t, env, "/users/notifications", map[string]interface{}{
+			"user_id": "dg2", "digest_time": "25:00",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, result = postPR(t, env, "/users/notifications", map[string]interface{}{
+			"user_id": "dg2", "email": "dg2@example.com", "timezone": "Europe/Moscow", "digest_time": "08:15",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "08:15", result["settings"].(map[string]interface{})["digest_time"])
+
+		code, _ = getJSON(t, env, "/users/notifications?user_id=ghost")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("digest preview", func(t *testing.T) {
+		// смерженный PR в дайджест не попадает
+		code, result := getJSON(t, env, "/users/digest?user_id=dg1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, result["digest"].(map[string]interface{})["reviews"])
+
+		for _, id := range []string{"dg2", "dg3"} {
+			code, result := getJSON(t, env, "/users/digest?user_id="+id)
+			require.Equal(t, http.StatusOK, code)
+			reviews := result["digest"].(map[string]interface{})["reviews"].([]interface{})
+			require.Len(t, reviews, 1)
+			assert.Equal(t, "pr-dg-1", reviews[0].(map[string]interface{})["pull_request_id"])
+		}
+	})
+
+	t.Run("scheduler sends once a day and respects opt-out", func(t *testing.T) {
+		code, _ := postPR(t, env, "/users/notifications", map[string]interface{}{
+			"user_id": "dg3", "digest_opt_out": true,
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		var out bytes.Buffer
+		scheduler, err := usecases.NewDigestScheduler(env.Storage, notify.NewWriterNotifier(&out),
+			usecases.DigestSchedulerConfig{SendTime: "00:00"})
+		require.NoError(t, err)
+		// время отправки dg2 раньше местной полуночи не наступает, поэтому дайджест уходит при любом now
+		_, err = env.Storage.UpsertNotificationSettings(context.Background(), &en.NotificationSettings{
+			UserID: "dg2", Email: "dg2@example.com", Timezone: "Europe/Moscow",
+		})
+		require.NoError(t, err)
+
+		sent, err := scheduler.RunOnce(context.Background())
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+		assert.Equal(t, "dg2", sent[0].UserID)
+		assert.Equal(t, "dg2@example.com", sent[0].Email)
+		assert.Contains(t, out.String(), "pr-dg-1")
+
+		sent, err = scheduler.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+
+		code, result := getJSON(t, env, "/users/notifications?user_id=dg2")
+		require.Equal(t, http.StatusOK, code)
+		assert.NotNil(t, result["settings"].(map[string]interface{})["last_digest_at"])
+	})
+
+	t.Run("storage selects only due digests", func(t *testing.T) {
+		ctx := context.Background()
+		// полночь UTC через несколько суток, позже отметок, поставленных планировщиком выше
+		day := time.Now().UTC().Truncate(24 * time.Hour).Add(72 * time.Hour)
+		dueUsers := func(at time.Time) []string {
+			digests, err := env.Storage.ListDueReviewDigests(ctx, at, "09:00")
+			require.NoError(t, err)
+			users := []string{}
+			for _, digest := range digests {
+				users = append(users, digest.UserID)
+			}
+			return users
+		}
+
+		// свое время отправки и тихие часы через полночь
+		_, err := env.Storage.UpsertNotificationSettings(ctx, &en.NotificationSettings{
+			UserID: "dg2", Timezone: "UTC", DigestTime: "10:00", QuietHoursStart: "20:00", QuietHoursEnd: "10:15",
+		})
+		require.NoError(t, err)
+		// время отправки по умолчанию в Москве (UTC+3)
+		_, err = env.Storage.UpsertNotificationSettings(ctx, &en.NotificationSettings{
+			UserID: "dg3", Timezone: "Europe/Moscow",
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, dueUsers(day.Add(5*time.Hour+30*time.Minute)))
+		assert.Equal(t, []string{"dg3"}, dueUsers(day.Add(9*time.Hour+30*time.Minute)))
+		assert.Equal(t, []string{"dg3"}, dueUsers(day.Add(10*time.Hour+5*time.Minute)))
+		assert.Equal(t, []string{"dg2", "dg3"}, dueUsers(day.Add(10*time.Hour+30*time.Minute)))
+		assert.Equal(t, []string{"dg3"}, dueUsers(day.Add(20*time.Hour+30*time.Minute)))
+
+		// дайджест dg3 уже отправлен в текущие местные сутки, следующие начинаются в 21:00 UTC
+		sentAt := day.Add(7 * time.Hour)
+		claimed, err := env.Storage.SetDigestSentAt(ctx, "dg3", nil, &sentAt)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		assert.Equal(t, []string{"dg2"}, dueUsers(day.Add(10*time.Hour+30*time.Minute)))
+		assert.Equal(t, []string{"dg3"}, dueUsers(day.Add(30*time.Hour+30*time.Minute)))
+
+		_, err = env.Storage.UpsertNotificationSettings(ctx, &en.NotificationSettings{
+			UserID: "dg2", Timezone: "UTC", DigestOptOut: true,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, dueUsers(day.Add(10*time.Hour+30*time.Minute)))
+	})
+}
@@ -0,0 +1,145 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+)
+
+const (
+	testGitHubSecret = "gh-secret"
+	testGitLabSecret = "gl-token"
+)
+
+func postGitWebhook(t *testing.T, url string, headers map[string]string, payload interface{}) (int, map[string]interface{}) {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if headers["X-Hub-Signature-256"] == "" && headers["X-GitHub-Event"] != "" {
+		mac := hmac.New(sha256.New, []byte(testGitHubSecret))
+		mac.Write(body)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func gitHubPRPayload(action string, merged bool) map[string]interface{} {
+	return map[string]interface{}{
+		"action": action,
+		"pull_request": map[string]interface{}{
+			"number": 12,
+			"title":  "Add search",
+			"draft":  false,
+			"merged": merged,
+			"user":   map[string]string{"login": "alice-gh"},
+		},
+		"repository": map[string]string{"full_name": "org/repo"},
+		"sender":     map[string]string{"login": "alice-gh"},
+	}
+}
+
+//nolint:funlen
+func TestGitWebhooks(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	hooks := env.StartServer(t, public.WithGitWebhookSecrets(testGitHubSecret, testGitLabSecret))
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "git",
+		"members": []map[string]interface{}{
+			{"user_id": "g1", "username": "Alice", "is_active": true},
+			{"user_id": "g2", "username": "Bob", "is_active": true},
+			{"user_id": "g3", "username": "Carol", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	githubEvent := map[string]string{"X-GitHub-Event": "pull_request"}
+
+	t.Run("unknown login is rejected", func(t *testing.T) {
+		code, result := postGitWebhook(t, hooks.URL+"/webhooks/github", githubEvent, gitHubPRPayload("opened", false))
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "NOT_FOUND", result["error"].(map[string]interface{})["code"])
+	})
+
+	code, _ = postPR(t, env, "/identities/set", map[string]string{
+		"provider": "github", "login": "alice-gh", "user_id": "g1",
+	})
+	require.Equal(t, http.StatusOK, code)
+	code, _ = postPR(t, env, "/identities/set", map[string]string{
+		"provider": "gitlab", "login": "alice-gl", "user_id": "g1",
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("bad signature", func(t *testing.T) {
+		headers := map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": "sha256=00"}
+		code, result := postGitWebhook(t, hooks.URL+"/webhooks/github", headers, gitHubPRPayload("opened", false))
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Equal(t, "INVALID_SIGNATURE", result["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("github opened then merged", func(t *testing.T) {
+		code, result := postGitWebhook(t, hooks.URL+"/webhooks/github", githubEvent, gitHubPRPayload("opened", false))
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "processed", result["status"])
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "github:org/repo#12", pr["pull_request_id"])
+		assert.Equal(t, "g1", pr["author_id"])
+		assert.Len(t, pr["assigned_reviewers"], 2)
+
+		// повторная доставка того же события не создает дубль
+		code, result = postGitWebhook(t, hooks.URL+"/webhooks/github", githubEvent, gitHubPRPayload("opened", false))
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ignored", result["status"])
+
+		code, result = postGitWebhook(t, hooks.URL+"/webhooks/github", githubEvent, gitHubPRPayload("closed", true))
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "MERGED", result["pr"].(map[string]interface{})["status"])
+	})
+
+	t.Run("gitlab token", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"object_kind": "merge_request",
+			"user":        map[string]string{"username": "alice-gl"},
+			"project":     map[string]string{"path_with_namespace": "org/repo"},
+			"object_attributes": map[string]interface{}{
+				"iid": 3, "title": "Fix login", "action": "open",
+			},
+		}
+
+		code, _ := postGitWebhook(t, hooks.URL+"/webhooks/gitlab", map[string]string{"X-Gitlab-Token": "wrong"}, payload)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code, result := postGitWebhook(t, hooks.URL+"/webhooks/gitlab", map[string]string{"X-Gitlab-Token": testGitLabSecret}, payload)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "gitlab:org/repo#3", result["pr"].(map[string]interface{})["pull_request_id"])
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		code, _ := postGitWebhook(t, env.Server.URL+"/webhooks/github", githubEvent, gitHubPRPayload("opened", false))
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+)
+
+// logBuffer собирает логи сервера: строка журнала доступа пишется уже после отправки ответа
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries возвращает записи с указанным request_id
+func (b *logBuffer) entries(t *testing.T, requestID string) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["request_id"] == requestID {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+//nolint:funlen
+func TestRequestLogging(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	logs := &logBuffer{}
+	srv := env.StartServer(t, public.WithLogger(slog.New(slog.NewJSONHandler(logs, nil))))
+
+	code, _ := doWithToken(t, http.MethodPost, srv.URL+"/team/add", "", map[string]interface{}{
+		"team_name": "logging",
+		"members": []map[string]interface{}{
+			{"user_id": "lg1", "username": "LG1", "is_active": true},
+			{"user_id": "lg2", "username": "LG2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("generated request id", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/team/get?team_name=logging")
+		require.NoError(t, err)
+		resp.Body.Close()
+		requestID := resp.Header.Get(public.RequestIDHeader)
+		assert.Len(t, requestID, 32)
+
+		require.Eventually(t, func() bool { return len(logs.entries(t, requestID)) == 1 }, time.Second, 10*time.Millisecond)
+		entry := logs.entries(t, requestID)[0]
+		assert.Equal(t, "http request", entry["msg"])
+		assert.Equal(t, "/team/get", entry["route"])
+		assert.Equal(t, float64(http.StatusOK), entry["status"])
+	})
+
+	t.Run("incoming request id and assignment decision", func(t *testing.T) {
+		body, err := json.Marshal(map[string]string{
+			"pull_request_id": "pr-lg-1", "pull_request_name": "Logging", "author_id": "lg1",
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/pullRequest/create", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(public.RequestIDHeader, "client-req-42")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "client-req-42", resp.Header.Get(public.RequestIDHeader))
+
+		require.Eventually(t, func() bool { return len(logs.entries(t, "client-req-42")) == 3 }, time.Second, 10*time.Millisecond)
+		entries := logs.entries(t, "client-req-42")
+		assert.Equal(t, "reviewers selected", entries[0]["msg"])
+		assert.Equal(t, []interface{}{"lg2"}, entries[0]["candidates"])
+		assert.Equal(t, []interface{}{"lg2"}, entries[0]["chosen"])
+		assert.Equal(t, "reviewers assigned", entries[1]["msg"])
+		assert.Equal(t, "pr-lg-1", entries[1]["pull_request_id"])
+		assert.Equal(t, "http request", entries[2]["msg"])
+	})
+
+	t.Run("invalid request id is replaced", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/team/get?team_name=logging", nil)
+		require.NoError(t, err)
+		req.Header.Set(public.RequestIDHeader, "bad id with spaces")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Len(t, resp.Header.Get(public.RequestIDHeader), 32)
+	})
+}
@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+)
+
+//nolint:funlen
+func TestManualReviewers(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "api",
+		"members": []map[string]interface{}{
+			{"user_id": "mr1", "username": "MR1", "is_active": true},
+			{"user_id": "mr2", "username": "MR2", "is_active": true},
+			{"user_id": "mr3", "username": "MR3", "is_active": true},
+			{"user_id": "mr4", "username": "MR4", "is_active": false},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "sre",
+		"members": []map[string]interface{}{
+			{"user_id": "sr1", "username": "SR1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "design",
+		"members": []map[string]interface{}{
+			{"user_id": "ds1", "username": "DS1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/team/settings", map[string]interface{}{
+		"team_name": "api", "min_reviewers": 1, "max_reviewers": 1, "fallback_teams": []string{"sre"},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-mr-1", "pull_request_name": "Manual", "author_id": "mr1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	first := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})[0].(string)
+	other := "mr2"
+	if first == "mr2" {
+		other = "mr3"
+	}
+
+	t.Run("add reviewer beyond max", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-mr-1", "user_id": other,
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.ElementsMatch(t, []interface{}{first, other}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+	})
+
+	t.Run("add reviewer from fallback team", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": "pr-mr-1", "user_id": "sr1",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "sre", result["pr"].(map[string]interface{})["reviewer_teams"].(map[string]interface{})["sr1"])
+	})
+
+	t.Run("invalid reviewers are rejected", func(t *testing.T) {
+		for _, tc := range []struct {
+			userID string
+			status int
+			code   string
+		}{
+			{"mr1", http.StatusConflict, "INVALID_REVIEWER"},
+			{"mr4", http.StatusConflict, "INVALID_REVIEWER"},
+			{"sr1", http.StatusConflict, "ALREADY_ASSIGNED"},
+			{"ds1", http.StatusConflict, "INVALID_TEAM_USER"},
+			{"ghost", http.StatusNotFound, "NOT_FOUND"},
+		} {
+			code, result := postPR(t, env, "/pullRequest/addReviewer", map[string]string{
+				"pull_request_id": "pr-mr-1", "user_id": tc.userID,
+			})
+			assert.Equal(t, tc.status, code, tc.userID)
+			assert.Equal(t, tc.code, result["error"].(map[string]interface{})["code"], tc.userID)
+		}
+	})
+
+	t.Run("remove reviewer", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/removeReviewer", map[string]string{
+			"pull_request_id": "pr-mr-1", "user_id": "sr1",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, result["pr"].(map[string]interface{})["assigned_reviewers"], "sr1")
+
+		code, history := getJSON(t, env, "/pullRequest/history?pull_request_id=pr-mr-1")
+		require.Equal(t, http.StatusOK, code)
+		events := history["events"].([]interface{})
+		last := events[len(events)-1].(map[string]interface{})
+		assert.Equal(t, "REVIEWER_REMOVED", last["type"])
+		assert.Equal(t, "manual_remove", last["reason"])
+	})
+
+	t.Run("reassign to explicit user", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/reassign", map[string]string{
+			"pull_request_id": "pr-mr-1", "old_user_id": other, "new_user_id": "sr1",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "sr1", result["replaced_by"])
+		assert.Equal(t, "sre", result["replaced_by_team"])
+		assert.ElementsMatch(t, []interface{}{first, "sr1"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+	})
+
+	t.Run("remove below min reviewers", func(t *testing.T) {
+		code, _ := postPR(t, env, "/pullRequest/removeReviewer", map[string]string{"pull_request_id": "pr-mr-1", "user_id": "sr1"})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/pullRequest/removeReviewer", map[string]string{"pull_request_id": "pr-mr-1", "user_id": first})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "NOT_ENOUGH_REVIEWERS", result["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("concurrent removals keep min reviewers", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-mr-2", "pull_request_name": "Concurrent", "author_id": "mr1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		reviewers := []string{result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})[0].(string), "sr1"}
+		code, _ = postPR(t, env, "/pullRequest/addReviewer", map[string]string{"pull_request_id": "pr-mr-2", "user_id": "sr1"})
+		require.Equal(t, http.StatusOK, code)
+
+		// оба снятия прошли проверку в сервисе, минимум соблюдается за счет проверки под блокировкой PR
+		errs := make(chan error, len(reviewers))
+		var wg sync.WaitGroup
+		for _, userID := range reviewers {
+			wg.Add(1)
+			go func(userID string) {
+				defer wg.Done()
+				errs <- env.Storage.ReassignReviewer(context.Background(), "pr-mr-2", userID, "", "", 1)
+			}(userID)
+		}
+		wg.Wait()
+		close(errs)
+
+		var failed []error
+		for err := range errs {
+			if err != nil {
+				failed = append(failed, err)
+			}
+		}
+		require.Len(t, failed, 1)
+		var appErr *en.AppError
+		require.ErrorAs(t, failed[0], &appErr)
+		assert.Equal(t, en.ErrCodeNotEnoughReviewers, appErr.Code)
+
+		pr, err := env.Storage.GetPR(context.Background(), "pr-mr-2")
+		require.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 1)
+	})
+
+	t.Run("merged PR is read-only", func(t *testing.T) {
+		code, _ := postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-mr-1"})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/pullRequest/addReviewer", map[string]string{"pull_request_id": "pr-mr-1", "user_id": other})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "PR_MERGED", result["error"].(map[string]interface{})["code"])
+	})
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/metrics"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	env := SetupTestEnv(t, usecases.WithMetrics(m))
+	defer env.Cleanup(t)
+	require.NoError(t, m.Register(metrics.NewPoolCollector(env.Storage.PoolStat)))
+	require.NoError(t, m.Register(metrics.NewBusinessCollector(env.Storage, 5*time.Second)))
+	srv := env.StartServer(t, public.WithMetrics(m))
+
+	code, _ := doWithToken(t, http.MethodPost, srv.URL+"/team/add", "", map[string]interface{}{
+		"team_name": "metrics",
+		"members": []map[string]interface{}{
+			{"user_id": "me1", "username": "ME1", "is_active": true},
+			{"user_id": "me2", "username": "ME2", "is_active": true},
+			{"user_id": "me3", "username": "ME3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = doWithToken(t, http.MethodPost, srv.URL+"/pullRequest/create", "", map[string]string{
+		"pull_request_id": "pr-me-1", "pull_request_name": "Metrics", "author_id": "me1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	// оба остальных участника уже ревьюверы, заменить некем
+	code, result := doWithToken(t, http.MethodPost, srv.URL+"/pullRequest/reassign", "", map[string]string{
+		"pull_request_id": "pr-me-1", "old_user_id": "me2",
+	})
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, "NO_CANDIDATE", result["error"].(map[string]interface{})["code"])
+
+	code, _ = doWithToken(t, http.MethodPost, srv.URL+"/pullRequest/removeReviewer", "", map[string]string{
+		"pull_request_id": "pr-me-1", "user_id": "me2",
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = doWithToken(t, http.MethodPost, srv.URL+"/users/setIsActive", "", map[string]interface{}{
+		"user_id": "me3", "is_active": false,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = doWithToken(t, http.MethodGet, srv.URL+"/no/such/path", "", nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	text := string(body)
+
+	for _, line := range []string{
+		`pr_review_http_requests_total{method="POST",route="/pullRequest/create",status="201"} 1`,
+		`pr_review_http_requests_total{method="POST",route="/pullRequest/reassign",status="409"} 1`,
+		`pr_review_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`pr_review_http_request_duration_seconds_count{method="POST",route="/team/add",status="201"} 1`,
+		`pr_review_no_candidate_total 1`,
+		`pr_review_users_deactivated_total 1`,
+		`pr_review_pull_requests{status="OPEN"} 1`,
+		`pr_review_pull_requests{status="MERGED"} 0`,
+		`pr_review_reviewer_assignments_total{reason="auto_assign"} 2`,
+		`pr_review_reviewer_removals_total{reason="manual_remove"} 1`,
+		`pr_review_users{active="false"} 1`,
+		`pr_review_db_pool_max_connections 10`,
+	} {
+		assert.Contains(t, text, line)
+	}
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestMultiTeamMembership(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "product",
+		"members": []map[string]interface{}{
+			{"user_id": "mt1", "username": "MT1", "is_active": true},
+			{"user_id": "mt2", "username": "MT2", "is_active": true},
+			{"user_id": "mt3", "username": "MT3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	// mt1 и mt2 дополнительно входят в гильдию, основной остается product
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name":     "guild",
+		"conflict_mode": "join",
+		"members": []map[string]interface{}{
+			{"user_id": "mt1", "username": "MT1", "is_active": true},
+			{"user_id": "mt2", "username": "MT2", "is_active": true},
+			{"user_id": "gd1", "username": "GD1", "is_active": true},
+			{"user_id": "gd2", "username": "GD2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("team lists members of all teams", func(t *testing.T) {
+		code, product := getJSON(t, env, "/team/get?team_name=product")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, product["team"].(map[string]interface{})["members"], 3)
+
+		code, guild := getJSON(t, env, "/team/get?team_name=guild")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, guild["team"].(map[string]interface{})["members"], 4)
+	})
+
+	t.Run("user keeps primary team", func(t *testing.T) {
+		code, result := postPR(t, env, "/users/setIsActive", map[string]interface{}{"user_id": "mt1", "is_active": true})
+		require.Equal(t, http.StatusOK, code)
+		user := result["user"].(map[string]interface{})
+		assert.Equal(t, "product", user["team_name"])
+		assert.Equal(t, []interface{}{"guild", "product"}, user["teams"])
+	})
+
+	t.Run("pr from primary team by default", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-mt-1", "pull_request_name": "Product", "author_id": "mt1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "product", pr["team_name"])
+		for _, r := range pr["assigned_reviewers"].([]interface{}) {
+			assert.Contains(t, []string{"mt2", "mt3"}, r)
+		}
+	})
+
+	t.Run("pr from chosen team", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-mt-2", "pull_request_name": "Guild", "author_id": "mt1", "team_name": "guild",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "guild", pr["team_name"])
+		reviewers := pr["assigned_reviewers"].([]interface{})
+		require.Len(t, reviewers, 2)
+		for _, r := range reviewers {
+			assert.Contains(t, []string{"mt2", "gd1", "gd2"}, r)
+		}
+
+		code, page := getJSON(t, env, "/pullRequest/list?team_name=guild")
+		require.Equal(t, http.StatusOK, code)
+		prs := page["pull_requests"].([]interface{})
+		require.Len(t, prs, 1)
+		assert.Equal(t, "pr-mt-2", prs[0].(map[string]interface{})["pull_request_id"])
+	})
+
+	t.Run("pr from foreign team is rejected", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-mt-3", "pull_request_name": "Foreign", "author_id": "mt3", "team_name": "guild",
+		})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "INVALID_TEAM_USER", result["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("leaving one team keeps the other", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/members/remove", map[string]interface{}{
+			"team_name": "product", "user_ids": []string{"mt2"},
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/users/setIsActive", map[string]interface{}{"user_id": "mt2", "is_active": true})
+		require.Equal(t, http.StatusOK, code)
+		user := result["user"].(map[string]interface{})
+		assert.Equal(t, "guild", user["team_name"])
+		assert.Equal(t, []interface{}{"guild"}, user["teams"])
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-mt-1")
+		require.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, pr["pr"].(map[string]interface{})["assigned_reviewers"], "mt2")
+	})
+
+	t.Run("deactivation reassigns open reviews", func(t *testing.T) {
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-mt-2")
+		require.Equal(t, http.StatusOK, code)
+		reviewer := pr["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})[0].(string)
+
+		code, result := postPR(t, env, "/team/deactivateMembers", map[string]interface{}{
+			"team_name": "guild", "user_ids": []string{reviewer},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{reviewer}, result["deactivated_users"])
+
+		code, pr = getJSON(t, env, "/pullRequest/get?pull_request_id=pr-mt-2")
+		require.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, pr["pr"].(map[string]interface{})["assigned_reviewers"], reviewer)
+	})
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestPullRequestHistory(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "history",
+		"members": []map[string]interface{}{
+			{"user_id": "h1", "username": "H1", "is_active": true},
+			{"user_id": "h2", "username": "H2", "is_active": true},
+			{"user_id": "h3", "username": "H3", "is_active": true},
+			{"user_id": "h4", "username": "H4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-history",
+		"pull_request_name": "History",
+		"author_id":         "h1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	reviewers := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	oldReviewer := reviewers[0].(string)
+
+	data, _ := json.Marshal(map[string]string{"pull_request_id": "pr-history", "old_user_id": oldReviewer})
+	req, err := http.NewRequest(http.MethodPost, env.Server.URL+"/pullRequest/reassign", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor-ID", "h1")
+	resp, err := env.Client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	code, _ = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-history"})
+	require.Equal(t, http.StatusOK, code)
+
+	code, result = getJSON(t, env, "/pullRequest/history?pull_request_id=pr-history")
+	require.Equal(t, http.StatusOK, code)
+	events := result["events"].([]interface{})
+	require.Len(t, events, 5)
+
+	types := make([]string, len(events))
+	for i, e := range events {
+		types[i] = e.(map[string]interface{})["type"].(string)
+	}
+	assert.Equal(t, []string{"PR_CREATED", "REVIEWER_ASSIGNED", "REVIEWER_ASSIGNED", "REVIEWER_REASSIGNED", "STATUS_CHANGED"}, types)
+
+	reassigned := events[3].(map[string]interface{})
+	assert.Equal(t, "h1", reassigned["actor"])
+	assert.Equal(t, "manual_reassign", reassigned["reason"])
+	assert.Equal(t, oldReviewer, reassigned["old_reviewer_id"])
+
+	merged := events[4].(map[string]interface{})
+	assert.Equal(t, "system", merged["actor"])
+	assert.Equal(t, "OPEN", merged["old_status"])
+	assert.Equal(t, "MERGED", merged["new_status"])
+
+	code, _ = getJSON(t, env, "/pullRequest/history?pull_request_id=missing")
+	assert.Equal(t, http.StatusNotFound, code)
+}
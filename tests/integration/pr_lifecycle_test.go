@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postPR(t *testing.T, env *TestEnv, path string, payload interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	data, _ := json.Marshal(payload)
+	resp, err := env.Client.Post(env.Server.URL+path, "application/json", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return resp.StatusCode, result
+}
+
+//nolint:funlen
+func TestPullRequestLifecycle(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "lifecycle",
+		"members": []map[string]interface{}{
+			{"user_id": "l1", "username": "L1", "is_active": true},
+			{"user_id": "l2", "username": "L2", "is_active": true},
+			{"user_id": "l3", "username": "L3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("DraftHasNoReviewersUntilReady", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id":   "pr-draft",
+			"pull_request_name": "Draft",
+			"author_id":         "l1",
+			"draft":             true,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "DRAFT", pr["status"])
+		assert.Empty(t, pr["assigned_reviewers"])
+
+		code, result = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-draft"})
+		require.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "INVALID_STATUS_TRANSITION", result["error"].(map[string]interface{})["code"])
+
+		code, result = postPR(t, env, "/pullRequest/markReady", map[string]string{"pull_request_id": "pr-draft"})
+		require.Equal(t, http.StatusOK, code)
+		pr = result["pr"].(map[string]interface{})
+		assert.Equal(t, "OPEN", pr["status"])
+		assert.Len(t, pr["assigned_reviewers"], 2)
+	})
+
+	t.Run("CloseAndReopen", func(t *testing.T) {
+		code, _ := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id":   "pr-close",
+			"pull_request_name": "Close me",
+			"author_id":         "l1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+
+		code, result := postPR(t, env, "/pullRequest/close", map[string]string{"pull_request_id": "pr-close"})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "CLOSED", result["pr"].(map[string]interface{})["status"])
+
+		code, result = postPR(t, env, "/pullRequest/reassign", map[string]string{
+			"pull_request_id": "pr-close",
+			"old_user_id":     "l2",
+		})
+		require.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "PR_NOT_OPEN", result["error"].(map[string]interface{})["code"])
+
+		code, result = postPR(t, env, "/pullRequest/reopen", map[string]string{"pull_request_id": "pr-close"})
+		require.Equal(t, http.StatusOK, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "OPEN", pr["status"])
+		assert.Len(t, pr["assigned_reviewers"], 2)
+	})
+
+	t.Run("StatsCountEveryState", func(t *testing.T) {
+		resp, err := env.Client.Get(env.Server.URL + "/stats")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		prStats := result["pr_stats"].(map[string]interface{})
+		assert.Equal(t, float64(2), prStats["open"])
+		assert.Equal(t, float64(0), prStats["draft"])
+		assert.Equal(t, float64(0), prStats["closed"])
+	})
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getJSON(t *testing.T, env *TestEnv, path string) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := env.Client.Get(env.Server.URL + path)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return resp.StatusCode, result
+}
+
+//nolint:funlen
+func TestPullRequestGetAndList(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "listing",
+		"members": []map[string]interface{}{
+			{"user_id": "ls1", "username": "LS1", "is_active": true},
+			{"user_id": "ls2", "username": "LS2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	for i := 1; i <= 5; i++ {
+		code, _ := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id":   fmt.Sprintf("pr-list-%d", i),
+			"pull_request_name": fmt.Sprintf("List %d", i),
+			"author_id":         "ls1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+	}
+	code, _ = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-list-1"})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("Get", func(t *testing.T) {
+		code, result := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-list-1")
+		require.Equal(t, http.StatusOK, code)
+		pr := result["pr"].(map[string]interface{})
+		assert.Equal(t, "MERGED", pr["status"])
+		assert.Equal(t, []interface{}{"ls2"}, pr["assigned_reviewers"])
+		assert.NotEmpty(t, pr["mergedAt"])
+
+		code, _ = getJSON(t, env, "/pullRequest/get?pull_request_id=missing")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("FilterByStatusAndReviewer", func(t *testing.T) {
+		code, result := getJSON(t, env, "/pullRequest/list?status=OPEN&reviewer_id=ls2&team_name=listing")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, result["pull_requests"], 4)
+		assert.Nil(t, result["next_cursor"])
+	})
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		var ids []string
+		query := url.Values{"sort_by": {"pull_request_name"}, "order": {"asc"}, "limit": {"2"}}
+		for page := 0; page < 5; page++ {
+			code, result := getJSON(t, env, "/pullRequest/list?"+query.Encode())
+			require.Equal(t, http.StatusOK, code)
+			for _, item := range result["pull_requests"].([]interface{}) {
+				ids = append(ids, item.(map[string]interface{})["pull_request_id"].(string))
+			}
+			cursor, ok := result["next_cursor"].(string)
+			if !ok {
+				break
+			}
+			query.Set("cursor", cursor)
+		}
+		assert.Equal(t, []string{"pr-list-1", "pr-list-2", "pr-list-3", "pr-list-4", "pr-list-5"}, ids)
+	})
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		code, _ := getJSON(t, env, "/pullRequest/list?created_from=yesterday")
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, _ = getJSON(t, env, "/pullRequest/list?sort_by=author_id")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestReviewVerdictsAndMergeGating(t *testing.T) {
+	env := SetupTestEnv(t, usecases.WithMergePolicy(usecases.MergePolicy{
+		RequiredApprovals:       2,
+		BlockOnChangesRequested: true,
+	}))
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "reviews",
+		"members": []map[string]interface{}{
+			{"user_id": "rv1", "username": "RV1", "is_active": true},
+			{"user_id": "rv2", "username": "RV2", "is_active": true},
+			{"user_id": "rv3", "username": "RV3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-review",
+		"pull_request_name": "Review me",
+		"author_id":         "rv1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	review := func(reviewer, verdict string) (int, map[string]interface{}) {
+		return postPR(t, env, "/pullRequest/review", map[string]string{
+			"pull_request_id": "pr-review",
+			"reviewer_id":     reviewer,
+			"verdict":         verdict,
+		})
+	}
+	merge := func() (int, map[string]interface{}) {
+		return postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-review"})
+	}
+
+	code, result := review("rv1", "APPROVED")
+	require.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "NOT_ASSIGNED", result["error"].(map[string]interface{})["code"])
+
+	code, _ = review("rv2", "CHANGES_REQUESTED")
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = review("rv3", "APPROVED")
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result = merge()
+	require.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "MERGE_BLOCKED", result["error"].(map[string]interface{})["code"])
+
+	code, _ = review("rv2", "APPROVED")
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = review("rv3", "COMMENTED")
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result = merge()
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "MERGED", result["pr"].(map[string]interface{})["status"])
+
+	code, result = review("rv2", "APPROVED")
+	require.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "PR_MERGED", result["error"].(map[string]interface{})["code"])
+}
@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestReviewCapacity(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "search",
+		"members": []map[string]interface{}{
+			{"user_id": "sc1", "username": "SC1", "is_active": true},
+			{"user_id": "sc2", "username": "SC2", "is_active": true},
+			{"user_id": "sc3", "username": "SC3", "is_active": true},
+			{"user_id": "sc4", "username": "SC4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	setCapacity := func(t *testing.T, userID string, limit interface{}) {
+		t.Helper()
+		code, result := postPR(t, env, "/users/setCapacity", map[string]interface{}{"user_id": userID, "max_open_reviews": limit})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, limit, result["user"].(map[string]interface{})["max_open_reviews"])
+	}
+	setCapacity(t, "sc2", float64(1))
+	setCapacity(t, "sc3", float64(1))
+	setCapacity(t, "sc4", float64(0))
+
+	t.Run("negative limit", func(t *testing.T) {
+		code, _ := postPR(t, env, "/users/setCapacity", map[string]interface{}{"user_id": "sc2", "max_open_reviews": -1})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("users at capacity are skipped", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-cap-1", "pull_request_name": "First", "author_id": "sc1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		assert.ElementsMatch(t, []interface{}{"sc2", "sc3"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+	})
+
+	t.Run("getReview reports load", func(t *testing.T) {
+		code, result := getJSON(t, env, "/users/getReview?user_id=sc2")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(1), result["open_reviews"])
+		assert.Equal(t, float64(1), result["max_open_reviews"])
+
+		code, result = getJSON(t, env, "/users/getReview?user_id=sc1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(0), result["open_reviews"])
+		assert.NotContains(t, result, "max_open_reviews")
+	})
+
+	t.Run("nobody has spare capacity", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-cap-2", "pull_request_name": "Second", "author_id": "sc1",
+		})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "NO_CAPACITY", result["error"].(map[string]interface{})["code"])
+
+		code, result = postPR(t, env, "/pullRequest/reassign", map[string]string{
+			"pull_request_id": "pr-cap-1", "old_user_id": "sc2",
+		})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "NO_CAPACITY", result["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("deactivation skips users at capacity", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/deactivateMembers", map[string]interface{}{
+			"team_name": "search", "user_ids": []string{"sc2"},
+		})
+		require.Equal(t, http.StatusOK, code)
+		reassigned := result["reassigned_prs"].([]interface{})
+		require.Len(t, reassigned, 1)
+		assert.Equal(t, "", reassigned[0].(map[string]interface{})["new_reviewer"])
+	})
+
+	t.Run("removing limit frees the reviewer", func(t *testing.T) {
+		setCapacity(t, "sc4", nil)
+
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-cap-3", "pull_request_name": "Third", "author_id": "sc1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, []interface{}{"sc4"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+	})
+}
@@ -0,0 +1,220 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestReviewSLA(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "sla",
+		"members": []map[string]interface{}{
+			{"user_id": "sl1", "username": "SL1", "is_active": true},
+			{"user_id": "sl2", "username": "SL2", "is_active": true},
+			{"user_id": "sl3", "username": "SL3", "is_active": true},
+			{"user_id": "sl4", "username": "SL4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var selectors *usecases.ReviewerSelectors
+	picker := func(teamName string) en.ReviewerPicker { return selectors.ForTeam(teamName).Select }
+	// назначения нельзя состарить через API, поэтому просрочка проверяется на уровне хранилища со сдвинутым временем
+	later := time.Now().UTC().Add(2 * time.Hour)
+
+	t.Run("settings", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "sla", "min_reviewers": 1, "max_reviewers": 1, "review_sla_minutes": 60,
+		})
+		require.Equal(t, http.StatusOK, code)
+		settings := result["settings"].(map[string]interface{})
+		assert.Equal(t, float64(60), settings["review_sla_minutes"])
+		assert.Equal(t, "reassign", settings["sla_escalation"])
+
+		code, _ = postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "sla", "min_reviewers": 1, "max_reviewers": 1, "sla_escalation": "notify",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		// без полей SLA прежние значения сохраняются
+		code, result = postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "sla", "min_reviewers": 1, "max_reviewers": 1,
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(60), result["settings"].(map[string]interface{})["review_sla_minutes"])
+	})
+
+	reviewers := make(map[string]string)
+	for _, id := range []string{"pr-sla-1", "pr-sla-2"} {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": id, "pull_request_name": "SLA", "author_id": "sl1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		reviewers[id] = result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})[0].(string)
+	}
+	code, _ = postPR(t, env, "/pullRequest/review", map[string]string{
+		"pull_request_id": "pr-sla-2", "reviewer_id": reviewers["pr-sla-2"], "verdict": "COMMENTED",
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("overdue list", func(t *testing.T) {
+		code, result := getJSON(t, env, "/pullRequest/overdue?team_name=sla")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, result["overdue"])
+
+		code, _ = getJSON(t, env, "/pullRequest/overdue?team_name=ghost")
+		assert.Equal(t, http.StatusNotFound, code)
+
+		// ревьювер с вердиктом не просрочен
+		overdue, err := env.Storage.ListOverdueReviews(context.Background(), "sla", later)
+		require.NoError(t, err)
+		require.Len(t, overdue, 1)
+		assert.Equal(t, "pr-sla-1", overdue[0].PullRequestID)
+		assert.Equal(t, reviewers["pr-sla-1"], overdue[0].ReviewerID)
+		assert.Equal(t, overdue[0].AssignedAt.Add(time.Hour), overdue[0].DueAt)
+	})
+
+	t.Run("escalation reassigns", func(t *testing.T) {
+		escalations, err := env.Storage.EscalateOverdueReviews(context.Background(), later, 10, picker)
+		require.NoError(t, err)
+		require.Len(t, escalations, 1)
+		e := escalations[0]
+		assert.Equal(t, "pr-sla-1", e.PullRequestID)
+		assert.Equal(t, en.EscalationReassign, e.Action)
+		assert.Equal(t, reviewers["pr-sla-1"], e.OldReviewer)
+		require.NotEmpty(t, e.NewReviewer)
+		assert.NotEqual(t, e.OldReviewer, e.NewReviewer)
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-sla-1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{e.NewReviewer}, pr["pr"].(map[string]interface{})["assigned_reviewers"])
+
+		code, history := getJSON(t, env, "/pullRequest/history?pull_request_id=pr-sla-1")
+		require.Equal(t, http.StatusOK, code)
+		events := history["events"].([]interface{})
+		last := events[len(events)-1].(map[string]interface{})
+		assert.Equal(t, "REVIEWER_REASSIGNED", last["type"])
+		assert.Equal(t, "review_sla_exceeded", last["reason"])
+
+		// новое назначение получает собственный SLA
+		escalations, err = env.Storage.EscalateOverdueReviews(context.Background(), time.Now().UTC(), 10, picker)
+		require.NoError(t, err)
+		assert.Empty(t, escalations)
+	})
+
+	t.Run("escalation adds reviewer", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "sla", "min_reviewers": 1, "max_reviewers": 1, "sla_escalation": "add_reviewer",
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-sla-3", "pull_request_name": "SLA", "author_id": "sl1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		old := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})[0].(string)
+
+		escalations, err := env.Storage.EscalateOverdueReviews(context.Background(), later, 10, picker)
+		require.NoError(t, err)
+		var added *en.ReviewEscalation
+		for i := range escalations {
+			if escalations[i].PullRequestID == "pr-sla-3" {
+				added = &escalations[i]
+			}
+		}
+		require.NotNil(t, added)
+		assert.Equal(t, en.EscalationAddReviewer, added.Action)
+		assert.Equal(t, old, added.OldReviewer)
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-sla-3")
+		require.Equal(t, http.StatusOK, code)
+		assert.ElementsMatch(t, []interface{}{old, added.NewReviewer}, pr["pr"].(map[string]interface{})["assigned_reviewers"])
+
+		// эскалированное назначение остается в списке просроченных, но повторно не эскалируется
+		overdue, err := env.Storage.ListOverdueReviews(context.Background(), "sla", later)
+		require.NoError(t, err)
+		for _, o := range overdue {
+			if o.PullRequestID == "pr-sla-3" && o.ReviewerID == old {
+				assert.NotNil(t, o.EscalatedAt)
+			}
+		}
+	})
+
+	t.Run("scheduler", func(t *testing.T) {
+		scheduler, err := usecases.NewReviewSLAScheduler(env.Storage, nil, usecases.ReviewSLASchedulerConfig{})
+		require.NoError(t, err)
+
+		escalations, err := scheduler.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, escalations)
+	})
+}
+
+//nolint:funlen
+func TestReviewSLAEscalationFreesCapacity(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "slacap",
+		"members": []map[string]interface{}{
+			{"user_id": "sc-a", "username": "A", "is_active": true},
+			{"user_id": "sc-b", "username": "B", "is_active": true},
+		},
+		"settings": map[string]interface{}{"min_reviewers": 0, "max_reviewers": 1},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/team/settings", map[string]interface{}{"team_name": "slacap", "review_sla_minutes": 60})
+	require.Equal(t, http.StatusOK, code)
+	for _, id := range []string{"sc-a", "sc-b"} {
+		code, _ = postPR(t, env, "/users/setCapacity", map[string]interface{}{"user_id": id, "max_open_reviews": 1})
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	// pr-cap-1: автор sc-a, ревьювер sc-b - единственный кандидат
+	code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-cap-1", "pull_request_name": "Cap 1", "author_id": "sc-a",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, []interface{}{"sc-b"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	code, _ = postPR(t, env, "/team/members/add", map[string]interface{}{
+		"team_name": "slacap",
+		"members":   []map[string]interface{}{{"user_id": "sc-c", "username": "C", "is_active": true}},
+	})
+	require.Equal(t, http.StatusOK, code)
+	code, _ = postPR(t, env, "/users/setCapacity", map[string]interface{}{"user_id": "sc-c", "max_open_reviews": 1})
+	require.Equal(t, http.StatusOK, code)
+
+	// pr-cap-2: автор sc-c, sc-b уже на лимите, поэтому ревьювер sc-a
+	code, result = postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-cap-2", "pull_request_name": "Cap 2", "author_id": "sc-c",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, []interface{}{"sc-a"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	picker := func(teamName string) en.ReviewerPicker {
+		return (*usecases.ReviewerSelectors)(nil).ForTeam(teamName).Select
+	}
+	escalations, err := env.Storage.EscalateOverdueReviews(context.Background(), time.Now().UTC().Add(2*time.Hour), 10, picker)
+	require.NoError(t, err)
+	require.Len(t, escalations, 2)
+
+	// sc-b передает pr-cap-1 свободному sc-c и сам освобождает место под лимит для pr-cap-2
+	assert.Equal(t, "pr-cap-1", escalations[0].PullRequestID)
+	assert.Equal(t, "sc-c", escalations[0].NewReviewer)
+	assert.Equal(t, "pr-cap-2", escalations[1].PullRequestID)
+	assert.Equal(t, "sc-b", escalations[1].NewReviewer)
+}
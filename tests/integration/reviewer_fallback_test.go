@@ -0,0 +1,184 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	en "github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestReviewerFallback(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "mobile",
+		"members": []map[string]interface{}{
+			{"user_id": "mb1", "username": "MB1", "is_active": true},
+			{"user_id": "mb2", "username": "MB2", "is_active": false},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "design",
+		"members": []map[string]interface{}{
+			{"user_id": "ds1", "username": "DS1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "web",
+		"members": []map[string]interface{}{
+			{"user_id": "wb1", "username": "WB1", "is_active": true},
+			{"user_id": "wb2", "username": "WB2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("without fallback no reviewers", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-fb-0", "pull_request_name": "Alone", "author_id": "mb1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		assert.Empty(t, result["pr"].(map[string]interface{})["assigned_reviewers"])
+	})
+
+	t.Run("invalid fallbacks are rejected", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 0, "max_reviewers": 2, "fallback_teams": []string{"mobile"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 0, "max_reviewers": 2, "fallback_teams": []string{"ghost"},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "NOT_FOUND", result["error"].(map[string]interface{})["code"])
+	})
+
+	code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+		"team_name": "mobile", "min_reviewers": 1, "max_reviewers": 2, "fallback_teams": []string{"design", "web"},
+	})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{"design", "web"}, result["settings"].(map[string]interface{})["fallback_teams"])
+
+	t.Run("settings without fallbacks keep the list", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 1, "max_reviewers": 2,
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{"design", "web"}, result["settings"].(map[string]interface{})["fallback_teams"])
+	})
+
+	var webReviewer string
+	t.Run("reviewers come from fallback teams in order", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-fb-1", "pull_request_name": "Fallback", "author_id": "mb1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		pr := result["pr"].(map[string]interface{})
+		reviewers := pr["assigned_reviewers"].([]interface{})
+		require.Len(t, reviewers, 2)
+		assert.Contains(t, reviewers, "ds1")
+
+		teams := pr["reviewer_teams"].(map[string]interface{})
+		assert.Equal(t, "design", teams["ds1"])
+		for _, r := range reviewers {
+			if r != "ds1" {
+				webReviewer = r.(string)
+			}
+		}
+		assert.Equal(t, "web", teams[webReviewer])
+
+		code, got := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-fb-1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, teams, got["pr"].(map[string]interface{})["reviewer_teams"])
+	})
+
+	t.Run("reassign uses fallback team", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/reassign", map[string]string{
+			"pull_request_id": "pr-fb-1", "old_user_id": webReviewer,
+		})
+		require.Equal(t, http.StatusOK, code)
+		replacement := result["replaced_by"].(string)
+		assert.Contains(t, []string{"wb1", "wb2"}, replacement)
+		assert.NotEqual(t, webReviewer, replacement)
+		assert.Equal(t, "web", result["replaced_by_team"])
+	})
+
+	t.Run("own team member is preferred", func(t *testing.T) {
+		code, _ := postPR(t, env, "/users/setIsActive", map[string]interface{}{"user_id": "mb2", "is_active": true})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/pullRequest/reassign", map[string]string{
+			"pull_request_id": "pr-fb-1", "old_user_id": "ds1",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "mb2", result["replaced_by"])
+		assert.Equal(t, "mobile", result["replaced_by_team"])
+	})
+
+	t.Run("deactivation replaces from fallback team", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/deactivateMembers", map[string]interface{}{
+			"team_name": "mobile", "user_ids": []string{"mb2"},
+		})
+		require.Equal(t, http.StatusOK, code)
+		reassigned := result["reassigned_prs"].([]interface{})
+		require.Len(t, reassigned, 1)
+		assert.Equal(t, "ds1", reassigned[0].(map[string]interface{})["new_reviewer"])
+
+		code, got := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-fb-1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "design", got["pr"].(map[string]interface{})["reviewer_teams"].(map[string]interface{})["ds1"])
+	})
+
+	t.Run("sla escalation uses fallback team", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 1, "max_reviewers": 2, "review_sla_minutes": 60,
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, got := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-fb-1")
+		require.Equal(t, http.StatusOK, code)
+		free := "wb1"
+		for _, r := range got["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{}) {
+			if r == "wb1" {
+				free = "wb2"
+			}
+		}
+
+		// в mobile кандидатов нет, ds1 уже на PR, поэтому замена находится только в web
+		var selectors *usecases.ReviewerSelectors
+		picker := func(teamName string) en.ReviewerPicker { return selectors.ForTeam(teamName).Select }
+		escalations, err := env.Storage.EscalateOverdueReviews(context.Background(), time.Now().UTC().Add(2*time.Hour), 10, picker)
+		require.NoError(t, err)
+		var replacements []string
+		for _, e := range escalations {
+			if e.NewReviewer != "" {
+				replacements = append(replacements, e.NewReviewer)
+			}
+		}
+		require.Equal(t, []string{free}, replacements)
+
+		code, got = getJSON(t, env, "/pullRequest/get?pull_request_id=pr-fb-1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "web", got["pr"].(map[string]interface{})["reviewer_teams"].(map[string]interface{})[free])
+	})
+
+	t.Run("empty list clears fallbacks", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 0, "max_reviewers": 2, "fallback_teams": []string{},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, result["settings"].(map[string]interface{})["fallback_teams"])
+	})
+}
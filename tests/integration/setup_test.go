@@ -24,12 +24,14 @@ import (
 type TestEnv struct {
 	PostgresContainer testcontainers.Container
 	DSN               string
+	Storage           *postgres.PgxStorage
+	Service           *usecases.ServiceStorage
 	Server            *httptest.Server
 	Client            *http.Client
 	ctx               context.Context
 }
 
-func SetupTestEnv(t *testing.T) *TestEnv {
+func SetupTestEnv(t *testing.T, opts ...usecases.Option) *TestEnv {
 	ctx := context.Background()
 
 	req := testcontainers.ContainerRequest{
@@ -69,7 +71,7 @@ func SetupTestEnv(t *testing.T) *TestEnv {
 	storage, err := postgres.NewPgxClient(ctx, dsn)
 	require.NoError(t, err)
 
-	service, err := usecases.NewServiceStorage(storage)
+	service, err := usecases.NewServiceStorage(storage, opts...)
 	require.NoError(t, err)
 
 	server, err := public.NewServer(service)
@@ -80,12 +82,23 @@ func SetupTestEnv(t *testing.T) *TestEnv {
 	return &TestEnv{
 		PostgresContainer: postgresContainer,
 		DSN:               dsn,
+		Storage:           storage,
+		Service:           service,
 		Server:            testServer,
 		Client:            &http.Client{Timeout: 10 * time.Second},
 		ctx:               ctx,
 	}
 }
 
+// StartServer поднимает дополнительный сервер с опциями над тем же сервисом и базой
+func (e *TestEnv) StartServer(t *testing.T, opts ...public.ServerOption) *httptest.Server {
+	server, err := public.NewServer(e.Service, opts...)
+	require.NoError(t, err)
+	testServer := httptest.NewServer(server.GetRouter())
+	t.Cleanup(testServer.Close)
+	return testServer
+}
+
 func (e *TestEnv) Cleanup(t *testing.T) {
 	e.Server.Close()
 	if err := e.PostgresContainer.Terminate(e.ctx); err != nil {
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestReviewerSkills(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "web",
+		"members": []map[string]interface{}{
+			{"user_id": "sk1", "username": "SK1", "is_active": true},
+			{"user_id": "sk2", "username": "SK2", "is_active": true, "skills": []string{"Frontend"}},
+			{"user_id": "sk3", "username": "SK3", "is_active": true},
+			{"user_id": "sk4", "username": "SK4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("team members keep skills", func(t *testing.T) {
+		code, team := getJSON(t, env, "/team/get?team_name=web")
+		require.Equal(t, http.StatusOK, code)
+		for _, m := range team["team"].(map[string]interface{})["members"].([]interface{}) {
+			member := m.(map[string]interface{})
+			if member["user_id"] == "sk2" {
+				assert.Equal(t, []interface{}{"frontend"}, member["skills"])
+			}
+		}
+	})
+
+	t.Run("set skills", func(t *testing.T) {
+		code, result := postPR(t, env, "/users/setSkills", map[string]interface{}{
+			"user_id": "sk3", "skills": []string{"sql", "Go", "go"},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{"go", "sql"}, result["user"].(map[string]interface{})["skills"])
+
+		code, _ = postPR(t, env, "/users/setSkills", map[string]interface{}{"user_id": "ghost", "skills": []string{"go"}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("re-adding member without skills keeps them", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/members/add", map[string]interface{}{
+			"team_name": "web",
+			"members":   []map[string]interface{}{{"user_id": "sk3", "username": "SK3", "is_active": true}},
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result := postPR(t, env, "/users/setIsActive", map[string]interface{}{"user_id": "sk3", "is_active": true})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{"go", "sql"}, result["user"].(map[string]interface{})["skills"])
+	})
+
+	t.Run("experts are assigned first", func(t *testing.T) {
+		for _, tc := range []struct{ id, label, expert string }{
+			{"pr-sk-1", "frontend", "sk2"},
+			{"pr-sk-2", "SQL", "sk3"},
+		} {
+			code, result := postPR(t, env, "/pullRequest/create", map[string]interface{}{
+				"pull_request_id": tc.id, "pull_request_name": "Labeled", "author_id": "sk1",
+				"labels": []string{tc.label},
+			})
+			require.Equal(t, http.StatusCreated, code)
+			pr := result["pr"].(map[string]interface{})
+			assert.Contains(t, pr["assigned_reviewers"], tc.expert)
+
+			code, stored := getJSON(t, env, "/pullRequest/get?pull_request_id="+tc.id)
+			require.Equal(t, http.StatusOK, code)
+			assert.Len(t, stored["pr"].(map[string]interface{})["labels"], 1)
+		}
+	})
+
+	t.Run("reassign prefers expert", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/add", map[string]interface{}{
+			"team_name": "mobile",
+			"members": []map[string]interface{}{
+				{"user_id": "mb1", "username": "MB1", "is_active": true},
+				{"user_id": "mb2", "username": "MB2", "is_active": true},
+				{"user_id": "mb3", "username": "MB3", "is_active": true},
+				{"user_id": "mb4", "username": "MB4", "is_active": true, "skills": []string{"ios"}},
+			},
+		})
+		require.Equal(t, http.StatusCreated, code, result)
+
+		code, _ = postPR(t, env, "/team/settings", map[string]interface{}{
+			"team_name": "mobile", "min_reviewers": 1, "max_reviewers": 1,
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result = postPR(t, env, "/pullRequest/create", map[string]interface{}{
+			"pull_request_id": "pr-sk-3", "pull_request_name": "iOS", "author_id": "mb1", "labels": []string{"ios"},
+		})
+		require.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, []interface{}{"mb4"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+
+		code, _ = postPR(t, env, "/users/setSkills", map[string]interface{}{"user_id": "mb3", "skills": []string{"ios"}})
+		require.Equal(t, http.StatusOK, code)
+
+		code, result = postPR(t, env, "/pullRequest/reassign", map[string]interface{}{
+			"pull_request_id": "pr-sk-3", "old_user_id": "mb4",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "mb3", result["replaced_by"])
+	})
+}
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestCreateTeam_ConflictModes(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "payments",
+		"members": []map[string]interface{}{
+			{"user_id": "p1", "username": "P1", "is_active": true},
+			{"user_id": "p2", "username": "P2", "is_active": true},
+			{"user_id": "p3", "username": "P3", "is_active": true},
+			{"user_id": "p4", "username": "P4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-pay", "pull_request_name": "Pay", "author_id": "p1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	reviewers := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	stolen := reviewers[0].(string)
+
+	newTeam := func(name, mode string) map[string]interface{} {
+		return map[string]interface{}{
+			"team_name":     name,
+			"conflict_mode": mode,
+			"members": []map[string]interface{}{
+				{"user_id": stolen, "username": "Moved", "is_active": true},
+				{"user_id": "n1", "username": "N1", "is_active": true},
+			},
+		}
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/add", newTeam("billing", "reject"))
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "TEAM_MEMBER_CONFLICT", result["error"].(map[string]interface{})["code"])
+
+		code, _ = getJSON(t, env, "/team/get?team_name=billing")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/add", newTeam("billing", "dry_run"))
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, true, result["dry_run"])
+		conflicts := result["conflicts"].([]interface{})
+		require.Len(t, conflicts, 1)
+		conflict := conflicts[0].(map[string]interface{})
+		assert.Equal(t, stolen, conflict["user_id"])
+		assert.Equal(t, "payments", conflict["current_team"])
+		assert.Equal(t, []interface{}{"pr-pay"}, conflict["open_reviews"])
+		assert.Len(t, result["reassigned_prs"], 1)
+
+		code, _ = getJSON(t, env, "/team/get?team_name=billing")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-pay")
+		require.Equal(t, http.StatusOK, code)
+		assert.Contains(t, pr["pr"].(map[string]interface{})["assigned_reviewers"], stolen)
+	})
+
+	t.Run("move reassigns open reviews", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/add", newTeam("billing", "move"))
+		require.Equal(t, http.StatusCreated, code)
+		assert.Len(t, result["conflicts"], 1)
+		reassigned := result["reassigned_prs"].([]interface{})
+		require.Len(t, reassigned, 1)
+		assert.Equal(t, stolen, reassigned[0].(map[string]interface{})["old_reviewer"])
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-pay")
+		require.Equal(t, http.StatusOK, code)
+		current := pr["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+		assert.Len(t, current, 2)
+		assert.NotContains(t, current, stolen)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		code, _ := postPR(t, env, "/team/add", newTeam("other", "steal"))
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
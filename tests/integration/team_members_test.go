@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestTeamManagement(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "core",
+		"members": []map[string]interface{}{
+			{"user_id": "c1", "username": "C1", "is_active": true},
+			{"user_id": "c2", "username": "C2", "is_active": true},
+			{"user_id": "c3", "username": "C3", "is_active": true},
+			{"user_id": "c4", "username": "C4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "infra",
+		"members":   []map[string]interface{}{{"user_id": "i1", "username": "I1", "is_active": true}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-team", "pull_request_name": "Team", "author_id": "c1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	reviewers := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	moved := reviewers[0].(string)
+
+	t.Run("member of another team joins without leaving it", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/members/add", map[string]interface{}{
+			"team_name": "infra",
+			"members":   []map[string]interface{}{{"user_id": "c2", "username": "C2", "is_active": true}},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, result["team"].(map[string]interface{})["members"], 2)
+
+		code, team := getJSON(t, env, "/team/get?team_name=core")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, team["team"].(map[string]interface{})["members"], 4)
+
+		code, _ = postPR(t, env, "/team/members/remove", map[string]interface{}{
+			"team_name": "infra", "user_ids": []string{"c2"},
+		})
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("move reassigns open reviews in the old team", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/members/move", map[string]string{"user_id": moved, "team_name": "infra"})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "core", result["from_team"])
+		reassigned := result["reassigned_prs"].([]interface{})
+		require.Len(t, reassigned, 1)
+		assert.NotEmpty(t, reassigned[0].(map[string]interface{})["new_reviewer"])
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-team")
+		require.Equal(t, http.StatusOK, code)
+		current := pr["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+		assert.Len(t, current, 2)
+		assert.NotContains(t, current, moved)
+
+		code, team := getJSON(t, env, "/team/get?team_name=infra")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, team["team"].(map[string]interface{})["members"], 2)
+	})
+
+	t.Run("add and remove", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/members/add", map[string]interface{}{
+			"team_name": "infra",
+			"members":   []map[string]interface{}{{"user_id": "i2", "username": "I2", "is_active": true}},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, result["team"].(map[string]interface{})["members"], 3)
+
+		code, result = postPR(t, env, "/team/members/remove", map[string]interface{}{
+			"team_name": "infra", "user_ids": []string{"i2"},
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []interface{}{"i2"}, result["user_ids"])
+
+		code, _ = postPR(t, env, "/team/members/remove", map[string]interface{}{
+			"team_name": "infra", "user_ids": []string{"c3"},
+		})
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("rename keeps members", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/rename", map[string]string{"team_name": "infra", "new_team_name": "platform"})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "platform", result["team"].(map[string]interface{})["team_name"])
+		assert.Len(t, result["team"].(map[string]interface{})["members"], 2)
+
+		code, _ = getJSON(t, env, "/team/get?team_name=infra")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("delete only empty team", func(t *testing.T) {
+		code, result := postPR(t, env, "/team/delete", map[string]string{"team_name": "platform"})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "TEAM_NOT_EMPTY", result["error"].(map[string]interface{})["code"])
+
+		code, _ = postPR(t, env, "/team/members/remove", map[string]interface{}{
+			"team_name": "platform", "user_ids": []string{"i1", moved},
+		})
+		require.Equal(t, http.StatusOK, code)
+
+		code, _ = postPR(t, env, "/team/delete", map[string]string{"team_name": "platform"})
+		assert.Equal(t, http.StatusOK, code)
+		code, _ = getJSON(t, env, "/team/get?team_name=platform")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
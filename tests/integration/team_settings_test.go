@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestTeamSettings(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	teamPayload := map[string]interface{}{
+		"team_name": "settings-team",
+		"members": []map[string]interface{}{
+			{"user_id": "s1", "username": "S1", "is_active": true},
+			{"user_id": "s2", "username": "S2", "is_active": true},
+			{"user_id": "s3", "username": "S3", "is_active": true},
+			{"user_id": "s4", "username": "S4", "is_active": true},
+		},
+		"settings": map[string]int{"min_reviewers": 1, "max_reviewers": 3},
+	}
+	data, _ := json.Marshal(teamPayload)
+	resp, err := env.Client.Post(env.Server.URL+"/team/add", "application/json", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	t.Run("GetSettings", func(t *testing.T) {
+		resp, err := env.Client.Get(env.Server.URL + "/team/settings?team_name=settings-team")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result map[string]map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, float64(1), result["settings"]["min_reviewers"])
+		assert.Equal(t, float64(3), result["settings"]["max_reviewers"])
+	})
+
+	t.Run("CreatePRUsesMaxReviewers", func(t *testing.T) {
+		prData, _ := json.Marshal(map[string]string{
+			"pull_request_id":   "pr-s1",
+			"pull_request_name": "PR S1",
+			"author_id":         "s1",
+		})
+		resp, err := env.Client.Post(env.Server.URL+"/pullRequest/create", "application/json", bytes.NewBuffer(prData))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var result map[string]map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Len(t, result["pr"]["assigned_reviewers"], 3)
+	})
+
+	t.Run("CreatePRBelowMinimum", func(t *testing.T) {
+		updData, _ := json.Marshal(map[string]interface{}{
+			"team_name":     "settings-team",
+			"min_reviewers": 4,
+			"max_reviewers": 5,
+		})
+		resp, err := env.Client.Post(env.Server.URL+"/team/settings", "application/json", bytes.NewBuffer(updData))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		_ = resp.Body.Close()
+
+		prData, _ := json.Marshal(map[string]string{
+			"pull_request_id":   "pr-s2",
+			"pull_request_name": "PR S2",
+			"author_id":         "s1",
+		})
+		resp, err = env.Client.Post(env.Server.URL+"/pullRequest/create", "application/json", bytes.NewBuffer(prData))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		var errResp ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		assert.Equal(t, "NOT_ENOUGH_REVIEWERS", errResp.Error.Code)
+	})
+
+	t.Run("InvalidSettings", func(t *testing.T) {
+		updData, _ := json.Marshal(map[string]interface{}{
+			"team_name":     "settings-team",
+			"min_reviewers": 3,
+			"max_reviewers": 1,
+		})
+		resp, err := env.Client.Post(env.Server.URL+"/team/settings", "application/json", bytes.NewBuffer(updData))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("PartialUpdate", func(t *testing.T) {
+		updData, _ := json.Marshal(map[string]interface{}{
+			"team_name":     "settings-team",
+			"max_reviewers": 6,
+		})
+		resp, err := env.Client.Post(env.Server.URL+"/team/settings", "application/json", bytes.NewBuffer(updData))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result map[string]map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		_ = resp.Body.Close()
+		assert.Equal(t, float64(4), result["settings"]["min_reviewers"])
+		assert.Equal(t, float64(6), result["settings"]["max_reviewers"])
+
+		// min_reviewers больше сохраненного max_reviewers
+		updData, _ = json.Marshal(map[string]interface{}{
+			"team_name":     "settings-team",
+			"min_reviewers": 7,
+		})
+		resp, err = env.Client.Post(env.Server.URL+"/team/settings", "application/json", bytes.NewBuffer(updData))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/storage/postgres"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/ports/http/public"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestTracing(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	storage, err := postgres.NewPgxClient(context.Background(), env.DSN, postgres.WithQueryTracing(provider))
+	require.NoError(t, err)
+	defer storage.Close()
+	service, err := usecases.NewServiceStorage(storage)
+	require.NoError(t, err)
+	traced, err := usecases.NewTracedService(service, provider)
+	require.NoError(t, err)
+	server, err := public.NewServer(traced, public.WithTracing(provider))
+	require.NoError(t, err)
+	srv := httptest.NewServer(server.GetRouter())
+	defer srv.Close()
+
+	code, _ := doWithToken(t, http.MethodPost, srv.URL+"/team/add", "", map[string]interface{}{
+		"team_name": "tracing",
+		"members": []map[string]interface{}{
+			{"user_id": "tr1", "username": "TR1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("spans of request, use case and queries", func(t *testing.T) {
+		recorder.Reset()
+		code, _ := doWithToken(t, http.MethodGet, srv.URL+"/team/get?team_name=tracing", "", nil)
+		require.Equal(t, http.StatusOK, code)
+
+		spans := recorder.Ended()
+		byName := make(map[string]sdktrace.ReadOnlySpan)
+		for _, span := range spans {
+			byName[span.Name()] = span
+		}
+		handler, ok := byName["GET /team/get"]
+		require.True(t, ok)
+		useCase, ok := byName["ServiceStorage.GetTeam"]
+		require.True(t, ok)
+		assert.Equal(t, handler.SpanContext().SpanID(), useCase.Parent().SpanID())
+
+		var queries int
+		for _, span := range spans {
+			if span.Parent().SpanID() == useCase.SpanContext().SpanID() {
+				queries++
+				assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			}
+		}
+		assert.Positive(t, queries)
+	})
+
+	t.Run("trace id in error response", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/pullRequest/get?pull_request_id=ghost", nil)
+		require.NoError(t, err)
+		// вызывающая сторона передает свою трассировку
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", resp.Header.Get(public.TraceIDHeader))
+
+		code, result := doWithToken(t, http.MethodGet, srv.URL+"/pullRequest/get?pull_request_id=ghost", "", nil)
+		require.Equal(t, http.StatusNotFound, code)
+		traceID := result["error"].(map[string]interface{})["trace_id"]
+		assert.Len(t, traceID, 32)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		code, result := getJSON(t, env, "/pullRequest/get?pull_request_id=ghost")
+		require.Equal(t, http.StatusNotFound, code)
+		assert.NotContains(t, result["error"], "trace_id")
+	})
+}
@@ -0,0 +1,188 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/entities"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestReviewerUnavailability(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "ops",
+		"members": []map[string]interface{}{
+			{"user_id": "op1", "username": "OP1", "is_active": true},
+			{"user_id": "op2", "username": "OP2", "is_active": true},
+			{"user_id": "op3", "username": "OP3", "is_active": true},
+			{"user_id": "op4", "username": "OP4", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-vac-1", "pull_request_name": "Before vacation", "author_id": "op1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	reviewers := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	away := reviewers[0].(string)
+
+	now := time.Now()
+	var periodID float64
+
+	t.Run("invalid period", func(t *testing.T) {
+		code, _ := postPR(t, env, "/users/unavailability/create", map[string]interface{}{
+			"user_id": away, "starts_at": now.Add(time.Hour), "ends_at": now,
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("create and list", func(t *testing.T) {
+		code, result := postPR(t, env, "/users/unavailability/create", map[string]interface{}{
+			"user_id": away, "starts_at": now.Add(-time.Minute), "ends_at": now.Add(7 * 24 * time.Hour), "reason": "vacation",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		period := result["period"].(map[string]interface{})
+		assert.Equal(t, away, period["user_id"])
+		assert.Equal(t, "vacation", period["reason"])
+		periodID = period["period_id"].(float64)
+
+		code, list := getJSON(t, env, "/users/unavailability/list?user_id="+away)
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, list["periods"], 1)
+	})
+
+	t.Run("unavailable user is not assigned", func(t *testing.T) {
+		code, result := postPR(t, env, "/pullRequest/create", map[string]string{
+			"pull_request_id": "pr-vac-2", "pull_request_name": "During vacation", "author_id": "op1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+		current := result["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+		assert.Len(t, current, 2)
+		assert.NotContains(t, current, away)
+	})
+
+	t.Run("scheduler reassigns open reviews once", func(t *testing.T) {
+		scheduler, err := usecases.NewAvailabilityScheduler(env.Storage, nil, usecases.AvailabilitySchedulerConfig{})
+		require.NoError(t, err)
+
+		reassigned, err := scheduler.RunOnce(context.Background())
+		require.NoError(t, err)
+		require.Len(t, reassigned, 1)
+		assert.Equal(t, "pr-vac-1", reassigned[0].PullRequestID)
+		assert.Equal(t, away, reassigned[0].OldReviewer)
+
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-vac-1")
+		require.Equal(t, http.StatusOK, code)
+		current := pr["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+		assert.Len(t, current, 2)
+		assert.NotContains(t, current, away)
+
+		reassigned, err = scheduler.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, reassigned)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		code, _ := postPR(t, env, "/users/unavailability/delete", map[string]interface{}{"period_id": periodID})
+		require.Equal(t, http.StatusOK, code)
+
+		code, _ = postPR(t, env, "/users/unavailability/delete", map[string]interface{}{"period_id": periodID})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, list := getJSON(t, env, "/users/unavailability/list?user_id="+away)
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, list["periods"])
+	})
+}
+
+//nolint:funlen
+func TestStartUnavailabilityPeriods(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+	ctx := context.Background()
+
+	code, _ := postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "trio",
+		"members": []map[string]interface{}{
+			{"user_id": "tr1", "username": "TR1", "is_active": true},
+			{"user_id": "tr2", "username": "TR2", "is_active": true},
+			{"user_id": "tr3", "username": "TR3", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-trio", "pull_request_name": "Trio", "author_id": "tr1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	now := time.Now().UTC()
+	createPeriod := func(userID string, startsAt, endsAt time.Time) int64 {
+		period, err := env.Storage.CreateUnavailability(ctx, &entities.Unavailability{
+			UserID: userID, StartsAt: startsAt, EndsAt: endsAt,
+		})
+		require.NoError(t, err)
+		return period.PeriodID
+	}
+	processed := func(periodID int64) bool {
+		period, err := env.Storage.GetUnavailability(ctx, periodID)
+		require.NoError(t, err)
+		return period.ProcessedAt != nil
+	}
+	reviewers := func() []interface{} {
+		code, pr := getJSON(t, env, "/pullRequest/get?pull_request_id=pr-trio")
+		require.Equal(t, http.StatusOK, code)
+		return pr["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	}
+	require.ElementsMatch(t, []interface{}{"tr2", "tr3"}, reviewers())
+
+	scheduler, err := usecases.NewAvailabilityScheduler(env.Storage, nil, usecases.AvailabilitySchedulerConfig{BatchSize: 1})
+	require.NoError(t, err)
+
+	t.Run("period ended before processing is only marked", func(t *testing.T) {
+		periodID := createPeriod("tr2", now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+		reassigned, err := scheduler.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reassigned)
+		assert.True(t, processed(periodID))
+		assert.ElementsMatch(t, []interface{}{"tr2", "tr3"}, reviewers())
+	})
+
+	t.Run("future period is not claimed", func(t *testing.T) {
+		periodID := createPeriod("tr2", now.Add(time.Hour), now.Add(2*time.Hour))
+
+		reassigned, err := scheduler.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reassigned)
+		assert.False(t, processed(periodID))
+	})
+
+	t.Run("reviewer without replacement stays and periods are claimed by batch", func(t *testing.T) {
+		first := createPeriod("tr2", now.Add(-2*time.Minute), now.Add(time.Hour))
+		second := createPeriod("tr3", now.Add(-time.Minute), now.Add(time.Hour))
+
+		// в команде нет свободного кандидата: tr1 - автор, второй ревьювер уже на PR
+		reassigned, err := scheduler.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reassigned)
+		assert.True(t, processed(first))
+		assert.False(t, processed(second))
+
+		reassigned, err = scheduler.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reassigned)
+		assert.True(t, processed(second))
+		assert.ElementsMatch(t, []interface{}{"tr2", "tr3"}, reviewers())
+	})
+}
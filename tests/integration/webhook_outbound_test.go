@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/adapters/webhook"
+	"github.com/100bench/avito_tech_assignment_autumn_2025/internal/usecases"
+)
+
+//nolint:funlen
+func TestOutboundWebhooks(t *testing.T) {
+	env := SetupTestEnv(t)
+	defer env.Cleanup(t)
+
+	const secret = "s3cr3t"
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	failFirst := true
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.Equal(t, webhook.Sign(secret, ts, body), r.Header.Get(webhook.HeaderSignature))
+
+		mu.Lock()
+		defer mu.Unlock()
+		if failFirst {
+			failFirst = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload map[string]interface{}
+		_ = json.Unmarshal(body, &payload)
+		received = append(received, payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	code, result := postPR(t, env, "/webhooks/subscriptions/create", map[string]interface{}{
+		"url":         receiver.URL,
+		"secret":      secret,
+		"event_types": []string{"PR_CREATED", "STATUS_CHANGED"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	subID := int64(result["subscription"].(map[string]interface{})["subscription_id"].(float64))
+
+	code, _ = postPR(t, env, "/team/add", map[string]interface{}{
+		"team_name": "hooks",
+		"members": []map[string]interface{}{
+			{"user_id": "wh1", "username": "WH1", "is_active": true},
+			{"user_id": "wh2", "username": "WH2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-hook",
+		"pull_request_name": "Hook",
+		"author_id":         "wh1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = postPR(t, env, "/pullRequest/merge", map[string]string{"pull_request_id": "pr-hook"})
+	require.Equal(t, http.StatusOK, code)
+
+	dispatcher, err := usecases.NewWebhookDispatcher(env.Storage, webhook.NewHTTPSender(time.Second),
+		usecases.WebhookDispatcherConfig{BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	// первая попытка первой доставки падает и повторяется после backoff
+	for i := 0; i < 5; i++ {
+		_, err := dispatcher.DispatchOnce(ctx)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	require.Len(t, received, 2)
+	types := []interface{}{received[0]["type"], received[1]["type"]}
+	mu.Unlock()
+	assert.ElementsMatch(t, []interface{}{"PR_CREATED", "STATUS_CHANGED"}, types)
+
+	code, result = getJSON(t, env, "/webhooks/deliveries/list?subscription_id="+strconv.FormatInt(subID, 10)+"&status=DELIVERED")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, result["deliveries"], 2)
+}